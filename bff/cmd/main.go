@@ -14,6 +14,7 @@ import (
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/api"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
 	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
 )
 
 func main() {
@@ -27,6 +28,22 @@ func main() {
 	flag.StringVar(&cfg.AuthTokenHeader, "auth-token-header", helper.GetEnvAsString("AUTH_TOKEN_HEADER", config.DefaultAuthTokenHeader), "Header used to extract the token (e.g., Authorization)")
 	flag.StringVar(&cfg.AuthTokenPrefix, "auth-token-prefix", helper.GetEnvAsString("AUTH_TOKEN_PREFIX", config.DefaultAuthTokenPrefix), "Prefix used in the token header (e.g., 'Bearer ')")
 	flag.StringVar(&cfg.OAuthProxyTokenHeader, "oauth-proxy-token-header", helper.GetEnvAsString("OAUTH_PROXY_TOKEN_HEADER", config.DefaultOAuthProxyTokenHeader), "Header containing access token from OAuth proxy (e.g., X-forward-access-token)")
+	flag.StringVar(&cfg.AuditLogFile, "audit-log-file", helper.GetEnvAsString("AUDIT_LOG_FILE", ""), "File that audit events are appended to (defaults to stdout)")
+	flag.StringVar(&cfg.TemplatesNamespace, "templates-namespace", helper.GetEnvAsString("TEMPLATES_NAMESPACE", ""), "Namespace holding cluster-wide evaluation templates")
+	flag.StringVar(&cfg.CloudEventsSinkURL, "cloudevents-sink", helper.GetEnvAsString("K_SINK", ""), "URL that evaluation lifecycle CloudEvents are sent to, e.g. a Knative broker")
+	flag.StringVar(&cfg.WebhookAllowedCIDRs, "webhook-allowed-cidrs", helper.GetEnvAsString("WEBHOOK_ALLOWED_CIDRS", ""), "Comma separated non-public CIDRs notification webhooks may be sent to, e.g. the Service network of in-cluster receivers (default none)")
+	flag.StringVar(&cfg.ArchiveBackend, "archive-backend", helper.GetEnvAsString("ARCHIVE_BACKEND", ""), "Backend archiving evaluations: configmap, secret, file or s3 (default none)")
+	flag.StringVar(&cfg.ArchiveDir, "archive-dir", helper.GetEnvAsString("ARCHIVE_DIR", ""), "Directory of the file archive backend")
+	flag.StringVar(&cfg.ArchiveS3Endpoint, "archive-s3-endpoint", helper.GetEnvAsString("ARCHIVE_S3_ENDPOINT", ""), "Endpoint of the S3-compatible archive backend, e.g. https://s3.us-east-1.amazonaws.com")
+	flag.StringVar(&cfg.ArchiveS3Bucket, "archive-s3-bucket", helper.GetEnvAsString("ARCHIVE_S3_BUCKET", ""), "Bucket of the S3 archive backend")
+	flag.StringVar(&cfg.ArchiveS3Region, "archive-s3-region", helper.GetEnvAsString("ARCHIVE_S3_REGION", ""), "Region of the S3 archive backend (default us-east-1)")
+	flag.StringVar(&cfg.ArchiveS3Prefix, "archive-s3-prefix", helper.GetEnvAsString("ARCHIVE_S3_PREFIX", ""), "Prefix of the object keys written by the S3 archive backend")
+	flag.StringVar(&cfg.HistoryFile, "history-file", helper.GetEnvAsString("HISTORY_FILE", ""), "File recording the history of evaluations, on a persistent volume (default none)")
+	flag.StringVar(&cfg.PrometheusURL, "prometheus-url", helper.GetEnvAsString("PROMETHEUS_URL", ""), "Prometheus queried for TrustyAI metric time series, e.g. https://thanos-querier.openshift-monitoring.svc:9092 (default none)")
+	flag.StringVar(&cfg.DatasetLoaderImage, "dataset-loader-image", helper.GetEnvAsString("DATASET_LOADER_IMAGE", config.DefaultDatasetLoaderImage), "Image of the Job copying large evaluation datasets to their PVC")
+	flag.BoolVar(&cfg.EnableScheduler, "enable-scheduler", helper.GetEnvAsBool("ENABLE_SCHEDULER", true), "Run scheduled evaluations from this process")
+	flag.BoolVar(&cfg.EnableRetention, "enable-retention", helper.GetEnvAsBool("ENABLE_RETENTION", false), "Delete finished evaluations older than the retention period annotated on their namespace")
+	flag.StringVar(&cfg.LeaderElectionNamespace, "leader-election-namespace", helper.GetEnvAsString("POD_NAMESPACE", kubernetes.InClusterNamespace()), "Namespace of the Lease used to elect the replica running background workers, and of the key signing schedules (default the BFF's namespace in a cluster)")
 	flag.Parse()
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
@@ -48,6 +65,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Background workers stop when the server shuts down
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		app.RunBackgroundWorkers(workersCtx)
+	}()
+
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
 		Handler:      app.Routes(),
@@ -82,6 +107,13 @@ func main() {
 		logger.Error("server shutdown failed", "error", err)
 	}
 
+	stopWorkers()
+	<-workersDone
+
+	if err := app.Close(); err != nil {
+		logger.Error("failed to release app resources", "error", err)
+	}
+
 	logger.Info("server stopped")
 	os.Exit(0)
 
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/pkg/client"
+)
+
+// requestTimeout bounds each request to the BFF
+const requestTimeout = 30 * time.Second
+
+// newAPIClient returns a client of the BFF REST API. Requests are authenticated with a
+// bearer token, or with the kubeflow-userid header when the BFF runs with the internal or
+// mock auth method.
+func newAPIClient(server, token, user string) *client.Client {
+	opts := []client.Option{client.WithHTTPClient(&http.Client{Timeout: requestTimeout})}
+	if token != "" {
+		opts = append(opts, client.WithBearerToken(token))
+	}
+	if user != "" {
+		opts = append(opts, client.WithUserID(user))
+	}
+	return client.New(server, opts...)
+}
+
+// modelName is the model the job evaluates, as models.LMEvalJobKind.ModelName
+func modelName(job *client.LMEvalJob) string {
+	for _, arg := range job.Spec.ModelArgs {
+		if arg.Name == "model" || arg.Name == "pretrained" {
+			return arg.Value
+		}
+	}
+	return job.Spec.Model
+}
+
+// isFinished reports whether the operator stopped running the job
+func isFinished(job *client.LMEvalJob) bool {
+	state := deref(jobStatus(job).State)
+	return state == models.LMEvalJobStateComplete || state == models.LMEvalJobStateCancelled
+}
+
+// isFailed reports whether the job finished without producing results
+func isFailed(job *client.LMEvalJob) bool {
+	status := jobStatus(job)
+	return isFinished(job) && (deref(status.Reason) == models.LMEvalJobReasonFailed || deref(status.State) == models.LMEvalJobStateCancelled)
+}
+
+// displayState is the state shown to users, as models.LMEvalJobKind.DisplayState
+func displayState(job *client.LMEvalJob) string {
+	state := deref(jobStatus(job).State)
+	switch {
+	case state == "":
+		return models.LMEvalJobStatePending
+	case state == models.LMEvalJobStateComplete && isFailed(job):
+		return models.LMEvalJobReasonFailed
+	default:
+		return state
+	}
+}
+
+// jobStatus is the status of the job, empty until the operator reports one
+func jobStatus(job *client.LMEvalJob) *client.LMEvalJobStatus {
+	if job.Status == nil {
+		return &client.LMEvalJobStatus{}
+	}
+	return job.Status
+}
+
+// deref is the value of an optional field of the generated types, empty when absent
+func deref[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/pkg/client"
+)
+
+const (
+	defaultModelType    = "local-completions"
+	defaultWaitTimeout  = time.Hour
+	defaultWaitInterval = 10 * time.Second
+)
+
+// stringList is a flag that may be repeated
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (c *cli) create(ctx context.Context, args []string) error {
+	fs := c.flagSet("create", "")
+	file := fs.String("f", "", "JSON file holding the create request, - reads standard input. Flags override its fields")
+	name := fs.String("name", "", "Kubernetes name of the evaluation")
+	displayName := fs.String("display-name", "", "Display name, defaults to the name")
+	modelType := fs.String("model-type", "", "Model type, e.g. llama or openai (default "+defaultModelType+")")
+	model := fs.String("model", "", "Name of the model to evaluate")
+	modelURL := fs.String("model-url", "", "Base URL of the model inference endpoint")
+	tokenizer := fs.String("tokenizer", "", "Tokenizer of the model")
+	tokenizedRequests := fs.String("tokenized-requests", "", "Whether requests are tokenized (true or false)")
+	tasks := fs.String("tasks", "", "Comma separated list of tasks")
+	template := fs.String("template", "", "Evaluation template to apply")
+	clusterTemplate := fs.Bool("cluster-template", false, "The template is a cluster-wide template")
+	experiment := fs.String("experiment", "", "Experiment grouping the evaluation")
+	var labels, thresholds stringList
+	fs.Var(&labels, "label", "Label as key=value, may be repeated")
+	fs.Var(&thresholds, "threshold", "Quality gate threshold such as 'arc_easy.acc >= 0.7', may be repeated")
+	batchSize := fs.String("batch-size", "", "Batch size, e.g. 8 or auto")
+	limit := fs.String("limit", "", "Limit of examples per task, a count or a fraction")
+	numFewShot := fs.Int("num-fewshot", -1, "Number of few-shot examples")
+	allowOnline := fs.Bool("allow-online", false, "Allow the job to download models and datasets")
+	allowRemoteCode := fs.Bool("allow-remote-code", false, "Allow the job to run code from the model or dataset")
+	wait := fs.Bool("wait", false, "Wait for the evaluation to finish, the exit code reflects its outcome")
+	timeout := fs.Duration("timeout", defaultWaitTimeout, "How long --wait waits, 0 waits forever")
+	interval := fs.Duration("interval", defaultWaitInterval, "How often --wait checks the evaluation")
+	positional, err := c.parse(fs, args, true)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError("create", "unexpected arguments %q", positional)
+	}
+
+	request := &client.LMEvalCreateRequest{}
+	if *file != "" {
+		if err := readCreateRequest(*file, request); err != nil {
+			return &exitCodeError{code: exitUsage, err: err}
+		}
+	}
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if set["name"] {
+		request.K8sName = name
+	}
+	if set["display-name"] {
+		request.EvaluationName = *displayName
+	}
+	if set["model-type"] {
+		request.ModelType = *modelType
+	}
+	if set["model"] {
+		request.Model.Name = *model
+	}
+	if set["model-url"] {
+		request.Model.URL = modelURL
+	}
+	if set["tokenizer"] {
+		request.Model.Tokenizer = tokenizer
+	}
+	if set["tokenized-requests"] {
+		request.Model.TokenizedRequest = tokenizedRequests
+	}
+	if set["tasks"] {
+		request.Tasks = splitList(*tasks)
+	}
+	if set["template"] {
+		request.TemplateRef = &client.TemplateRef{Name: *template}
+		if *clusterTemplate {
+			request.TemplateRef.Scope = client.Ptr(models.TemplateScopeCluster)
+		}
+	}
+	if set["experiment"] {
+		request.Experiment = experiment
+	}
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return usageError("create", "invalid label %q, expected key=value", label)
+		}
+		if request.Labels == nil {
+			request.Labels = map[string]string{}
+		}
+		request.Labels[key] = value
+	}
+	if set["threshold"] {
+		request.Thresholds = thresholds
+	}
+	if set["batch-size"] {
+		request.BatchSize = batchSize
+	}
+	if set["limit"] {
+		request.Limit = limit
+	}
+	if set["num-fewshot"] {
+		request.NumFewShot = numFewShot
+	}
+	if set["allow-online"] {
+		request.AllowOnline = allowOnline
+	}
+	if set["allow-remote-code"] {
+		request.AllowRemoteCode = allowRemoteCode
+	}
+
+	if deref(request.K8sName) == "" {
+		return usageError("create", "--name is required")
+	}
+	if request.EvaluationName == "" {
+		request.EvaluationName = *request.K8sName
+	}
+	if request.ModelType == "" {
+		request.ModelType = defaultModelType
+	}
+
+	api := c.client()
+	created, err := api.CreateEvaluation(ctx, &client.CreateEvaluationParams{Namespace: c.namespace}, request)
+	if err != nil {
+		return err
+	}
+	job := &created.Data
+	if *wait {
+		fmt.Fprintf(c.stderr, "evaluation %q created\n", job.Metadata.Name)
+		return c.waitFor(ctx, api, job.Metadata.Name, *timeout, *interval)
+	}
+	if c.output == outputFormatJSON {
+		return writeJSON(c.stdout, job)
+	}
+	fmt.Fprintf(c.stdout, "evaluation %q created\n", job.Metadata.Name)
+	return nil
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	fs := c.flagSet("list", "")
+	selector := fs.String("l", "", "Label selector, e.g. trustyai.opendatahub.io/experiment=q3-release")
+	positional, err := c.parse(fs, args, false)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError("list", "unexpected arguments %q", positional)
+	}
+
+	list, err := c.client().ListEvaluations(ctx, &client.ListEvaluationsParams{Namespace: c.namespace, LabelSelector: *selector})
+	if err != nil {
+		return err
+	}
+	if c.output == outputFormatJSON {
+		return writeJSON(c.stdout, list.Data.Items)
+	}
+	return writeEvaluationsTable(c.stdout, list.Data.Items, c.namespace == "")
+}
+
+func (c *cli) get(ctx context.Context, args []string) error {
+	fs := c.flagSet("get", "NAME")
+	name, err := c.parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	evaluation, err := c.client().GetEvaluation(ctx, name, &client.GetEvaluationParams{Namespace: c.namespace})
+	if err != nil {
+		return err
+	}
+	return c.printEvaluation(evaluation)
+}
+
+func (c *cli) wait(ctx context.Context, args []string) error {
+	fs := c.flagSet("wait", "NAME")
+	timeout := fs.Duration("timeout", defaultWaitTimeout, "How long to wait, 0 waits forever")
+	interval := fs.Duration("interval", defaultWaitInterval, "How often to check the evaluation")
+	name, err := c.parseName(fs, args)
+	if err != nil {
+		return err
+	}
+	return c.waitFor(ctx, c.client(), name, *timeout, *interval)
+}
+
+func (c *cli) logs(ctx context.Context, args []string) error {
+	fs := c.flagSet("logs", "NAME")
+	tail := fs.Int("tail", 0, "Number of lines from the end of the logs to show, 0 shows all of them")
+	name, err := c.parseName(fs, args)
+	if err != nil {
+		return err
+	}
+	if *tail < 0 {
+		return usageError("logs", "--tail must not be negative")
+	}
+
+	params := &client.GetEvaluationLogsParams{Namespace: c.namespace}
+	if *tail > 0 {
+		params.TailLines = tail
+	}
+	logs, err := c.client().GetEvaluationLogs(ctx, name, params)
+	if err != nil {
+		return err
+	}
+	if c.output == outputFormatJSON {
+		return writeJSON(c.stdout, logs.Data)
+	}
+	_, err = io.WriteString(c.stdout, logs.Data.Logs)
+	return err
+}
+
+func (c *cli) results(ctx context.Context, args []string) error {
+	fs := c.flagSet("results", "NAME")
+	name, err := c.parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	evaluation, err := c.client().GetEvaluation(ctx, name, &client.GetEvaluationParams{Namespace: c.namespace})
+	if err != nil {
+		return err
+	}
+	results := deref(jobStatus(&evaluation.Data).Results)
+	if results == "" {
+		return fmt.Errorf("evaluation %q has no results yet", name)
+	}
+	metrics, err := models.ParseLMEvalResults(results)
+	if err != nil {
+		return err
+	}
+	if metrics == nil {
+		metrics = []models.LMEvalMetric{}
+	}
+
+	if c.output == outputFormatJSON {
+		return writeJSON(c.stdout, metrics)
+	}
+	return writeMetricsTable(c.stdout, metrics)
+}
+
+func (c *cli) delete(ctx context.Context, args []string) error {
+	fs := c.flagSet("delete", "NAME...")
+	names, err := c.parse(fs, args, true)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return usageError("delete", "at least one evaluation name is required")
+	}
+
+	api := c.client()
+	for _, name := range names {
+		if err := api.DeleteEvaluation(ctx, name, &client.DeleteEvaluationParams{Namespace: c.namespace}); err != nil {
+			return fmt.Errorf("failed to delete evaluation %q: %w", name, err)
+		}
+		fmt.Fprintf(c.stdout, "evaluation %q deleted\n", name)
+	}
+	return nil
+}
+
+// parseName parses the flags of a command taking a single evaluation name
+func (c *cli) parseName(fs *flag.FlagSet, args []string) (string, error) {
+	positional, err := c.parse(fs, args, true)
+	if err != nil {
+		return "", err
+	}
+	if len(positional) != 1 {
+		return "", usageError(fs.Name(), "expected exactly one evaluation name")
+	}
+	return positional[0], nil
+}
+
+// waitFor polls an evaluation until it finishes and turns its outcome into the exit code
+func (c *cli) waitFor(ctx context.Context, api *client.Client, name string, timeout, interval time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	lastState := ""
+	for {
+		evaluation, err := api.GetEvaluation(ctx, name, &client.GetEvaluationParams{Namespace: c.namespace})
+		if err != nil {
+			if ctx.Err() != nil {
+				return waitInterrupted(ctx, name)
+			}
+			return err
+		}
+
+		if isFinished(&evaluation.Data) {
+			if err := c.printEvaluation(evaluation); err != nil {
+				return err
+			}
+			return evaluationOutcome(&evaluation.Data, evaluation.Metadata)
+		}
+
+		if state := displayState(&evaluation.Data); state != lastState {
+			fmt.Fprintf(c.stderr, "evaluation %q is %s\n", name, state)
+			lastState = state
+		}
+
+		select {
+		case <-ctx.Done():
+			return waitInterrupted(ctx, name)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func waitInterrupted(ctx context.Context, name string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &exitCodeError{code: exitTimeout, err: fmt.Errorf("timed out waiting for evaluation %q", name)}
+	}
+	return fmt.Errorf("stopped waiting for evaluation %q: %w", name, ctx.Err())
+}
+
+// evaluationOutcome maps a finished evaluation to its exit code, nil when it succeeded
+func evaluationOutcome(job *client.LMEvalJob, insights *client.LMEvalJobInsights) error {
+	name := job.Metadata.Name
+	switch {
+	case deref(jobStatus(job).State) == models.LMEvalJobStateCancelled:
+		return &exitCodeError{code: exitCancelled, err: fmt.Errorf("evaluation %q was cancelled", name)}
+	case isFailed(job):
+		return &exitCodeError{code: exitFailed, err: fmt.Errorf("evaluation %q failed: %s", name, deref(job.Status.Message))}
+	case insights != nil && insights.QualityGate != nil && insights.QualityGate.Verdict == models.QualityGateVerdictFail:
+		return &exitCodeError{code: exitGateFailed, err: fmt.Errorf("evaluation %q failed its quality gate: %s", name, deref(insights.QualityGate.Reason))}
+	}
+	return nil
+}
+
+func (c *cli) printEvaluation(evaluation *client.LMEvalJobDetailEnvelope) error {
+	if c.output == outputFormatJSON {
+		return writeJSON(c.stdout, evaluation)
+	}
+	return writeEvaluationDetails(c.stdout, &evaluation.Data, evaluation.Metadata)
+}
+
+func readCreateRequest(path string, request *client.LMEvalCreateRequest) error {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		return fmt.Errorf("invalid create request in %s: %w", path, err)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
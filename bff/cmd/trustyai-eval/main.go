@@ -0,0 +1,196 @@
+// Command trustyai-eval submits and follows model evaluations through the TrustyAI
+// dashboard BFF, for CI jobs and terminals where the dashboard is not at hand.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/pkg/client"
+)
+
+// Exit codes. wait, and create --wait, report the outcome of the evaluation with them.
+const (
+	exitOK         = 0
+	exitError      = 1
+	exitUsage      = 2
+	exitFailed     = 3
+	exitCancelled  = 4
+	exitGateFailed = 5
+	exitTimeout    = 6
+)
+
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+
+	defaultServer = "http://localhost:8080"
+)
+
+const usage = `trustyai-eval manages model evaluations through the TrustyAI dashboard API.
+
+Usage:
+  trustyai-eval <command> [flags] [arguments]
+
+Commands:
+  create    Create an evaluation
+  list      List evaluations
+  get       Show an evaluation
+  wait      Wait until an evaluation finishes
+  logs      Print the logs of an evaluation
+  results   Print the metrics of an evaluation
+  delete    Delete evaluations
+
+Common flags, also read from the environment:
+  --server      BFF address (TRUSTYAI_EVAL_SERVER, default http://localhost:8080)
+  --token       bearer token (TRUSTYAI_EVAL_TOKEN)
+  --user        kubeflow-userid header for BFFs using the internal or mock auth method (TRUSTYAI_EVAL_USER)
+  -n            namespace (TRUSTYAI_EVAL_NAMESPACE)
+  -o            output format, table or json (default table)
+
+Exit codes of wait and create --wait:
+  0  the evaluation completed and its quality gate, if any, passed
+  1  the request failed
+  2  invalid usage
+  3  the evaluation failed
+  4  the evaluation was cancelled
+  5  the quality gate failed
+  6  timed out waiting
+`
+
+// exitCodeError carries the exit code of a command that did not succeed
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+func usageError(command string, format string, args ...any) error {
+	return &exitCodeError{
+		code: exitUsage,
+		err:  fmt.Errorf(format+"\nRun 'trustyai-eval %s -h' for usage.", append(args, command)...),
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// cli holds the common flags and output streams of a command
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	server    string
+	token     string
+	user      string
+	namespace string
+	output    string
+}
+
+// run executes the command in args and returns the process exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	c := &cli{stdout: stdout, stderr: stderr}
+	commands := map[string]func(context.Context, []string) error{
+		"create":  c.create,
+		"list":    c.list,
+		"get":     c.get,
+		"wait":    c.wait,
+		"logs":    c.logs,
+		"results": c.results,
+		"delete":  c.delete,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	err := command(ctx, args[1:])
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		fmt.Fprintln(stderr, "Error:", codeErr.err)
+		return codeErr.code
+	}
+	fmt.Fprintln(stderr, "Error:", err)
+	return exitError
+}
+
+// flagSet creates the flags of a command, including the common ones
+func (c *cli) flagSet(name, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: trustyai-eval %s [flags] %s\n\nFlags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.server, "server", helper.GetEnvAsString("TRUSTYAI_EVAL_SERVER", defaultServer), "BFF address")
+	fs.StringVar(&c.token, "token", helper.GetEnvAsString("TRUSTYAI_EVAL_TOKEN", ""), "Bearer token used to authenticate")
+	fs.StringVar(&c.user, "user", helper.GetEnvAsString("TRUSTYAI_EVAL_USER", ""), "User sent in the kubeflow-userid header")
+	fs.StringVar(&c.namespace, "n", helper.GetEnvAsString("TRUSTYAI_EVAL_NAMESPACE", ""), "Namespace of the evaluations")
+	fs.StringVar(&c.output, "o", outputFormatTable, "Output format: table or json")
+	return fs
+}
+
+// parse parses the command flags, which may come before or after the arguments, validates
+// the common ones and returns the arguments
+func (c *cli) parse(fs *flag.FlagSet, args []string, needsNamespace bool) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &exitCodeError{code: exitUsage, err: err}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if c.output != outputFormatTable && c.output != outputFormatJSON {
+		return nil, usageError(fs.Name(), "unsupported output format %q", c.output)
+	}
+	if needsNamespace && c.namespace == "" {
+		return nil, usageError(fs.Name(), "a namespace is required, set -n or TRUSTYAI_EVAL_NAMESPACE")
+	}
+	return positional, nil
+}
+
+func (c *cli) client() *client.Client {
+	return newAPIClient(c.server, c.token, c.user)
+}
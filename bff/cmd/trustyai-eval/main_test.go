@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const evaluationsPath = "/api/v1/evaluations"
+
+// envelope is the BFF response envelope
+type envelope struct {
+	Data     any `json:"data"`
+	Metadata any `json:"metadata,omitempty"`
+}
+
+// fakeBFF serves a single evaluation and records the requests it receives
+type fakeBFF struct {
+	job      *models.LMEvalJobKind
+	insights *models.LMEvalJobInsights
+	requests []*http.Request
+	created  *models.LMEvalCreateRequest
+}
+
+func (f *fakeBFF) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == evaluationsPath:
+		f.created = &models.LMEvalCreateRequest{}
+		_ = json.NewDecoder(r.Body).Decode(f.created)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(envelope{Data: f.job})
+	case r.Method == http.MethodGet && r.URL.Path == evaluationsPath:
+		list := &models.LMEvalJobList{Items: []models.LMEvalJobKind{*f.job}}
+		_ = json.NewEncoder(w).Encode(envelope{Data: list})
+	case r.Method == http.MethodGet && r.URL.Path == evaluationsPath+"/"+f.job.Metadata.Name:
+		_ = json.NewEncoder(w).Encode(envelope{Data: f.job, Metadata: f.insights})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"404","message":"the requested resource could not be found"}}`))
+	}
+}
+
+var _ = Describe("trustyai-eval", func() {
+	var (
+		bff            *fakeBFF
+		server         *httptest.Server
+		stdout, stderr *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		bff = &fakeBFF{job: &models.LMEvalJobKind{
+			Metadata: models.LMEvalJobMetadata{Name: "llama", Namespace: "project-1"},
+			Spec: models.LMEvalJobSpec{
+				ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: "llama-2-7b"}},
+				TaskList:  models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+			},
+			Status: &models.LMEvalJobStatus{
+				State:   models.LMEvalJobStateComplete,
+				Results: `{"results":{"arc_easy":{"acc,none":0.82,"acc_stderr,none":0.01}}}`,
+			},
+		}}
+		server = httptest.NewServer(bff)
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	execute := func(args ...string) int {
+		args = append(args, "--server", server.URL, "--token", "s3cr3t", "-n", "project-1")
+		return run(context.Background(), args, stdout, stderr)
+	}
+
+	It("authenticates with the bearer token and prints a table", func() {
+		Expect(execute("list")).To(Equal(exitOK))
+		Expect(bff.requests[0].Header.Get("Authorization")).To(Equal("Bearer s3cr3t"))
+		Expect(bff.requests[0].URL.Query().Get("namespace")).To(Equal("project-1"))
+		Expect(stdout.String()).To(ContainSubstring("NAME"))
+		Expect(stdout.String()).To(MatchRegexp(`llama\s+llama\s+llama-2-7b\s+arc_easy\s+Complete`))
+	})
+
+	It("builds the create request from flags", func() {
+		Expect(execute("create", "--name", "llama", "--model", "llama-2-7b", "--tasks", "arc_easy, hellaswag",
+			"--label", "team=nlp", "--threshold", "arc_easy.acc >= 0.7", "--num-fewshot", "0")).To(Equal(exitOK))
+		Expect(bff.created.K8sName).To(Equal("llama"))
+		Expect(bff.created.EvaluationName).To(Equal("llama"))
+		Expect(bff.created.ModelType).To(Equal(defaultModelType))
+		Expect(bff.created.Tasks).To(Equal([]string{"arc_easy", "hellaswag"}))
+		Expect(bff.created.Labels).To(HaveKeyWithValue("team", "nlp"))
+		Expect(bff.created.Thresholds).To(Equal([]string{"arc_easy.acc >= 0.7"}))
+		Expect(*bff.created.NumFewShot).To(Equal(0))
+	})
+
+	It("prints results as JSON", func() {
+		Expect(execute("results", "llama", "-o", "json")).To(Equal(exitOK))
+		var metrics []models.LMEvalMetric
+		Expect(json.Unmarshal(stdout.Bytes(), &metrics)).To(Succeed())
+		Expect(metrics).To(HaveLen(1))
+		Expect(metrics[0].Key()).To(Equal("arc_easy.acc"))
+		Expect(*metrics[0].StdErr).To(Equal(0.01))
+	})
+
+	It("reports API errors", func() {
+		Expect(execute("get", "missing")).To(Equal(exitError))
+		Expect(stderr.String()).To(ContainSubstring("could not be found (HTTP 404)"))
+	})
+
+	It("rejects invalid usage", func() {
+		Expect(execute("get")).To(Equal(exitUsage))
+		Expect(execute("list", "-o", "yaml")).To(Equal(exitUsage))
+		Expect(run(context.Background(), []string{"explode"}, stdout, stderr)).To(Equal(exitUsage))
+	})
+
+	DescribeTable("wait exits with the outcome of the evaluation",
+		func(status models.LMEvalJobStatus, verdict string, expected int) {
+			bff.job.Status = &status
+			if verdict != "" {
+				bff.insights = &models.LMEvalJobInsights{QualityGate: &models.QualityGateResult{Verdict: verdict}}
+			}
+			Expect(execute("wait", "llama", "--timeout", "300ms", "--interval", "50ms")).To(Equal(expected))
+		},
+		Entry("completed", models.LMEvalJobStatus{State: models.LMEvalJobStateComplete}, "", exitOK),
+		Entry("gate passed", models.LMEvalJobStatus{State: models.LMEvalJobStateComplete}, models.QualityGateVerdictPass, exitOK),
+		Entry("failed", models.LMEvalJobStatus{State: models.LMEvalJobStateComplete, Reason: models.LMEvalJobReasonFailed}, "", exitFailed),
+		Entry("cancelled", models.LMEvalJobStatus{State: models.LMEvalJobStateCancelled}, "", exitCancelled),
+		Entry("gate failed", models.LMEvalJobStatus{State: models.LMEvalJobStateComplete}, models.QualityGateVerdictFail, exitGateFailed),
+		Entry("timed out", models.LMEvalJobStatus{State: models.LMEvalJobStateRunning}, "", exitTimeout),
+	)
+})
+
+func TestTrustyAIEval(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "trustyai-eval suite")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/pkg/client"
+)
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeEvaluationsTable(w io.Writer, jobs []client.LMEvalJob, withNamespace bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if withNamespace {
+		fmt.Fprint(tw, "NAMESPACE\t")
+	}
+	fmt.Fprintln(tw, "NAME\tDISPLAY NAME\tMODEL\tTASKS\tSTATE\tAGE")
+	for i := range jobs {
+		job := &jobs[i]
+		if withNamespace {
+			fmt.Fprintf(tw, "%s\t", job.Metadata.Namespace)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.Metadata.Name,
+			displayName(job),
+			modelName(job),
+			strings.Join(job.Spec.TaskList.TaskNames, ","),
+			displayState(job),
+			formatAge(job.Metadata.CreationTimestamp))
+	}
+	return tw.Flush()
+}
+
+func writeEvaluationDetails(w io.Writer, job *client.LMEvalJob, insights *client.LMEvalJobInsights) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", job.Metadata.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", job.Metadata.Namespace)
+	fmt.Fprintf(tw, "Display name:\t%s\n", displayName(job))
+	if description := job.Metadata.Annotations[constants.DescriptionAnnotation]; description != "" {
+		fmt.Fprintf(tw, "Description:\t%s\n", description)
+	}
+	if tags := job.Metadata.Annotations[constants.TagsAnnotation]; tags != "" {
+		fmt.Fprintf(tw, "Tags:\t%s\n", strings.ReplaceAll(tags, ",", ", "))
+	}
+	if createdBy := job.Metadata.Annotations[constants.CreatedByAnnotation]; createdBy != "" {
+		fmt.Fprintf(tw, "Created by:\t%s\n", createdBy)
+	}
+	fmt.Fprintf(tw, "Model:\t%s\n", modelName(job))
+	fmt.Fprintf(tw, "Tasks:\t%s\n", strings.Join(job.Spec.TaskList.TaskNames, ", "))
+	fmt.Fprintf(tw, "State:\t%s\n", displayState(job))
+	if message := deref(jobStatus(job).Message); message != "" {
+		fmt.Fprintf(tw, "Message:\t%s\n", message)
+	}
+	if created := job.Metadata.CreationTimestamp; created != nil && !created.IsZero() {
+		fmt.Fprintf(tw, "Created:\t%s (%s ago)\n", created.Format(time.RFC3339), formatAge(created))
+	}
+	if job.Status != nil && job.Status.CompleteTime != nil {
+		fmt.Fprintf(tw, "Completed:\t%s\n", job.Status.CompleteTime.Format(time.RFC3339))
+	}
+	if insights != nil && insights.QualityGate != nil {
+		fmt.Fprintf(tw, "Quality gate:\t%s\n", withReason(insights.QualityGate.Verdict, deref(insights.QualityGate.Reason)))
+	}
+	if insights != nil && insights.Regression != nil {
+		fmt.Fprintf(tw, "Regression:\t%s\n", withReason(insights.Regression.Verdict, deref(insights.Regression.Reason)))
+	}
+	return tw.Flush()
+}
+
+func writeMetricsTable(w io.Writer, metrics []models.LMEvalMetric) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "TASK\tMETRIC\tFILTER\tVALUE\tSTDERR")
+	for _, metric := range metrics {
+		stderr := "-"
+		if metric.StdErr != nil {
+			stderr = fmt.Sprintf("%.4f", *metric.StdErr)
+		}
+		filter := metric.Filter
+		if filter == "" {
+			filter = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.4f\t%s\n", metric.Task, metric.Metric, filter, metric.Value, stderr)
+	}
+	return tw.Flush()
+}
+
+func displayName(job *client.LMEvalJob) string {
+	if name := job.Metadata.Annotations[constants.DisplayNameAnnotation]; name != "" {
+		return name
+	}
+	return job.Metadata.Name
+}
+
+func withReason(verdict, reason string) string {
+	if reason == "" {
+		return verdict
+	}
+	return verdict + " (" + reason + ")"
+}
+
+// formatAge renders the time since t the way kubectl does, e.g. 45s, 12m, 3h or 2d
+func formatAge(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	age := time.Since(*t)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
@@ -1,15 +1,26 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"os"
 	"path"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
 	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
 )
 
 const (
@@ -21,12 +32,37 @@ const (
 	NamespacesPath  = ApiPathPrefix + "/namespaces"
 	EvaluationsPath = ApiPathPrefix + "/evaluations"
 	ModelsPath      = ApiPathPrefix + "/models"
+	TemplatesPath   = ApiPathPrefix + "/templates"
+	SchedulesPath   = ApiPathPrefix + "/schedules"
+	ExperimentsPath = ApiPathPrefix + "/experiments"
+	ReportsPath     = ApiPathPrefix + "/reports"
+	LeaderboardPath = ApiPathPrefix + "/leaderboard"
+	OpenAPIPath     = ApiPathPrefix + "/openapi.json"
+	APIDocsPath     = "/api/docs"
+
+	NotificationSubscriptionsPath = ApiPathPrefix + "/notifications/subscriptions"
+	ArchivedEvaluationsPath       = ApiPathPrefix + "/archive/evaluations"
+	HistoryPath                   = ApiPathPrefix + "/history"
+	GuardrailsPath                = ApiPathPrefix + "/guardrails"
+	FairnessMetricsPath           = ApiPathPrefix + "/fairness/metrics"
+	DriftPath                     = ApiPathPrefix + "/drift"
+	ExplanationsPath              = ApiPathPrefix + "/explanations"
+	DataUploadPath                = ApiPathPrefix + "/data/upload"
+	DatasetsPath                  = ApiPathPrefix + "/datasets"
 )
 
 type App struct {
 	config                  config.EnvConfig
 	logger                  *slog.Logger
 	kubernetesClientFactory kubernetes.KubernetesClientFactory
+	trustyAIClientFactory   trustyai.ClientFactory
+	auditLogger             *audit.Logger
+	archive                 archive.Store
+	history                 *history.Store
+	signer                  *signing.Signer
+	webhookEgress           *notifications.Egress
+	publisher               *notifications.Publisher
+	closers                 []io.Closer
 }
 
 func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
@@ -41,10 +77,194 @@ func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
 		config:                  cfg,
 		logger:                  logger,
 		kubernetesClientFactory: k8sFactory,
+		trustyAIClientFactory:   trustyai.NewClientFactory(cfg, logger),
+	}
+
+	// Events are recorded with the service account, users are not expected to have
+	// permission to create them
+	if saClient, err := k8sFactory.GetServiceAccountClient(); err != nil {
+		logger.Warn("not recording events, service account client unavailable", slog.Any("error", err))
+	} else {
+		app.publisher = notifications.NewPublisher(saClient, logger, cfg.CloudEventsSinkURL)
+		app.closers = append(app.closers, app.publisher)
+	}
+
+	if app.webhookEgress, err = notifications.NewEgress(cfg.WebhookAllowedCIDRs); err != nil {
+		return nil, err
+	}
+
+	if app.signer, err = newSigner(cfg, k8sFactory, logger); err != nil {
+		return nil, err
+	}
+
+	if cfg.AuditLogFile != "" {
+		auditLogger, closer, err := audit.NewFileLogger(cfg.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+		app.auditLogger = auditLogger
+		app.closers = append(app.closers, closer)
+	} else {
+		app.auditLogger = audit.NewLogger(os.Stdout)
+	}
+
+	if cfg.ArchiveBackend != "" {
+		if app.archive, err = newArchiveStore(cfg, k8sFactory); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.HistoryFile != "" {
+		if app.history, err = history.Open(cfg.HistoryFile); err != nil {
+			return nil, err
+		}
+		app.closers = append(app.closers, app.history)
 	}
+
 	return app, nil
 }
 
+// newSigner loads the key shared by all replicas from the BFF's namespace. Without one, as
+// outside of a cluster, schedules and guardrails evaluations are only valid for the process
+// that saved them.
+func newSigner(cfg config.EnvConfig, k8sFactory kubernetes.KubernetesClientFactory, logger *slog.Logger) (*signing.Signer, error) {
+	if cfg.LeaderElectionNamespace == "" || cfg.AuthMethod == config.AuthMethodMock {
+		if cfg.AuthMethod != config.AuthMethodMock {
+			logger.Warn("no leader election namespace, schedules and guardrails evaluations are signed with a key of this process only")
+		}
+		return signing.NewEphemeral()
+	}
+
+	client, err := k8sFactory.GetServiceAccountClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return signing.Load(ctx, client, cfg.LeaderElectionNamespace)
+}
+
+// Close releases resources held by the app, such as the audit log file.
+func (app *App) Close() error {
+	var errs []error
+	for _, closer := range app.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// route is a handler served under ApiPathPrefix, openapi.yaml documents every one of them
+type route struct {
+	method  string
+	path    string
+	handler httprouter.Handle
+}
+
+// apiRoutes lists the routes served under ApiPathPrefix
+func (app *App) apiRoutes() []route {
+	return []route{
+		// Kubernetes routes
+		{http.MethodGet, UserPath, app.UserHandler},
+		{http.MethodGet, NamespacesPath, app.GetNamespacesHandler},
+
+		// LMEval routes
+		{http.MethodGet, EvaluationsPath, app.ListLMEvalsHandler},
+		{http.MethodPost, EvaluationsPath, app.CreateLMEvalHandler},
+		{http.MethodPost, EvaluationsPath + "/batch", app.CreateLMEvalBatchHandler},
+		{http.MethodGet, EvaluationsPath + "/:name", app.GetLMEvalHandler},
+		{http.MethodPatch, EvaluationsPath + "/:name", app.PatchLMEvalHandler},
+		{http.MethodDelete, EvaluationsPath + "/:name", app.DeleteLMEvalHandler},
+		{http.MethodGet, EvaluationsPath + "/:name/report", app.EvaluationReportHandler},
+		{http.MethodGet, EvaluationsPath + "/:name/gate", app.QualityGateHandler},
+		{http.MethodGet, EvaluationsPath + "/:name/logs", app.LMEvalLogsHandler},
+
+		// Archive routes
+		{http.MethodGet, ArchivedEvaluationsPath, app.ListArchivedEvaluationsHandler},
+		{http.MethodGet, ArchivedEvaluationsPath + "/:id", app.GetArchivedEvaluationHandler},
+
+		// Guardrails routes
+		{http.MethodGet, GuardrailsPath + "/orchestrators", app.ListGuardrailsOrchestratorsHandler},
+		{http.MethodGet, GuardrailsPath + "/evaluations", app.ListGuardrailsEvaluationsHandler},
+		{http.MethodPost, GuardrailsPath + "/evaluations", app.CreateGuardrailsEvaluationHandler},
+		{http.MethodGet, GuardrailsPath + "/evaluations/:name", app.GetGuardrailsEvaluationHandler},
+		{http.MethodDelete, GuardrailsPath + "/evaluations/:name", app.DeleteGuardrailsEvaluationHandler},
+
+		// Custom evaluation dataset routes
+		{http.MethodGet, DatasetsPath, app.ListDatasetsHandler},
+		{http.MethodPost, DatasetsPath, app.UploadDatasetHandler},
+		{http.MethodGet, DatasetsPath + "/:name", app.GetDatasetHandler},
+		{http.MethodDelete, DatasetsPath + "/:name", app.DeleteDatasetHandler},
+
+		// TrustyAI service routes
+		{http.MethodGet, FairnessMetricsPath, app.ListFairnessMetricsHandler},
+		{http.MethodPost, FairnessMetricsPath, app.CreateFairnessMetricHandler},
+		{http.MethodGet, FairnessMetricsPath + "/:id", app.GetFairnessMetricHandler},
+		{http.MethodDelete, FairnessMetricsPath + "/:id", app.DeleteFairnessMetricHandler},
+
+		{http.MethodGet, DriftPath + "/metrics", app.ListDriftMetricsHandler},
+		{http.MethodPost, DriftPath + "/metrics", app.CreateDriftMetricHandler},
+		{http.MethodGet, DriftPath + "/metrics/:id", app.GetDriftMetricHandler},
+		{http.MethodDelete, DriftPath + "/metrics/:id", app.DeleteDriftMetricHandler},
+		{http.MethodGet, DriftPath + "/metrics/:id/series", app.DriftMetricSeriesHandler},
+		{http.MethodPost, DriftPath + "/reference-tags", app.TagDataHandler},
+		{http.MethodPost, DataUploadPath, app.UploadDataHandler},
+		{http.MethodPost, ExplanationsPath, app.CreateExplanationHandler},
+
+		// History routes
+		{http.MethodGet, HistoryPath, app.EvaluationHistoryHandler},
+
+		// Report routes
+		{http.MethodGet, ReportsPath, app.EvaluationsReportHandler},
+
+		// Experiment routes
+		{http.MethodGet, ExperimentsPath, app.ListExperimentsHandler},
+		{http.MethodGet, ExperimentsPath + "/:name", app.GetExperimentHandler},
+
+		// Leaderboard routes
+		{http.MethodGet, LeaderboardPath, app.LeaderboardHandler},
+
+		// Models routes
+		{http.MethodGet, ModelsPath, app.GetModelsHandler},
+		{http.MethodGet, ModelsPath + "/:id/trends", app.ModelTrendsHandler},
+
+		// API description
+		{http.MethodGet, OpenAPIPath, app.OpenAPIHandler},
+
+		// Evaluation template routes
+		{http.MethodGet, TemplatesPath, app.ListEvaluationTemplatesHandler},
+		{http.MethodPost, TemplatesPath, app.CreateEvaluationTemplateHandler},
+		{http.MethodGet, TemplatesPath + "/:name", app.GetEvaluationTemplateHandler},
+		{http.MethodPut, TemplatesPath + "/:name", app.UpdateEvaluationTemplateHandler},
+		{http.MethodDelete, TemplatesPath + "/:name", app.DeleteEvaluationTemplateHandler},
+
+		// Evaluation schedule routes
+		{http.MethodGet, SchedulesPath, app.ListEvaluationSchedulesHandler},
+		{http.MethodPost, SchedulesPath, app.CreateEvaluationScheduleHandler},
+		{http.MethodGet, SchedulesPath + "/:name", app.GetEvaluationScheduleHandler},
+		{http.MethodPut, SchedulesPath + "/:name", app.UpdateEvaluationScheduleHandler},
+		{http.MethodDelete, SchedulesPath + "/:name", app.DeleteEvaluationScheduleHandler},
+		{http.MethodGet, SchedulesPath + "/:name/runs", app.ListEvaluationScheduleRunsHandler},
+
+		// Notification routes
+		{http.MethodGet, NotificationSubscriptionsPath, app.ListNotificationSubscriptionsHandler},
+		{http.MethodPost, NotificationSubscriptionsPath, app.CreateNotificationSubscriptionHandler},
+		{http.MethodGet, NotificationSubscriptionsPath + "/:name", app.GetNotificationSubscriptionHandler},
+		{http.MethodPut, NotificationSubscriptionsPath + "/:name", app.UpdateNotificationSubscriptionHandler},
+		{http.MethodDelete, NotificationSubscriptionsPath + "/:name", app.DeleteNotificationSubscriptionHandler},
+		{http.MethodGet, NotificationSubscriptionsPath + "/:name/deliveries", app.ListNotificationDeliveriesHandler},
+	}
+}
+
+// muxRoutes lists the routes served under ApiPathPrefix that httprouter cannot express,
+// such as custom methods on a collection
+func (app *App) muxRoutes() []route {
+	return []route{
+		{http.MethodPost, EvaluationsPath + ":bulkDelete", app.BulkDeleteLMEvalsHandler},
+	}
+}
+
 func (app *App) Routes() http.Handler {
 	// Router for /api/v1/*
 	apiRouter := httprouter.New()
@@ -52,24 +272,25 @@ func (app *App) Routes() http.Handler {
 	apiRouter.NotFound = http.HandlerFunc(app.notFoundResponse)
 	apiRouter.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
 
-	// Kubernetes routes
-	apiRouter.GET(UserPath, app.UserHandler)
-	apiRouter.GET(NamespacesPath, app.GetNamespacesHandler)
-
-	// LMEval routes
-	apiRouter.GET(EvaluationsPath, app.ListLMEvalsHandler)
-	apiRouter.POST(EvaluationsPath, app.CreateLMEvalHandler)
-	apiRouter.GET(EvaluationsPath+"/:name", app.GetLMEvalHandler)
-	apiRouter.DELETE(EvaluationsPath+"/:name", app.DeleteLMEvalHandler)
-
-	// Models routes
-	apiRouter.GET(ModelsPath, app.GetModelsHandler)
+	for _, rt := range app.apiRoutes() {
+		apiRouter.Handle(rt.method, rt.path, rt.handler)
+	}
 
 	// App Router
 	appMux := http.NewServeMux()
 
 	// handler for api calls
 	appMux.Handle(ApiPathPrefix+"/", apiRouter)
+	for _, rt := range app.muxRoutes() {
+		handler := rt.handler
+		appMux.HandleFunc(rt.method+" "+rt.path, func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r, nil)
+		})
+	}
+
+	// API reference rendered from the OpenAPI spec, public like the frontend assets
+	appMux.HandleFunc("GET "+APIDocsPath, app.APIDocsHandler)
+	appMux.HandleFunc("GET "+APIDocsPath+"/{file}", app.APIDocsAssetHandler)
 
 	//file server for the frontend file and SPA routes
 	staticDir := http.Dir(app.config.StaticAssetsDir)
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type ArchivedEvaluationListEnvelope Envelope[[]models.ArchivedEvaluationSummary, None]
+type ArchivedEvaluationEnvelope Envelope[*models.ArchivedEvaluation, None]
+
+var errArchiveDisabled = errors.New("the evaluation archive is not configured")
+
+// newArchiveStore creates the configured archive, nil when archiving is disabled. The
+// ConfigMap and Secret backends write with the service account, like the other state the
+// BFF manages in the background.
+func newArchiveStore(cfg config.EnvConfig, factory kubernetes.KubernetesClientFactory) (archive.Store, error) {
+	var client kubernetes.KubernetesClientInterface
+	if cfg.ArchiveBackend == archive.BackendConfigMap || cfg.ArchiveBackend == archive.BackendSecret {
+		var err error
+		if client, err = factory.GetServiceAccountClient(); err != nil {
+			return nil, fmt.Errorf("failed to get the archive client: %w", err)
+		}
+	}
+
+	store, err := archive.New(archive.Config{
+		Backend: cfg.ArchiveBackend,
+		Dir:     cfg.ArchiveDir,
+		S3: archive.S3Config{
+			Endpoint:        cfg.ArchiveS3Endpoint,
+			Bucket:          cfg.ArchiveS3Bucket,
+			Region:          cfg.ArchiveS3Region,
+			Prefix:          cfg.ArchiveS3Prefix,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		},
+	}, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the evaluation archive: %w", err)
+	}
+	return store, nil
+}
+
+// archiveBeforeDelete snapshots an evaluation about to be deleted, so its results stay
+// available. It does nothing when archiving is disabled.
+func (app *App) archiveBeforeDelete(ctx context.Context, job *models.LMEvalJobKind) error {
+	if app.archive == nil {
+		return nil
+	}
+	return archive.Archive(ctx, app.archive, job, models.ArchiveTriggerDeleted)
+}
+
+// authorizeEvaluationReads checks that the user can list the evaluations of the namespace
+// of a request. Stores the BFF keeps itself, like the archive and the history, are read
+// with its own credentials, so access to them follows access to the evaluations.
+func (app *App) authorizeEvaluationReads(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return "", false
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return "", false
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return "", false
+	}
+	if _, err := client.ListLMEvalJobs(ctx, identity, namespace); err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return "", false
+	}
+	return namespace, true
+}
+
+// ListArchivedEvaluationsHandler handles GET /api/v1/archive/evaluations
+func (app *App) ListArchivedEvaluationsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if app.archive == nil {
+		app.notImplementedResponse(w, r, errArchiveDisabled)
+		return
+	}
+	namespace, ok := app.authorizeEvaluationReads(w, r)
+	if !ok {
+		return
+	}
+
+	summaries, err := app.archive.List(r.Context(), namespace)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to list archived evaluations: %w", err))
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, ArchivedEvaluationListEnvelope{Data: summaries}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetArchivedEvaluationHandler handles GET /api/v1/archive/evaluations/:id
+func (app *App) GetArchivedEvaluationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if app.archive == nil {
+		app.notImplementedResponse(w, r, errArchiveDisabled)
+		return
+	}
+	namespace, ok := app.authorizeEvaluationReads(w, r)
+	if !ok {
+		return
+	}
+
+	evaluation, err := app.archive.Get(r.Context(), namespace, ps.ByName("id"))
+	if errors.Is(err, archive.ErrNotFound) {
+		app.notFoundResponse(w, r)
+		return
+	}
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to read archived evaluation: %w", err))
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, ArchivedEvaluationEnvelope{Data: evaluation}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type failingArchive struct{ archive.Store }
+
+func (failingArchive) Put(context.Context, *models.ArchivedEvaluation) error {
+	return errors.New("bucket unavailable")
+}
+
+func TestDeleteLMEvalHandlerArchivesTheEvaluation(t *testing.T) {
+	app, client := newTestApp()
+	store, err := archive.NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	app.archive = store
+
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err = client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: "llama-eval"},
+		Spec:     models.LMEvalJobSpec{TaskList: models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}}},
+	})
+	assert.NoError(t, err)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", "llama-eval",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8}}}"}}`))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	app.DeleteLMEvalHandler(w, newTestRequest("DELETE", "/api/v1/evaluations/llama-eval?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama-eval"}})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	app.ListArchivedEvaluationsHandler(w, newTestRequest("GET", "/api/v1/archive/evaluations?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list ArchivedEvaluationListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	if !assert.Len(t, list.Data, 1) {
+		return
+	}
+	assert.Equal(t, "llama-eval", list.Data[0].Name)
+	assert.Equal(t, models.LMEvalJobStateComplete, list.Data[0].State)
+	assert.Equal(t, models.ArchiveTriggerDeleted, list.Data[0].Trigger)
+
+	w = httptest.NewRecorder()
+	app.GetArchivedEvaluationHandler(w, newTestRequest("GET", "/api/v1/archive/evaluations/"+list.Data[0].ID+"?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "id", Value: list.Data[0].ID}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var item ArchivedEvaluationEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+	if !assert.NotNil(t, item.Data) {
+		return
+	}
+	assert.Equal(t, []models.LMEvalMetric{{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.8}}, item.Data.Metrics)
+	assert.Equal(t, "arc_easy", item.Data.Job.Spec.TaskList.TaskNames[0])
+
+	w = httptest.NewRecorder()
+	app.GetArchivedEvaluationHandler(w, newTestRequest("GET", "/api/v1/archive/evaluations/unknown-1?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "id", Value: "unknown-1"}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteLMEvalHandlerKeepsEvaluationsThatCannotBeArchived(t *testing.T) {
+	app, client := newTestApp()
+	app.archive = failingArchive{}
+
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err := client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: "llama-eval"},
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	app.DeleteLMEvalHandler(w, newTestRequest("DELETE", "/api/v1/evaluations/llama-eval?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama-eval"}})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	_, err = client.GetLMEvalJob(context.Background(), identity, "project-1", "llama-eval")
+	assert.NoError(t, err)
+}
+
+func TestArchivedEvaluationsRequireAnArchive(t *testing.T) {
+	app, _ := newTestApp()
+
+	w := httptest.NewRecorder()
+	app.ListArchivedEvaluationsHandler(w, newTestRequest("GET", "/api/v1/archive/evaluations?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
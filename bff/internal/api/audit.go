@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const (
+	AuditActionCreateEvaluation = audit.ActionCreateEvaluation
+	AuditActionUpdateEvaluation = audit.ActionUpdateEvaluation
+	AuditActionDeleteEvaluation = audit.ActionDeleteEvaluation
+)
+
+// resolveUser returns the user behind the request. With header based auth the
+// user is carried on the identity; with token based auth it has to be looked up.
+func (app *App) resolveUser(client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity) string {
+	if identity.UserID != "" {
+		return identity.UserID
+	}
+
+	user, err := client.GetUser(identity)
+	if err != nil || user == "" {
+		return "unknown"
+	}
+	return user
+}
+
+// resolveRunAs returns the full user name and groups behind the request, which work done
+// later by the BFF on the user's behalf is authorized as
+func (app *App) resolveRunAs(w http.ResponseWriter, r *http.Request, client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity) (models.RunAs, bool) {
+	user, err := client.GetUserInfo(identity)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to resolve the requesting user: %w", err))
+		return models.RunAs{}, false
+	}
+	return models.RunAs{User: user.UserID, Groups: user.Groups}, true
+}
+
+// auditUser returns the full user name and the groups behind the request. With header based
+// auth they are carried on the identity; with token based auth they have to be looked up.
+func (app *App) auditUser(client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity) *kubernetes.RequestIdentity {
+	if identity.UserID != "" {
+		return identity
+	}
+
+	user, err := client.GetUserInfo(identity)
+	if err != nil {
+		return &kubernetes.RequestIdentity{UserID: "unknown"}
+	}
+	return user
+}
+
+// recordAudit emits an audit event for a mutating request made by user, see auditUser. A nil
+// err marks the action as successful.
+func (app *App) recordAudit(r *http.Request, user *kubernetes.RequestIdentity, action, namespace, resource string, err error) {
+	event := audit.NewEvent(user.UserID, user.Groups, action, namespace, resource, err)
+	event.TraceID, _ = r.Context().Value(constants.TraceIdKey).(string)
+	app.auditLogger.Log(r.Context(), event)
+}
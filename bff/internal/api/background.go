@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/batch"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/guardrails"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/retention"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/scheduler"
+)
+
+// leaderElectionLeaseName is the Lease that replicas compete for to run background workers
+const leaderElectionLeaseName = "trustyai-dashboard-bff"
+
+// RunBackgroundWorkers runs the enabled background workers until ctx is cancelled.
+// In a cluster only the replica elected through a Lease in the BFF's namespace runs them.
+// The history recorder runs on every replica, each one keeps its own history.
+func (app *App) RunBackgroundWorkers(ctx context.Context) {
+	if app.history != nil {
+		var recorder sync.WaitGroup
+		defer recorder.Wait()
+
+		client, err := app.kubernetesClientFactory.GetServiceAccountClient()
+		if err != nil {
+			app.logger.Error("failed to start the history recorder", slog.Any("error", err))
+		} else {
+			recorder.Add(1)
+			go func() {
+				defer recorder.Done()
+				history.NewRecorder(client, app.history, app.logger, history.DefaultResyncInterval).Run(ctx)
+			}()
+		}
+	}
+
+	workers, err := app.backgroundWorkers()
+	if err != nil {
+		app.logger.Error("failed to start background workers", slog.Any("error", err))
+		return
+	}
+	run := func(ctx context.Context) {
+		var wg sync.WaitGroup
+		for _, worker := range workers {
+			wg.Add(1)
+			go func(worker func(context.Context)) {
+				defer wg.Done()
+				worker(ctx)
+			}(worker)
+		}
+		wg.Wait()
+	}
+
+	if app.config.LeaderElectionNamespace == "" || app.config.AuthMethod == config.AuthMethodMock {
+		if app.config.AuthMethod != config.AuthMethodMock {
+			app.logger.Warn("running background workers without leader election, run a single BFF process or set --leader-election-namespace")
+		}
+		run(ctx)
+		return
+	}
+
+	if err := kubernetes.RunWithLeaderElection(ctx, app.logger, app.config.LeaderElectionNamespace, leaderElectionLeaseName, run); err != nil {
+		app.logger.Error("leader election failed", slog.Any("error", err))
+	}
+}
+
+func (app *App) backgroundWorkers() ([]func(context.Context), error) {
+	client, err := app.kubernetesClientFactory.GetServiceAccountClient()
+	if err != nil {
+		return nil, err
+	}
+
+	workers := []func(context.Context){
+		batch.NewController(client, app.logger, batch.DefaultInterval).Run,
+		notifications.New(client, app.publisher, app.webhookEgress, app.logger, notifications.DefaultInterval).Run,
+		guardrails.NewRunner(client, app.signer, app.logger, guardrails.DefaultInterval).Run,
+	}
+
+	if app.config.EnableScheduler {
+		evaluationScheduler := scheduler.New(client, app.signer, newLMEvalJob, app.logger, scheduler.DefaultInterval)
+		evaluationScheduler.Audit = app.auditLogger
+		evaluationScheduler.OnRunCreated = func(ctx context.Context, job *models.LMEvalJobKind) {
+			app.publisher.Created(ctx, job, "schedule "+job.Metadata.Labels[constants.ScheduleLabel])
+		}
+		evaluationScheduler.BeforeDelete = app.archiveBeforeDelete
+		workers = append(workers, evaluationScheduler.Run)
+	}
+
+	if app.config.EnableRetention {
+		retentionController := retention.NewController(client, app.logger, retention.DefaultInterval)
+		retentionController.Audit = app.auditLogger
+		retentionController.BeforeDelete = app.archiveBeforeDelete
+		workers = append(workers, retentionController.Run)
+	}
+
+	if app.archive != nil {
+		workers = append(workers, archive.NewArchiver(client, app.archive, app.logger, archive.DefaultInterval).Run)
+	}
+
+	return workers, nil
+}
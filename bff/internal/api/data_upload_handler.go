@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+type DataUploadEnvelope Envelope[*models.DataUploadResult, None]
+
+const AuditActionUploadData = "trustyai-data.upload"
+
+const (
+	// maxUploadBytes bounds upload bodies, whose files are streamed to the service
+	maxUploadBytes = 1 << 30
+	// maxUploadFieldBytes bounds the form fields of an upload
+	maxUploadFieldBytes = 64 << 10
+	// uploadTimeout bounds uploads, which may outlive the timeouts of the server
+	uploadTimeout = 30 * time.Minute
+)
+
+// UploadDataHandler handles POST /api/v1/data/upload, a multipart form with the modelId,
+// tag, format and outputs fields followed by the file, which is streamed to the
+// namespace's TrustyAI service rather than read in memory
+func (app *App) UploadDataHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Now().Add(uploadTimeout))
+	_ = controller.SetWriteDeadline(time.Now().Add(uploadTimeout))
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+
+	form, err := r.MultipartReader()
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("the body must be a multipart form: %w", err))
+		return
+	}
+	var upload models.DataUpload
+	format := ""
+	var file *multipart.Part
+	for {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			app.badRequestResponse(w, r, fmt.Errorf("file is required"))
+			return
+		}
+		if err != nil {
+			app.uploadErrorResponse(w, r, fmt.Errorf("%w: %w", trustyai.ErrInvalidData, err), 0)
+			return
+		}
+		if part.FormName() == "file" {
+			file = part
+			break
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldBytes))
+		if err != nil {
+			app.uploadErrorResponse(w, r, fmt.Errorf("%w: %w", trustyai.ErrInvalidData, err), 0)
+			return
+		}
+		switch part.FormName() {
+		case "modelId":
+			upload.ModelID = strings.TrimSpace(string(value))
+		case "tag":
+			upload.Tag = strings.TrimSpace(string(value))
+		case "format":
+			format = strings.ToLower(strings.TrimSpace(string(value)))
+		case "outputs":
+			for _, output := range strings.Split(string(value), ",") {
+				if output = strings.TrimSpace(output); output != "" {
+					upload.Outputs = append(upload.Outputs, output)
+				}
+			}
+		default:
+			app.badRequestResponse(w, r, fmt.Errorf("unknown form field %q", part.FormName()))
+			return
+		}
+	}
+	defer file.Close()
+
+	format, err = uploadFormat(file, format)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if err := trustyai.ValidateDataUpload(&upload, format); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	uploadCtx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+	result := &models.DataUploadResult{ModelID: upload.ModelID, Tag: upload.Tag, Format: format}
+	if format == models.DataUploadFormatCSV {
+		result.Rows, result.Message, err = trustyai.UploadCSV(uploadCtx, service, file, upload)
+	} else {
+		result.Message, err = trustyai.UploadJSON(uploadCtx, service, file, upload)
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionUploadData, namespace, "trustyai/data/"+upload.ModelID+"/"+upload.Tag, err)
+	if err != nil {
+		app.uploadErrorResponse(w, r, err, result.Rows)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, DataUploadEnvelope{Data: result}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// uploadFormat is the format set in the form, otherwise the one of the file's extension
+// or content type
+func uploadFormat(file *multipart.Part, format string) (string, error) {
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(file.Header.Get("Content-Type"))
+		switch {
+		case strings.EqualFold(path.Ext(file.FileName()), ".csv"), mediaType == "text/csv":
+			format = models.DataUploadFormatCSV
+		case strings.EqualFold(path.Ext(file.FileName()), ".json"), mediaType == "application/json":
+			format = models.DataUploadFormatJSON
+		}
+	}
+	switch format {
+	case models.DataUploadFormatCSV, models.DataUploadFormatJSON:
+		return format, nil
+	case "":
+		return "", fmt.Errorf("the format of file %q is unknown, set format to %s or %s", file.FileName(), models.DataUploadFormatCSV, models.DataUploadFormatJSON)
+	}
+	return "", fmt.Errorf("format must be %s or %s", models.DataUploadFormatCSV, models.DataUploadFormatJSON)
+}
+
+// uploadErrorResponse reports an error of an upload, with the number of CSV rows the
+// service kept before it
+func (app *App) uploadErrorResponse(w http.ResponseWriter, r *http.Request, err error, rows int) {
+	uploaded := ""
+	if rows > 0 {
+		uploaded = fmt.Sprintf(" (%d rows were uploaded before)", rows)
+	}
+	var maxBytesError *http.MaxBytesError
+	var httpError *integrations.HTTPError
+	switch {
+	case errors.As(err, &maxBytesError):
+		app.errorResponse(w, r, &integrations.HTTPError{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			ErrorResponse: integrations.ErrorResponse{
+				Code:    strconv.Itoa(http.StatusRequestEntityTooLarge),
+				Message: fmt.Sprintf("the upload exceeds %d bytes%s", maxBytesError.Limit, uploaded),
+			},
+		})
+	case errors.Is(err, trustyai.ErrInvalidData):
+		app.badRequestResponse(w, r, fmt.Errorf("%w%s", err, uploaded))
+	case errors.As(err, &httpError) && httpError.StatusCode >= 400 && httpError.StatusCode < 500:
+		app.badRequestResponse(w, r, fmt.Errorf("TrustyAI service: %s%s", httpError.Message, uploaded))
+	default:
+		app.trustyAIErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+// formField is a field of a multipart form, a file when filename is set
+type formField struct {
+	name, filename, value string
+}
+
+func newUploadTestRequest(target string, fields ...formField) *http.Request {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	for _, field := range fields {
+		if field.filename != "" {
+			part, _ := form.CreateFormFile(field.name, field.filename)
+			_, _ = part.Write([]byte(field.value))
+		} else {
+			_ = form.WriteField(field.name, field.value)
+		}
+	}
+	_ = form.Close()
+
+	req := httptest.NewRequest("POST", target, &buf)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	identity := &kubernetes.RequestIdentity{UserID: "test-user"}
+	return req.WithContext(context.WithValue(req.Context(), constants.RequestIdentityKey, identity))
+}
+
+func TestUploadData(t *testing.T) {
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = trustyai.NewMockClientFactory()
+
+	w := httptest.NewRecorder()
+	app.UploadDataHandler(w, newUploadTestRequest("/api/v1/data/upload?namespace=project-1",
+		formField{name: "modelId", value: "credit-model"},
+		formField{name: "tag", value: "BASELINE"},
+		formField{name: "outputs", value: "approved"},
+		formField{name: "file", filename: "baseline.csv", value: "age,income,approved\n30,52000,1\n45,61000,0\n"},
+	), nil)
+	if !assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		return
+	}
+	var uploaded DataUploadEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	assert.Equal(t, models.DataUploadResult{
+		ModelID: "credit-model",
+		Tag:     "BASELINE",
+		Format:  models.DataUploadFormatCSV,
+		Rows:    2,
+		Message: "2 datapoints successfully added to credit-model data.",
+	}, *uploaded.Data)
+
+	// The uploaded inferences are a drift reference
+	w = httptest.NewRecorder()
+	app.CreateDriftMetricHandler(w, newTestRequest("POST", "/api/v1/drift/metrics?namespace=project-1", models.DriftMetricRequest{
+		ModelID: "credit-model", MetricName: models.DriftMetricMeanShift, ReferenceTag: "BASELINE",
+	}, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	app.UploadDataHandler(w, newUploadTestRequest("/api/v1/data/upload?namespace=project-1",
+		formField{name: "modelId", value: "credit-model"},
+		formField{name: "tag", value: "BASELINE"},
+		formField{name: "file", filename: "inferences", value: `{"request":{"inputs":[{"name":"age","shape":[1],"datatype":"FP64","data":[52]}]},"response":{"outputs":[{"name":"approved","shape":[1],"datatype":"INT64","data":[1]}]}}`},
+		formField{name: "format", value: "json"},
+	), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "fields after the file are not read")
+	assert.Contains(t, w.Body.String(), "the format of file")
+}
+
+func TestUploadDataErrors(t *testing.T) {
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = trustyai.NewMockClientFactory()
+	file := formField{name: "file", filename: "data.csv", value: "age,approved\n30,1\n"}
+
+	for name, fields := range map[string][]formField{
+		"no file":         {{name: "modelId", value: "credit-model"}, {name: "tag", value: "BASELINE"}},
+		"no model":        {{name: "tag", value: "BASELINE"}, {name: "outputs", value: "approved"}, file},
+		"reserved tag":    {{name: "modelId", value: "credit-model"}, {name: "tag", value: "_trustyai_unlabeled"}, {name: "outputs", value: "approved"}, file},
+		"no outputs":      {{name: "modelId", value: "credit-model"}, {name: "tag", value: "BASELINE"}, file},
+		"unknown output":  {{name: "modelId", value: "credit-model"}, {name: "tag", value: "BASELINE"}, {name: "outputs", value: "risk"}, file},
+		"unknown field":   {{name: "model", value: "credit-model"}, file},
+		"unknown format":  {{name: "modelId", value: "credit-model"}, {name: "tag", value: "BASELINE"}, {name: "format", value: "parquet"}, file},
+		"not json object": {{name: "modelId", value: "credit-model"}, {name: "tag", value: "BASELINE"}, {name: "file", filename: "data.json", value: "[]"}},
+	} {
+		w := httptest.NewRecorder()
+		app.UploadDataHandler(w, newUploadTestRequest("/api/v1/data/upload?namespace=project-1", fields...), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+
+	w := httptest.NewRecorder()
+	app.UploadDataHandler(w, newTestRequest("POST", "/api/v1/data/upload?namespace=project-1", models.DataUpload{ModelID: "credit-model"}, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "multipart form")
+}
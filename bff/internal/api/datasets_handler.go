@@ -0,0 +1,457 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/datasets"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type DatasetEnvelope Envelope[*models.Dataset, None]
+type DatasetListEnvelope Envelope[[]models.Dataset, None]
+
+const (
+	AuditActionCreateDataset = "dataset.create"
+	AuditActionDeleteDataset = "dataset.delete"
+)
+
+// ListDatasetsHandler handles GET /api/v1/datasets
+func (app *App) ListDatasetsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	configMaps, err := client.ListConfigMaps(ctx, namespace, datasets.LabelSelector())
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	list := []models.Dataset{}
+	for i := range configMaps {
+		dataset, err := datasets.FromConfigMap(&configMaps[i])
+		if err != nil {
+			app.logger.Warn("skipping unreadable dataset", slog.String("configmap", configMaps[i].Name), slog.Any("error", err))
+			continue
+		}
+		if err := datasetState(ctx, client, dataset); err != nil {
+			app.logger.Warn("failed to read the state of a dataset", slog.String("dataset", dataset.Name), slog.Any("error", err))
+		}
+		list = append(list, *dataset)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CreationTimestamp.Equal(list[j].CreationTimestamp) {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].CreationTimestamp.After(list[j].CreationTimestamp)
+	})
+
+	if err := app.WriteJSON(w, http.StatusOK, DatasetListEnvelope{Data: list}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetDatasetHandler handles GET /api/v1/datasets/:name
+func (app *App) GetDatasetHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	dataset, err := loadDataset(ctx, client, namespace, ps.ByName("name"))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, DatasetEnvelope{Data: dataset}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// UploadDatasetHandler handles POST /api/v1/datasets, a multipart form with the name,
+// displayName, format, inputColumn and outputColumn fields followed by the file. Datasets
+// up to datasets.MaxConfigMapBytes are stored in a ConfigMap, larger ones on a PVC.
+func (app *App) UploadDatasetHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Now().Add(uploadTimeout))
+	_ = controller.SetWriteDeadline(time.Now().Add(uploadTimeout))
+	r.Body = http.MaxBytesReader(w, r.Body, datasets.MaxBytes+maxUploadFieldBytes)
+
+	form, err := r.MultipartReader()
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("the body must be a multipart form: %w", err))
+		return
+	}
+	dataset := &models.Dataset{Namespace: namespace}
+	format, inputColumn, outputColumn := "", "", ""
+	var file *multipart.Part
+	for file == nil {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			app.badRequestResponse(w, r, fmt.Errorf("file is required"))
+			return
+		}
+		if err != nil {
+			app.datasetUploadErrorResponse(w, r, err)
+			return
+		}
+		if part.FormName() == "file" {
+			file = part
+			continue
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldBytes))
+		if err != nil {
+			app.datasetUploadErrorResponse(w, r, err)
+			return
+		}
+		field := strings.TrimSpace(string(value))
+		switch part.FormName() {
+		case "name":
+			dataset.Name = field
+		case "displayName":
+			dataset.DisplayName = field
+		case "format":
+			format = strings.ToLower(field)
+		case "inputColumn":
+			inputColumn = field
+		case "outputColumn":
+			outputColumn = field
+		default:
+			app.badRequestResponse(w, r, fmt.Errorf("unknown form field %q", part.FormName()))
+			return
+		}
+	}
+	defer file.Close()
+
+	if err := datasets.ValidateName(dataset.Name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if dataset.Format, err = datasetFormat(file, format); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	// The file is read twice, to be checked and then stored, so it is spooled to disk
+	spool, err := os.CreateTemp("", "dataset-*")
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to spool the dataset: %w", err))
+		return
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+	if dataset.Size, err = io.Copy(spool, io.LimitReader(file, datasets.MaxBytes+1)); err != nil {
+		app.datasetUploadErrorResponse(w, r, err)
+		return
+	}
+	if dataset.Size > datasets.MaxBytes {
+		app.datasetUploadErrorResponse(w, r, &http.MaxBytesError{Limit: datasets.MaxBytes})
+		return
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	if dataset.Columns, dataset.Rows, err = datasets.Inspect(spool, dataset.Format); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if err := datasets.SetColumns(dataset, inputColumn, outputColumn); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+	user := app.resolveUser(client, identity)
+	dataset.CreatedBy = user
+
+	var created *corev1.ConfigMap
+	if dataset.Size <= datasets.MaxConfigMapBytes {
+		dataset.Storage = models.DatasetStorageConfigMap
+		created, err = storeDatasetInConfigMap(ctx, client, namespace, dataset, spool)
+	} else {
+		dataset.Storage = models.DatasetStoragePVC
+		created, err = app.storeDatasetOnPVC(ctx, client, namespace, dataset, spool)
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateDataset, namespace, "configmaps/"+datasets.ConfigMapName(dataset.Name), err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	stored, err := datasets.FromConfigMap(created)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	if err := datasetState(ctx, client, stored); err != nil {
+		app.logger.Warn("failed to read the state of a dataset", slog.String("dataset", stored.Name), slog.Any("error", err))
+	}
+
+	if err := app.WriteJSON(w, http.StatusCreated, DatasetEnvelope{Data: stored}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteDatasetHandler handles DELETE /api/v1/datasets/:name, deleting the ConfigMap of
+// the dataset as well as its PVC and loader Job
+func (app *App) DeleteDatasetHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	dataset, err := loadDataset(ctx, client, namespace, ps.ByName("name"))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	if dataset.Storage == models.DatasetStoragePVC {
+		err = client.DeleteJob(ctx, namespace, datasets.LoaderJobName(dataset.Name))
+		if err == nil || apierrors.IsNotFound(err) {
+			err = client.DeletePersistentVolumeClaim(ctx, namespace, datasets.PVCName(dataset.Name))
+		}
+		if apierrors.IsNotFound(err) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = client.DeleteConfigMap(ctx, namespace, datasets.ConfigMapName(dataset.Name))
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteDataset, namespace, "configmaps/"+datasets.ConfigMapName(dataset.Name), err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// datasetFormat is the format set in the form, otherwise the one of the file's extension
+// or content type
+func datasetFormat(file *multipart.Part, format string) (string, error) {
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(file.Header.Get("Content-Type"))
+		extension := strings.ToLower(path.Ext(file.FileName()))
+		switch {
+		case extension == ".csv", mediaType == "text/csv":
+			format = models.DatasetFormatCSV
+		case extension == ".jsonl", extension == ".ndjson", mediaType == "application/jsonl", mediaType == "application/x-ndjson":
+			format = models.DatasetFormatJSONL
+		}
+	}
+	switch format {
+	case models.DatasetFormatCSV, models.DatasetFormatJSONL:
+		return format, nil
+	case "":
+		return "", fmt.Errorf("the format of file %q is unknown, set format to %s or %s", file.FileName(), models.DatasetFormatJSONL, models.DatasetFormatCSV)
+	}
+	return "", fmt.Errorf("format must be %s or %s", models.DatasetFormatJSONL, models.DatasetFormatCSV)
+}
+
+// storeDatasetInConfigMap stores a small dataset in the ConfigMap describing it
+func storeDatasetInConfigMap(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace string, dataset *models.Dataset, file io.Reader) (*corev1.ConfigMap, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := datasets.ToConfigMap(dataset, data)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateConfigMap(ctx, namespace, configMap)
+}
+
+// storeDatasetOnPVC creates the ConfigMap describing a large dataset, which also claims its
+// name, then its PVC and the Job loading the chunks of the file into it. What was created
+// is deleted again when a step fails, the Job together with the chunks it owns.
+func (app *App) storeDatasetOnPVC(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace string, dataset *models.Dataset, file io.Reader) (*corev1.ConfigMap, error) {
+	configMap, err := datasets.ToConfigMap(dataset, nil)
+	if err != nil {
+		return nil, err
+	}
+	created, err := client.CreateConfigMap(ctx, namespace, configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cleaning up outlives a cancelled request
+	cleanupCtx := context.WithoutCancel(ctx)
+	var cleanups []func() error
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			if err := cleanups[i](); err != nil && !apierrors.IsNotFound(err) {
+				app.logger.Warn("failed to clean up an unfinished dataset", slog.String("dataset", dataset.Name), slog.String("namespace", namespace), slog.Any("error", err))
+			}
+		}
+	}
+	cleanups = append(cleanups, func() error { return client.DeleteConfigMap(cleanupCtx, namespace, created.Name) })
+
+	if _, err := client.CreatePersistentVolumeClaim(ctx, namespace, datasets.PersistentVolumeClaim(dataset)); err != nil {
+		cleanup()
+		return nil, err
+	}
+	cleanups = append(cleanups, func() error {
+		return client.DeletePersistentVolumeClaim(cleanupCtx, namespace, datasets.PVCName(dataset.Name))
+	})
+
+	image := app.config.DatasetLoaderImage
+	if image == "" {
+		image = config.DefaultDatasetLoaderImage
+	}
+	job, err := client.CreateJob(ctx, namespace, datasets.LoaderJob(dataset, image))
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	cleanups = append(cleanups, func() error { return client.DeleteJob(cleanupCtx, namespace, job.Name) })
+
+	err = datasets.ChunkConfigMaps(dataset, file, job, func(chunk *corev1.ConfigMap) error {
+		_, err := client.CreateConfigMap(ctx, namespace, chunk)
+		return err
+	})
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	return created, nil
+}
+
+// loadDataset reads a dataset of a namespace with its state, a ConfigMap that does not
+// describe a dataset is not found
+func loadDataset(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, name string) (*models.Dataset, error) {
+	configMap, err := client.GetConfigMap(ctx, namespace, datasets.ConfigMapName(name))
+	if err != nil {
+		return nil, err
+	}
+	if configMap.Labels[constants.DatasetLabel] != name {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "datasets"}, name)
+	}
+	dataset, err := datasets.FromConfigMap(configMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := datasetState(ctx, client, dataset); err != nil {
+		return nil, err
+	}
+	return dataset, nil
+}
+
+// datasetState sets the state of a dataset stored on a PVC from its loader Job
+func datasetState(ctx context.Context, client kubernetes.KubernetesClientInterface, dataset *models.Dataset) error {
+	if dataset.Storage != models.DatasetStoragePVC {
+		return nil
+	}
+	job, err := client.GetJob(ctx, dataset.Namespace, datasets.LoaderJobName(dataset.Name))
+	if apierrors.IsNotFound(err) {
+		job, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	datasets.LoaderState(dataset, job)
+	return nil
+}
+
+// datasetUploadErrorResponse reports an error reading an uploaded dataset
+func (app *App) datasetUploadErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		app.errorResponse(w, r, &integrations.HTTPError{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			ErrorResponse: integrations.ErrorResponse{
+				Code:    strconv.Itoa(http.StatusRequestEntityTooLarge),
+				Message: fmt.Sprintf("the dataset exceeds %d bytes", datasets.MaxBytes),
+			},
+		})
+		return
+	}
+	app.badRequestResponse(w, r, fmt.Errorf("%w: %w", datasets.ErrInvalidDataset, err))
+}
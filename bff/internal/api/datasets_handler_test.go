@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/datasets"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const datasetsTarget = "/api/v1/datasets?namespace=project-1"
+
+func TestUploadDatasetToConfigMap(t *testing.T) {
+	app, client := newTestApp()
+
+	w := httptest.NewRecorder()
+	app.UploadDatasetHandler(w, newUploadTestRequest(datasetsTarget,
+		formField{name: "name", value: "support-qa"},
+		formField{name: "file", filename: "support.csv", value: "question,answer,topic\nHow do I reset my password?,Use the sign-in page.,account\n"},
+	), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var uploaded DatasetEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	assert.Equal(t, models.DatasetStorageConfigMap, uploaded.Data.Storage)
+	assert.Equal(t, models.DatasetFormatCSV, uploaded.Data.Format)
+	assert.Equal(t, models.DatasetStateReady, uploaded.Data.State)
+	assert.Equal(t, 1, uploaded.Data.Rows)
+	assert.Equal(t, models.DatasetRef{Name: "support-qa", InputColumn: "question", OutputColumn: "answer"}, uploaded.Data.Ref)
+
+	configMap, err := client.GetConfigMap(context.Background(), "project-1", "eval-dataset-support-qa")
+	if assert.NoError(t, err) {
+		assert.Contains(t, configMap.Data["dataset.csv"], "How do I reset my password?")
+	}
+
+	w = httptest.NewRecorder()
+	app.UploadDatasetHandler(w, newUploadTestRequest(datasetsTarget,
+		formField{name: "name", value: "support-qa"},
+		formField{name: "file", filename: "support.csv", value: "question,answer\nq,a\n"},
+	), nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	app.ListDatasetsHandler(w, newTestRequest("GET", datasetsTarget, nil, "test-user"), nil)
+	var list DatasetListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	if assert.Len(t, list.Data, 1) {
+		assert.Equal(t, "support-qa", list.Data[0].Name)
+	}
+}
+
+func TestUploadDatasetToPVC(t *testing.T) {
+	app, client := newTestApp()
+
+	var file strings.Builder
+	for i := 0; file.Len() <= datasets.MaxConfigMapBytes; i++ {
+		fmt.Fprintf(&file, "{\"prompt\": \"Summarise ticket %d\", \"response\": \"Ticket %d is about billing.\"}\n", i, i)
+	}
+	w := httptest.NewRecorder()
+	app.UploadDatasetHandler(w, newUploadTestRequest(datasetsTarget,
+		formField{name: "name", value: "tickets"},
+		formField{name: "file", filename: "tickets.jsonl", value: file.String()},
+	), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var uploaded DatasetEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	assert.Equal(t, models.DatasetStoragePVC, uploaded.Data.Storage)
+	assert.Equal(t, models.DatasetFormatJSONL, uploaded.Data.Format)
+	assert.Equal(t, int64(file.Len()), uploaded.Data.Size)
+	assert.Equal(t, models.DatasetRef{Name: "tickets", InputColumn: "prompt", OutputColumn: "response"}, uploaded.Data.Ref)
+
+	// The file is staged in chunks owned by the loader Job
+	job, err := client.GetJob(context.Background(), "project-1", "eval-dataset-tickets-loader")
+	if !assert.NoError(t, err) {
+		return
+	}
+	chunks, err := client.ListConfigMaps(context.Background(), "project-1", constants.DatasetChunkLabel+"=tickets")
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 2)
+	staged := ""
+	for _, name := range []string{"eval-dataset-tickets-chunk-000", "eval-dataset-tickets-chunk-001"} {
+		chunk, err := client.GetConfigMap(context.Background(), "project-1", name)
+		if assert.NoError(t, err) {
+			assert.Equal(t, job.UID, chunk.OwnerReferences[0].UID)
+			staged += string(chunk.BinaryData["chunk"])
+		}
+	}
+	assert.Equal(t, file.String(), staged)
+
+	// Deleting the dataset deletes its PVC and loader Job, and so the chunks
+	w = httptest.NewRecorder()
+	app.DeleteDatasetHandler(w, newTestRequest("DELETE", "/api/v1/datasets/tickets?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "tickets"}})
+	assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	chunks, _ = client.ListConfigMaps(context.Background(), "project-1", constants.DatasetChunkLabel+"=tickets")
+	assert.Empty(t, chunks)
+	assert.Error(t, client.DeletePersistentVolumeClaim(context.Background(), "project-1", "eval-dataset-tickets"))
+
+	w = httptest.NewRecorder()
+	app.GetDatasetHandler(w, newTestRequest("GET", "/api/v1/datasets/tickets?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "tickets"}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUploadDatasetErrors(t *testing.T) {
+	app, _ := newTestApp()
+	file := formField{name: "file", filename: "support.csv", value: "question,answer\nq,a\n"}
+
+	for name, fields := range map[string][]formField{
+		"no name":        {file},
+		"invalid name":   {{name: "name", value: "Support QA"}, file},
+		"no file":        {{name: "name", value: "support-qa"}},
+		"unknown format": {{name: "name", value: "support-qa"}, {name: "file", filename: "support.parquet", value: "PAR1"}},
+		"unknown column": {{name: "name", value: "support-qa"}, {name: "inputColumn", value: "prompt"}, file},
+		"no rows":        {{name: "name", value: "support-qa"}, {name: "file", filename: "support.csv", value: "question,answer\n"}},
+		"not json lines": {{name: "name", value: "support-qa"}, {name: "file", filename: "support.jsonl", value: "question,answer\n"}},
+		"unknown field":  {{name: "dataset", value: "support-qa"}, file},
+	} {
+		w := httptest.NewRecorder()
+		app.UploadDatasetHandler(w, newUploadTestRequest(datasetsTarget, fields...), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
+
+func TestCreateLMEvalHandlerWithDataset(t *testing.T) {
+	app, _ := newTestApp()
+
+	w := httptest.NewRecorder()
+	app.UploadDatasetHandler(w, newUploadTestRequest(datasetsTarget,
+		formField{name: "name", value: "support-qa"},
+		formField{name: "file", filename: "support.csv", value: "ticket,reply\nHow do I reset my password?,Use the sign-in page.\n"},
+	), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "Support evaluation",
+		K8sName:        "support-run",
+		ModelType:      "llama",
+		Dataset:        &models.DatasetRef{Name: "support-qa"},
+	}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "the columns cannot be guessed")
+	assert.Contains(t, w.Body.String(), "inputColumn")
+
+	createRequest.Dataset = &models.DatasetRef{Name: "support-qa", InputColumn: "ticket", OutputColumn: "reply"}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var response LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	spec := response.Data.Spec
+	assert.False(t, spec.AllowOnline)
+	if assert.Len(t, spec.TaskList.TaskRecipes, 1) {
+		assert.Contains(t, spec.TaskList.TaskRecipes[0].Card.Custom, `"ticket":"question"`)
+	}
+	if assert.NotNil(t, spec.Pod) {
+		assert.Equal(t, "eval-dataset-support-qa", spec.Pod.Volumes[0].ConfigMap.Name)
+	}
+	assert.Equal(t, "support-qa", response.Data.Metadata.Annotations[constants.DatasetAnnotation])
+
+	createRequest.K8sName = "support-run-online"
+	allowOnline := true
+	createRequest.AllowOnline = &allowOnline
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	createRequest.AllowOnline = nil
+	createRequest.Dataset = &models.DatasetRef{Name: "missing"}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `dataset \"missing\" not found`)
+}
@@ -0,0 +1,322 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+type DriftMetricEnvelope Envelope[*models.DriftMetric, None]
+type DriftMetricListEnvelope Envelope[[]models.DriftMetric, None]
+type MetricSeriesEnvelope Envelope[*models.MetricSeriesList, None]
+
+const (
+	AuditActionCreateDriftMetric = "drift-metric.create"
+	AuditActionDeleteDriftMetric = "drift-metric.delete"
+	AuditActionTagData           = "trustyai-data.tag"
+)
+
+const (
+	// defaultSeriesRange is the time range of series requested without start
+	defaultSeriesRange = 24 * time.Hour
+	// maxSeriesRange bounds the time range of series
+	maxSeriesRange = 90 * 24 * time.Hour
+)
+
+// ListDriftMetricsHandler handles GET /api/v1/drift/metrics, optionally filtered by model
+func (app *App) ListDriftMetricsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, _, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metrics, err := service.ListDriftMetrics(ctx)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	if model := r.URL.Query().Get("model"); model != "" {
+		filtered := []models.DriftMetric{}
+		for _, metric := range metrics {
+			if metric.Request.ModelID == model {
+				filtered = append(filtered, metric)
+			}
+		}
+		metrics = filtered
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, DriftMetricListEnvelope{Data: metrics}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetDriftMetricHandler handles GET /api/v1/drift/metrics/:id
+func (app *App) GetDriftMetricHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, _, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metric, ok := app.getDriftMetric(w, r, service, ps.ByName("id"))
+	if !ok {
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, DriftMetricEnvelope{Data: metric}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateDriftMetricHandler handles POST /api/v1/drift/metrics, which schedules the metric
+// on the namespace's TrustyAI service
+func (app *App) CreateDriftMetricHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var request models.DriftMetricRequest
+	if err := app.ReadJSON(w, r, &request); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := trustyai.ValidateDrift(&request); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	id, err := service.ScheduleDriftMetric(ctx, request)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateDriftMetric, namespace, trustyAIMetricResource(request.MetricName, id), err)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	metric := &models.DriftMetric{ID: id, Request: request}
+	if err := app.WriteJSON(w, http.StatusCreated, DriftMetricEnvelope{Data: metric}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteDriftMetricHandler handles DELETE /api/v1/drift/metrics/:id
+func (app *App) DeleteDriftMetricHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metric, ok := app.getDriftMetric(w, r, service, ps.ByName("id"))
+	if !ok {
+		return
+	}
+
+	err := service.DeleteDriftMetric(ctx, metric.Request.MetricName, metric.ID)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteDriftMetric, namespace, trustyAIMetricResource(metric.Request.MetricName, metric.ID), err)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DriftMetricSeriesHandler handles GET /api/v1/drift/metrics/:id/series. start and end are
+// RFC 3339 times, the last 24 hours by default.
+func (app *App) DriftMetricSeriesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	start, end, err := parseSeriesRange(r, time.Now().UTC())
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, _, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metric, ok := app.getDriftMetric(w, r, service, ps.ByName("id"))
+	if !ok {
+		return
+	}
+
+	series, err := service.MetricSeries(ctx, metric.Request.MetricName, metric.ID, start, end)
+	if errors.Is(err, trustyai.ErrSeriesUnavailable) {
+		app.errorResponse(w, r, &integrations.HTTPError{
+			StatusCode: http.StatusServiceUnavailable,
+			ErrorResponse: integrations.ErrorResponse{
+				Code:    strconv.Itoa(http.StatusServiceUnavailable),
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := &models.MetricSeriesList{ID: metric.ID, Metric: metric.Request.MetricName, Start: start, End: end, Series: series}
+	if err := app.WriteJSON(w, http.StatusOK, MetricSeriesEnvelope{Data: response}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// TagDataHandler handles POST /api/v1/drift/reference-tags, which tags ranges of the
+// stored inferences of a model, typically as the reference of drift metrics
+func (app *App) TagDataHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var request models.DataTagRequest
+	if err := app.ReadJSON(w, r, &request); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := trustyai.ValidateDataTag(&request); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	err := service.TagData(ctx, request)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionTagData, namespace, "trustyai/data/"+request.ModelID+"/"+request.Tag, err)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getDriftMetric finds a scheduled drift metric, writing a not found response when there
+// is none with that id
+func (app *App) getDriftMetric(w http.ResponseWriter, r *http.Request, service trustyai.Client, id string) (*models.DriftMetric, bool) {
+	metrics, err := service.ListDriftMetrics(r.Context())
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return nil, false
+	}
+	for i := range metrics {
+		if metrics[i].ID == id {
+			return &metrics[i], true
+		}
+	}
+	app.notFoundResponse(w, r)
+	return nil, false
+}
+
+// parseSeriesRange reads the start and end query parameters of a time series request
+func parseSeriesRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	end := now
+	if value := r.URL.Query().Get("end"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("end must be an RFC 3339 time: %w", err)
+		}
+		end = parsed.UTC()
+	}
+	start := end.Add(-defaultSeriesRange)
+	if value := r.URL.Query().Get("start"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("start must be an RFC 3339 time: %w", err)
+		}
+		start = parsed.UTC()
+	}
+	switch {
+	case !start.Before(end):
+		return time.Time{}, time.Time{}, fmt.Errorf("start must be before end")
+	case end.Sub(start) > maxSeriesRange:
+		return time.Time{}, time.Time{}, fmt.Errorf("the time range must not exceed %d days", int(maxSeriesRange.Hours()/24))
+	}
+	return start, end, nil
+}
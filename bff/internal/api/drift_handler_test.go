@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+// standInTrustyAI serves the drift endpoints of a TrustyAI service and the query API of
+// the Prometheus scraping it
+type standInTrustyAI struct {
+	mu       sync.Mutex
+	tags     map[string]bool
+	requests map[string]json.RawMessage
+}
+
+func (s *standInTrustyAI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method + " " + r.URL.Path {
+	case "POST /data/tag":
+		var body struct {
+			ModelID     string             `json:"modelId"`
+			DataTagging map[string][][]int `json:"dataTagging"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for tag := range body.DataTagging {
+			s.tags[body.ModelID+"/"+tag] = true
+		}
+		_, _ = w.Write([]byte("1000 datapoints successfully tagged."))
+	case "POST /metrics/drift/kstest/request":
+		raw, _ := io.ReadAll(r.Body)
+		var body models.DriftMetricRequest
+		_ = json.Unmarshal(raw, &body)
+		if !s.tags[body.ModelID+"/"+body.ReferenceTag] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("No data with tag " + body.ReferenceTag))
+			return
+		}
+		s.requests["ks-1"] = raw
+		_, _ = w.Write([]byte(`{"requestId":"ks-1","timestamp":"2026-03-01T10:00:00.000+00:00"}`))
+	case "GET /metrics/drift/kstest/requests":
+		requests := []map[string]any{}
+		for id, request := range s.requests {
+			requests = append(requests, map[string]any{"id": id, "request": request})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"requests": requests})
+	case "GET /metrics/drift/meanshift/requests", "GET /metrics/drift/fouriermmd/requests", "GET /metrics/drift/approxkstest/requests":
+		_, _ = w.Write([]byte(`{"requests":[]}`))
+	case "DELETE /metrics/drift/kstest/request":
+		var body struct {
+			RequestID string `json:"requestId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		delete(s.requests, body.RequestID)
+		_, _ = w.Write([]byte("Removed"))
+	case "GET /api/v1/query_range":
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"request":"ks-1","subcategory":"age"},"values":[[1772323200,"0.8"],[1772326800,"0.01"]]}]}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// standInTrustyAIFactory returns clients of a stand-in server
+type standInTrustyAIFactory struct {
+	url        string
+	prometheus bool
+}
+
+func (f *standInTrustyAIFactory) GetClient(namespace string, _ kubernetes.ServiceDetails, token string) (trustyai.Client, error) {
+	http, err := integrations.NewHTTPClient(slog.Default(), f.url)
+	if err != nil {
+		return nil, err
+	}
+	var prometheus *trustyai.PrometheusClient
+	if f.prometheus {
+		prometheus = trustyai.NewPrometheusClient(f.url, namespace, token)
+	}
+	return trustyai.NewClient(http, nil, nil, prometheus), nil
+}
+
+func TestDriftMetricLifecycle(t *testing.T) {
+	server := httptest.NewServer(&standInTrustyAI{tags: map[string]bool{}, requests: map[string]json.RawMessage{}})
+	defer server.Close()
+	factory := &standInTrustyAIFactory{url: server.URL, prometheus: true}
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = factory
+
+	request := models.DriftMetricRequest{
+		ModelID:      "credit-model",
+		MetricName:   models.DriftMetricKSTest,
+		ReferenceTag: "TRAINING",
+		FitColumns:   []string{"age"},
+	}
+
+	// The reference must be tagged first
+	w := httptest.NewRecorder()
+	app.CreateDriftMetricHandler(w, newTestRequest("POST", "/api/v1/drift/metrics?namespace=project-1", request, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "No data with tag TRAINING")
+
+	w = httptest.NewRecorder()
+	app.TagDataHandler(w, newTestRequest("POST", "/api/v1/drift/reference-tags?namespace=project-1", models.DataTagRequest{
+		ModelID: "credit-model", Tag: "TRAINING", Ranges: [][2]int{{0, 1000}},
+	}, "test-user"), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	app.CreateDriftMetricHandler(w, newTestRequest("POST", "/api/v1/drift/metrics?namespace=project-1", request, "test-user"), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var created DriftMetricEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "ks-1", created.Data.ID)
+
+	w = httptest.NewRecorder()
+	app.ListDriftMetricsHandler(w, newTestRequest("GET", "/api/v1/drift/metrics?namespace=project-1&model=credit-model", nil, "test-user"), nil)
+	var list DriftMetricListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	if assert.Len(t, list.Data, 1) {
+		assert.Equal(t, models.DriftMetricKSTest, list.Data[0].Request.MetricName)
+		assert.Equal(t, []string{"age"}, list.Data[0].Request.FitColumns)
+	}
+
+	params := httprouter.Params{{Key: "id", Value: "ks-1"}}
+	w = httptest.NewRecorder()
+	app.DriftMetricSeriesHandler(w, newTestRequest("GET", "/api/v1/drift/metrics/ks-1/series?namespace=project-1&start=2026-03-01T00:00:00Z&end=2026-03-02T00:00:00Z", nil, "test-user"), params)
+	if assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		var series MetricSeriesEnvelope
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &series))
+		if assert.Len(t, series.Data.Series, 1) {
+			assert.Equal(t, "age", series.Data.Series[0].Subcategory)
+			assert.Len(t, series.Data.Series[0].Points, 2)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	app.DeleteDriftMetricHandler(w, newTestRequest("DELETE", "/api/v1/drift/metrics/ks-1?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	app.GetDriftMetricHandler(w, newTestRequest("GET", "/api/v1/drift/metrics/ks-1?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDriftMetricSeriesWithoutPrometheus(t *testing.T) {
+	server := httptest.NewServer(&standInTrustyAI{tags: map[string]bool{}, requests: map[string]json.RawMessage{
+		"ks-1": json.RawMessage(`{"modelId":"credit-model","referenceTag":"TRAINING"}`),
+	}})
+	defer server.Close()
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = &standInTrustyAIFactory{url: server.URL}
+
+	params := httprouter.Params{{Key: "id", Value: "ks-1"}}
+	w := httptest.NewRecorder()
+	app.DriftMetricSeriesHandler(w, newTestRequest("GET", "/api/v1/drift/metrics/ks-1/series?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	for _, query := range []string{"start=yesterday", "start=2026-03-02T00:00:00Z&end=2026-03-01T00:00:00Z", "start=2025-01-01T00:00:00Z&end=2026-03-01T00:00:00Z"} {
+		w = httptest.NewRecorder()
+		app.DriftMetricSeriesHandler(w, newTestRequest("GET", "/api/v1/drift/metrics/ks-1/series?namespace=project-1&"+query, nil, "test-user"), params)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
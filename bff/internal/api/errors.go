@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 type HTTPError struct {
@@ -54,6 +55,43 @@ func (app *App) forbiddenResponse(w http.ResponseWriter, r *http.Request, messag
 	app.errorResponse(w, r, httpError)
 }
 
+func (app *App) conflictResponse(w http.ResponseWriter, r *http.Request, err error) {
+	httpError := &integrations.HTTPError{
+		StatusCode: http.StatusConflict,
+		ErrorResponse: integrations.ErrorResponse{
+			Code:    strconv.Itoa(http.StatusConflict),
+			Message: err.Error(),
+		},
+	}
+	app.errorResponse(w, r, httpError)
+}
+
+// notImplementedResponse reports that an optional feature is disabled in this deployment
+func (app *App) notImplementedResponse(w http.ResponseWriter, r *http.Request, err error) {
+	httpError := &integrations.HTTPError{
+		StatusCode: http.StatusNotImplemented,
+		ErrorResponse: integrations.ErrorResponse{
+			Code:    strconv.Itoa(http.StatusNotImplemented),
+			Message: err.Error(),
+		},
+	}
+	app.errorResponse(w, r, httpError)
+}
+
+// kubernetesErrorResponse maps well known Kubernetes API errors to the matching HTTP response
+func (app *App) kubernetesErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case apierrors.IsNotFound(err):
+		app.notFoundResponse(w, r)
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		app.conflictResponse(w, r, err)
+	case apierrors.IsForbidden(err):
+		app.forbiddenResponse(w, r, err.Error())
+	default:
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
 func (app *App) errorResponse(w http.ResponseWriter, r *http.Request, error *integrations.HTTPError) {
 
 	env := ErrorEnvelope{Error: error}
@@ -0,0 +1,444 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/gate"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/regression"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/scheduler"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type EvaluationScheduleEnvelope Envelope[*models.EvaluationSchedule, None]
+type EvaluationScheduleListEnvelope Envelope[[]models.EvaluationSchedule, None]
+type EvaluationScheduleRunListEnvelope Envelope[[]models.EvaluationScheduleRun, None]
+
+const (
+	AuditActionCreateSchedule = "schedule.create"
+	AuditActionUpdateSchedule = "schedule.update"
+	AuditActionDeleteSchedule = "schedule.delete"
+)
+
+// ListEvaluationSchedulesHandler handles GET /api/v1/schedules
+func (app *App) ListEvaluationSchedulesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	configMaps, err := client.ListConfigMaps(ctx, namespace, scheduler.LabelSelector())
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	schedules := []models.EvaluationSchedule{}
+	for i := range configMaps {
+		schedule, err := scheduler.FromConfigMap(&configMaps[i])
+		if err != nil {
+			app.logger.Warn("skipping unreadable evaluation schedule", "configmap", configMaps[i].Name, "error", err)
+			continue
+		}
+		schedules = append(schedules, *schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Name < schedules[j].Name
+	})
+
+	response := EvaluationScheduleListEnvelope{
+		Data: schedules,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetEvaluationScheduleHandler handles GET /api/v1/schedules/:name
+func (app *App) GetEvaluationScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	schedule, ok := app.getEvaluationSchedule(w, r, client, namespace, ps.ByName("name"))
+	if !ok {
+		return
+	}
+
+	response := EvaluationScheduleEnvelope{
+		Data: schedule,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateEvaluationScheduleHandler handles POST /api/v1/schedules
+func (app *App) CreateEvaluationScheduleHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var schedule models.EvaluationSchedule
+	err := app.ReadJSON(w, r, &schedule)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if !app.prepareEvaluationSchedule(w, r, namespace, &schedule) {
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	user := app.resolveUser(client, identity)
+	schedule.CreatedBy = user
+	schedule.LastScheduleTime = nil
+	if !app.signEvaluationSchedule(w, r, client, identity, &schedule) {
+		return
+	}
+
+	configMap, err := scheduler.ToConfigMap(&schedule)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	configMap.ResourceVersion = ""
+
+	created, err := client.CreateConfigMap(ctx, namespace, configMap)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateSchedule, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	createdSchedule, err := scheduler.FromConfigMap(created)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := EvaluationScheduleEnvelope{
+		Data: createdSchedule,
+	}
+
+	err = app.WriteJSON(w, http.StatusCreated, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// UpdateEvaluationScheduleHandler handles PUT /api/v1/schedules/:name
+func (app *App) UpdateEvaluationScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var schedule models.EvaluationSchedule
+	err := app.ReadJSON(w, r, &schedule)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if schedule.Name != "" && schedule.Name != name {
+		app.badRequestResponse(w, r, fmt.Errorf("schedule name %q does not match path %q", schedule.Name, name))
+		return
+	}
+	schedule.Name = name
+
+	if !app.prepareEvaluationSchedule(w, r, namespace, &schedule) {
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	existing, ok := app.getEvaluationSchedule(w, r, client, namespace, name)
+	if !ok {
+		return
+	}
+
+	// Run bookkeeping and the original author are owned by the server, not the request
+	schedule.CreatedBy = existing.CreatedBy
+	schedule.LastScheduleTime = existing.LastScheduleTime
+	if schedule.ResourceVersion == "" {
+		schedule.ResourceVersion = existing.ResourceVersion
+	}
+	if !app.signEvaluationSchedule(w, r, client, identity, &schedule) {
+		return
+	}
+
+	configMap, err := scheduler.ToConfigMap(&schedule)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	updated, err := client.UpdateConfigMap(ctx, namespace, configMap)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionUpdateSchedule, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	updatedSchedule, err := scheduler.FromConfigMap(updated)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := EvaluationScheduleEnvelope{
+		Data: updatedSchedule,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteEvaluationScheduleHandler handles DELETE /api/v1/schedules/:name.
+// Runs that were already created are kept.
+func (app *App) DeleteEvaluationScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if _, ok := app.getEvaluationSchedule(w, r, client, namespace, name); !ok {
+		return
+	}
+
+	configMapName := scheduler.ConfigMapName(name)
+	err = client.DeleteConfigMap(ctx, namespace, configMapName)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteSchedule, namespace, "configmaps/"+configMapName, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListEvaluationScheduleRunsHandler handles GET /api/v1/schedules/:name/runs
+func (app *App) ListEvaluationScheduleRunsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if _, ok := app.getEvaluationSchedule(w, r, client, namespace, name); !ok {
+		return
+	}
+
+	jobs, err := scheduler.ListRuns(ctx, client, identity, namespace, name)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to list schedule runs: %w", err))
+		return
+	}
+
+	runs := []models.EvaluationScheduleRun{}
+	for _, job := range jobs {
+		run := models.EvaluationScheduleRun{
+			Name:              job.Metadata.Name,
+			Namespace:         job.Metadata.Namespace,
+			CreationTimestamp: job.Metadata.CreationTimestamp,
+		}
+		if value := job.Metadata.Annotations[constants.ScheduledTimeAnnotation]; value != "" {
+			if scheduledTime, err := time.Parse(time.RFC3339, value); err == nil {
+				run.ScheduledTime = &scheduledTime
+			}
+		}
+		if job.Status != nil {
+			run.State = job.Status.State
+			run.Reason = job.Status.Reason
+			run.CompleteTime = job.Status.CompleteTime
+		}
+		runs = append(runs, run)
+	}
+
+	response := EvaluationScheduleRunListEnvelope{
+		Data: runs,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// prepareEvaluationSchedule resolves the schedule's template, if any, and validates it.
+// The template is applied once here so later template edits do not change existing schedules.
+// It writes the error response itself and returns false when the request cannot proceed.
+func (app *App) prepareEvaluationSchedule(w http.ResponseWriter, r *http.Request, namespace string, schedule *models.EvaluationSchedule) bool {
+	schedule.Namespace = namespace
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = "UTC"
+	}
+
+	if ref := schedule.Evaluation.TemplateRef; ref != nil {
+		template, err := app.resolveEvaluationTemplate(r.Context(), namespace, *ref)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				app.badRequestResponse(w, r, fmt.Errorf("template %q not found", ref.Name))
+				return false
+			}
+			app.evaluationTemplateErrorResponse(w, r, err)
+			return false
+		}
+		applyEvaluationTemplate(&schedule.Evaluation, template)
+	}
+
+	if err := scheduler.Validate(schedule); err != nil {
+		app.badRequestResponse(w, r, err)
+		return false
+	}
+	if err := validateEvaluationLabels(schedule.Evaluation.Labels, schedule.Evaluation.Experiment); err != nil {
+		app.badRequestResponse(w, r, err)
+		return false
+	}
+	if policy := schedule.Evaluation.Regression; policy != nil {
+		if err := regression.Validate(policy); err != nil {
+			app.badRequestResponse(w, r, err)
+			return false
+		}
+	}
+	if err := gate.Validate(schedule.Evaluation.Thresholds); err != nil {
+		app.badRequestResponse(w, r, err)
+		return false
+	}
+	return true
+}
+
+// getEvaluationSchedule loads a schedule, writing a 404 when the ConfigMap is not a schedule
+func (app *App) getEvaluationSchedule(w http.ResponseWriter, r *http.Request, client kubernetes.KubernetesClientInterface, namespace, name string) (*models.EvaluationSchedule, bool) {
+	configMap, err := client.GetConfigMap(r.Context(), namespace, scheduler.ConfigMapName(name))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return nil, false
+	}
+	if configMap.Labels[constants.EvaluationScheduleLabel] != "true" {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	schedule, err := scheduler.FromConfigMap(configMap)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return nil, false
+	}
+	return schedule, true
+}
+
+// signEvaluationSchedule makes the requesting user the one the schedule's runs are authorized as
+// and signs it, so the scheduler only acts on schedules saved through the API
+func (app *App) signEvaluationSchedule(w http.ResponseWriter, r *http.Request, client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity, schedule *models.EvaluationSchedule) bool {
+	runAs, ok := app.resolveRunAs(w, r, client, identity)
+	if !ok {
+		return false
+	}
+	schedule.RunAs = runAs
+
+	if err := scheduler.Sign(app.signer, schedule); err != nil {
+		app.serverErrorResponse(w, r, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/scheduler"
+)
+
+func TestEvaluationScheduleLifecycle(t *testing.T) {
+	app, client := newTestApp()
+	params := httprouter.Params{{Key: "name", Value: "nightly"}}
+
+	template := models.EvaluationTemplate{Name: "reasoning-suite", Tasks: []string{"arc_easy", "hellaswag"}}
+	w := httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?namespace=project-1", template, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	schedule := models.EvaluationSchedule{
+		Name:     "nightly",
+		Schedule: "0 2 * * *",
+		Evaluation: models.LMEvalCreateRequest{
+			EvaluationName: "Nightly reasoning",
+			ModelType:      "llama",
+			TemplateRef:    &models.EvaluationTemplateRef{Name: "reasoning-suite"},
+		},
+	}
+	w = httptest.NewRecorder()
+	app.CreateEvaluationScheduleHandler(w, newTestRequest("POST", "/api/v1/schedules?namespace=project-1", schedule, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created EvaluationScheduleEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "UTC", created.Data.TimeZone)
+	assert.Equal(t, "test-user", created.Data.CreatedBy)
+	assert.Equal(t, []string{"arc_easy", "hellaswag"}, created.Data.Evaluation.Tasks)
+	assert.NotNil(t, created.Data.NextScheduleTime)
+
+	w = httptest.NewRecorder()
+	app.ListEvaluationSchedulesHandler(w, newTestRequest("GET", "/api/v1/schedules?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var list EvaluationScheduleListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+
+	suspended := created.Data
+	suspended.Suspend = true
+	w = httptest.NewRecorder()
+	app.UpdateEvaluationScheduleHandler(w, newTestRequest("PUT", "/api/v1/schedules/nightly?namespace=project-1", suspended, "other-user"), params)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updated EvaluationScheduleEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.True(t, updated.Data.Suspend)
+	assert.Nil(t, updated.Data.NextScheduleTime)
+	assert.Equal(t, "test-user", updated.Data.CreatedBy)
+
+	// Runs are authorized as the user who saved the schedule last
+	configMap, err := client.GetConfigMap(context.Background(), "project-1", scheduler.ConfigMapName("nightly"))
+	if assert.NoError(t, err) {
+		stored, err := scheduler.FromConfigMap(configMap)
+		assert.NoError(t, err)
+		assert.Equal(t, "other-user", stored.RunAs.User)
+		assert.NoError(t, scheduler.Verify(app.signer, stored))
+	}
+
+	w = httptest.NewRecorder()
+	app.ListEvaluationScheduleRunsHandler(w, newTestRequest("GET", "/api/v1/schedules/nightly/runs?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	app.DeleteEvaluationScheduleHandler(w, newTestRequest("DELETE", "/api/v1/schedules/nightly?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	app.GetEvaluationScheduleHandler(w, newTestRequest("GET", "/api/v1/schedules/nightly?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateEvaluationScheduleValidatesCron(t *testing.T) {
+	app, _ := newTestApp()
+
+	schedule := models.EvaluationSchedule{
+		Name:       "nightly",
+		Schedule:   "every night",
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+	}
+	w := httptest.NewRecorder()
+	app.CreateEvaluationScheduleHandler(w, newTestRequest("POST", "/api/v1/schedules?namespace=project-1", schedule, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
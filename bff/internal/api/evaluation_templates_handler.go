@@ -0,0 +1,439 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/templates"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type EvaluationTemplateEnvelope Envelope[*models.EvaluationTemplate, None]
+type EvaluationTemplateListEnvelope Envelope[[]models.EvaluationTemplate, None]
+
+const (
+	AuditActionCreateTemplate = "template.create"
+	AuditActionUpdateTemplate = "template.update"
+	AuditActionDeleteTemplate = "template.delete"
+)
+
+var errClusterTemplatesDisabled = errors.New("cluster-wide templates are not configured")
+
+// ListEvaluationTemplatesHandler handles GET /api/v1/templates
+func (app *App) ListEvaluationTemplatesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	scope := r.URL.Query().Get("scope")
+
+	if scope != "" && scope != models.TemplateScopeNamespace && scope != models.TemplateScopeCluster {
+		app.badRequestResponse(w, r, fmt.Errorf("scope must be %q or %q", models.TemplateScopeNamespace, models.TemplateScopeCluster))
+		return
+	}
+	if scope == models.TemplateScopeNamespace && namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	list := []models.EvaluationTemplate{}
+
+	// Without an explicit scope, return the namespace's own templates together with the cluster-wide ones
+	if scope != models.TemplateScopeCluster && namespace != "" {
+		client, err := app.kubernetesClientFactory.GetClient(ctx)
+		if err != nil {
+			app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+			return
+		}
+
+		namespaceTemplates, err := listEvaluationTemplates(ctx, client, namespace, models.TemplateScopeNamespace)
+		if err != nil {
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+		list = append(list, namespaceTemplates...)
+	}
+
+	if scope != models.TemplateScopeNamespace && app.config.TemplatesNamespace != "" {
+		client, err := app.kubernetesClientFactory.GetServiceAccountClient()
+		if err != nil {
+			app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+			return
+		}
+
+		clusterTemplates, err := listEvaluationTemplates(ctx, client, app.config.TemplatesNamespace, models.TemplateScopeCluster)
+		if err != nil {
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+		list = append(list, clusterTemplates...)
+	}
+
+	response := EvaluationTemplateListEnvelope{
+		Data: list,
+	}
+
+	err := app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetEvaluationTemplateHandler handles GET /api/v1/templates/:name
+func (app *App) GetEvaluationTemplateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	ref := models.EvaluationTemplateRef{
+		Name:  ps.ByName("name"),
+		Scope: r.URL.Query().Get("scope"),
+	}
+
+	template, err := app.resolveEvaluationTemplate(ctx, r.URL.Query().Get("namespace"), ref)
+	if err != nil {
+		app.evaluationTemplateErrorResponse(w, r, err)
+		return
+	}
+
+	response := EvaluationTemplateEnvelope{
+		Data: template,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateEvaluationTemplateHandler handles POST /api/v1/templates
+func (app *App) CreateEvaluationTemplateHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	var template models.EvaluationTemplate
+	err := app.ReadJSON(w, r, &template)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if err := templates.Validate(&template); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, namespace, scope, ok := app.evaluationTemplateWriteTarget(w, r, identity)
+	if !ok {
+		return
+	}
+
+	user := app.resolveUser(client, identity)
+	template.CreatedBy = user
+
+	configMap, err := templates.ToConfigMap(&template)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	created, err := client.CreateConfigMap(ctx, namespace, configMap)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateTemplate, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	createdTemplate, err := templates.FromConfigMap(created, scope)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := EvaluationTemplateEnvelope{
+		Data: createdTemplate,
+	}
+
+	err = app.WriteJSON(w, http.StatusCreated, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// UpdateEvaluationTemplateHandler handles PUT /api/v1/templates/:name
+func (app *App) UpdateEvaluationTemplateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	var template models.EvaluationTemplate
+	err := app.ReadJSON(w, r, &template)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if template.Name != "" && template.Name != name {
+		app.badRequestResponse(w, r, fmt.Errorf("template name %q does not match path %q", template.Name, name))
+		return
+	}
+	template.Name = name
+
+	if err := templates.Validate(&template); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, namespace, scope, ok := app.evaluationTemplateWriteTarget(w, r, identity)
+	if !ok {
+		return
+	}
+
+	existing, err := client.GetConfigMap(ctx, namespace, templates.ConfigMapName(name))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+	if !templates.IsTemplate(existing) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	// The original author is kept; an empty resourceVersion updates unconditionally
+	template.CreatedBy = existing.Annotations[constants.CreatedByAnnotation]
+
+	configMap, err := templates.ToConfigMap(&template)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	configMap.ResourceVersion = template.ResourceVersion
+	if configMap.ResourceVersion == "" {
+		configMap.ResourceVersion = existing.ResourceVersion
+	}
+
+	updated, err := client.UpdateConfigMap(ctx, namespace, configMap)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionUpdateTemplate, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	updatedTemplate, err := templates.FromConfigMap(updated, scope)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := EvaluationTemplateEnvelope{
+		Data: updatedTemplate,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteEvaluationTemplateHandler handles DELETE /api/v1/templates/:name
+func (app *App) DeleteEvaluationTemplateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	client, namespace, _, ok := app.evaluationTemplateWriteTarget(w, r, identity)
+	if !ok {
+		return
+	}
+
+	configMapName := templates.ConfigMapName(ps.ByName("name"))
+	existing, err := client.GetConfigMap(ctx, namespace, configMapName)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+	if !templates.IsTemplate(existing) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = client.DeleteConfigMap(ctx, namespace, configMapName)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteTemplate, namespace, "configmaps/"+configMapName, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// evaluationTemplateWriteTarget resolves the client and namespace a template write goes to.
+// Cluster-wide templates can only be written by cluster admins. It writes the error response
+// itself and returns false when the request cannot proceed.
+func (app *App) evaluationTemplateWriteTarget(w http.ResponseWriter, r *http.Request, identity *kubernetes.RequestIdentity) (kubernetes.KubernetesClientInterface, string, string, bool) {
+	client, err := app.kubernetesClientFactory.GetClient(r.Context())
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return nil, "", "", false
+	}
+
+	scope := r.URL.Query().Get("scope")
+	switch scope {
+	case models.TemplateScopeCluster:
+		if app.config.TemplatesNamespace == "" {
+			app.badRequestResponse(w, r, errClusterTemplatesDisabled)
+			return nil, "", "", false
+		}
+
+		isAdmin, err := client.IsClusterAdmin(identity)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return nil, "", "", false
+		}
+		if !isAdmin {
+			app.forbiddenResponse(w, r, "only cluster admins can manage cluster-wide templates")
+			return nil, "", "", false
+		}
+		return client, app.config.TemplatesNamespace, scope, true
+
+	case "", models.TemplateScopeNamespace:
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+			return nil, "", "", false
+		}
+		return client, namespace, models.TemplateScopeNamespace, true
+
+	default:
+		app.badRequestResponse(w, r, fmt.Errorf("scope must be %q or %q", models.TemplateScopeNamespace, models.TemplateScopeCluster))
+		return nil, "", "", false
+	}
+}
+
+// resolveEvaluationTemplate loads a template by reference. Namespace templates are read with the
+// caller's client, cluster-wide templates with the service account client so every user can use them.
+func (app *App) resolveEvaluationTemplate(ctx context.Context, namespace string, ref models.EvaluationTemplateRef) (*models.EvaluationTemplate, error) {
+	if ref.Name == "" {
+		return nil, fmt.Errorf("template name is required")
+	}
+
+	var client kubernetes.KubernetesClientInterface
+	var err error
+
+	switch ref.Scope {
+	case models.TemplateScopeCluster:
+		if app.config.TemplatesNamespace == "" {
+			return nil, errClusterTemplatesDisabled
+		}
+		namespace = app.config.TemplatesNamespace
+		client, err = app.kubernetesClientFactory.GetServiceAccountClient()
+	case "", models.TemplateScopeNamespace:
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace parameter is required")
+		}
+		ref.Scope = models.TemplateScopeNamespace
+		client, err = app.kubernetesClientFactory.GetClient(ctx)
+	default:
+		return nil, fmt.Errorf("scope must be %q or %q", models.TemplateScopeNamespace, models.TemplateScopeCluster)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+
+	configMap, err := client.GetConfigMap(ctx, namespace, templates.ConfigMapName(ref.Name))
+	if err != nil {
+		return nil, err
+	}
+	if !templates.IsTemplate(configMap) {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), configMap.Name)
+	}
+
+	return templates.FromConfigMap(configMap, ref.Scope)
+}
+
+// evaluationTemplateErrorResponse maps template lookup failures to responses
+func (app *App) evaluationTemplateErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+	app.badRequestResponse(w, r, err)
+}
+
+// applyEvaluationTemplate fills the create request from a template. Values already set on the
+// request take precedence, so a request can also turn off code execution or online access.
+func applyEvaluationTemplate(createRequest *models.LMEvalCreateRequest, template *models.EvaluationTemplate) {
+	if len(createRequest.Tasks) == 0 {
+		createRequest.Tasks = template.Tasks
+	}
+	if createRequest.NumFewShot == nil {
+		createRequest.NumFewShot = template.NumFewShot
+	}
+	if createRequest.Limit == "" {
+		createRequest.Limit = template.Limit
+	}
+	if createRequest.BatchSize == "" {
+		createRequest.BatchSize = template.BatchSize
+	}
+	if len(createRequest.Thresholds) == 0 {
+		createRequest.Thresholds = template.Thresholds
+	}
+	if createRequest.AllowRemoteCode == nil {
+		allowRemoteCode := template.AllowRemoteCode
+		createRequest.AllowRemoteCode = &allowRemoteCode
+	}
+	if createRequest.AllowOnline == nil {
+		allowOnline := template.AllowOnline
+		createRequest.AllowOnline = &allowOnline
+	}
+}
+
+func listEvaluationTemplates(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, scope string) ([]models.EvaluationTemplate, error) {
+	configMaps, err := client.ListConfigMaps(ctx, namespace, templates.LabelSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]models.EvaluationTemplate, 0, len(configMaps))
+	for i := range configMaps {
+		template, err := templates.FromConfigMap(&configMaps[i], scope)
+		if err != nil {
+			// Skip templates that were edited by hand into an unreadable state
+			continue
+		}
+		list = append(list, *template)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
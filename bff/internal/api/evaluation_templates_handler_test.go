@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestEvaluationTemplateLifecycle(t *testing.T) {
+	app, _ := newTestApp()
+	fewShot := 5
+
+	template := models.EvaluationTemplate{
+		Name:       "safety-suite",
+		Tasks:      []string{"toxigen", "truthfulqa_mc2"},
+		NumFewShot: &fewShot,
+		Limit:      "0.5",
+	}
+
+	w := httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?namespace=project-1", template, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created EvaluationTemplateEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "safety-suite", created.Data.Name)
+	assert.Equal(t, models.TemplateScopeNamespace, created.Data.Scope)
+	assert.Equal(t, "test-user", created.Data.CreatedBy)
+
+	// Creating the same template twice conflicts
+	w = httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?namespace=project-1", template, "test-user"), nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	app.ListEvaluationTemplatesHandler(w, newTestRequest("GET", "/api/v1/templates?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var list EvaluationTemplateListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, []string{"toxigen", "truthfulqa_mc2"}, list.Data[0].Tasks)
+
+	// Updating with a stale resourceVersion conflicts
+	stale := template
+	stale.ResourceVersion = "stale"
+	w = httptest.NewRecorder()
+	app.UpdateEvaluationTemplateHandler(w, newTestRequest("PUT", "/api/v1/templates/safety-suite?namespace=project-1", stale, "test-user"),
+		httprouter.Params{{Key: "name", Value: "safety-suite"}})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	updated := template
+	updated.Tasks = []string{"toxigen"}
+	updated.ResourceVersion = created.Data.ResourceVersion
+	w = httptest.NewRecorder()
+	app.UpdateEvaluationTemplateHandler(w, newTestRequest("PUT", "/api/v1/templates/safety-suite?namespace=project-1", updated, "other-user"),
+		httprouter.Params{{Key: "name", Value: "safety-suite"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var afterUpdate EvaluationTemplateEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &afterUpdate))
+	assert.Equal(t, []string{"toxigen"}, afterUpdate.Data.Tasks)
+	assert.Equal(t, "test-user", afterUpdate.Data.CreatedBy)
+
+	w = httptest.NewRecorder()
+	app.DeleteEvaluationTemplateHandler(w, newTestRequest("DELETE", "/api/v1/templates/safety-suite?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "safety-suite"}})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	app.GetEvaluationTemplateHandler(w, newTestRequest("GET", "/api/v1/templates/safety-suite?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "safety-suite"}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestClusterTemplatesRequireAdmin(t *testing.T) {
+	app, _ := newTestApp()
+	template := models.EvaluationTemplate{Name: "reasoning-suite", Tasks: []string{"arc_challenge"}}
+
+	w := httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?scope=cluster", template, "test-user"), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?scope=cluster", template, "admin@example.com"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Cluster templates are listed next to the namespace's own templates
+	w = httptest.NewRecorder()
+	app.ListEvaluationTemplatesHandler(w, newTestRequest("GET", "/api/v1/templates?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var list EvaluationTemplateListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, models.TemplateScopeCluster, list.Data[0].Scope)
+	assert.Equal(t, "trustyai-dashboard", list.Data[0].Namespace)
+}
+
+func TestCreateLMEvalHandlerAppliesTemplate(t *testing.T) {
+	app, _ := newTestApp()
+	fewShot := 5
+
+	template := models.EvaluationTemplate{
+		Name:        "safety-suite",
+		Tasks:       []string{"toxigen", "truthfulqa_mc2"},
+		NumFewShot:  &fewShot,
+		Limit:       "100",
+		AllowOnline: true,
+	}
+	w := httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?namespace=project-1", template, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "Safety run",
+		K8sName:        "safety-run",
+		ModelType:      "llama",
+		Limit:          "10",
+		TemplateRef:    &models.EvaluationTemplateRef{Name: "safety-suite"},
+	}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"toxigen", "truthfulqa_mc2"}, response.Data.Spec.TaskList.TaskNames)
+	assert.Equal(t, 5, *response.Data.Spec.NumFewShot)
+	assert.Equal(t, "10", response.Data.Spec.Limit)
+	assert.True(t, response.Data.Spec.AllowOnline)
+	assert.Equal(t, "namespace/safety-suite", response.Data.Metadata.Annotations[constants.TemplateAnnotation])
+
+	// The request turns off online access the template allows
+	allowOnline := false
+	createRequest.K8sName = "safety-run-offline"
+	createRequest.AllowOnline = &allowOnline
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var offline LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &offline))
+	assert.False(t, offline.Data.Spec.AllowOnline)
+
+	createRequest.TemplateRef = &models.EvaluationTemplateRef{Name: "missing"}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type ExperimentEnvelope Envelope[*models.Experiment, None]
+type ExperimentListEnvelope Envelope[[]models.Experiment, None]
+
+// ListExperimentsHandler handles GET /api/v1/experiments
+func (app *App) ListExperimentsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, fmt.Errorf("failed to list LMEvalJobs: %w", err))
+		return
+	}
+
+	groups := map[string][]models.LMEvalJobKind{}
+	for _, job := range lmEvalJobs.Items {
+		if name := job.Metadata.Labels[constants.ExperimentLabel]; name != "" {
+			groups[name] = append(groups[name], job)
+		}
+	}
+
+	experiments := []models.Experiment{}
+	for name, jobs := range groups {
+		experiments = append(experiments, models.Experiment{
+			Name:      name,
+			Namespace: namespace,
+			Status:    experimentStatus(jobs),
+		})
+	}
+	sort.Slice(experiments, func(i, j int) bool {
+		return experiments[i].Name < experiments[j].Name
+	})
+
+	response := ExperimentListEnvelope{
+		Data: experiments,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetExperimentHandler handles GET /api/v1/experiments/:name
+func (app *App) GetExperimentHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, fmt.Errorf("failed to list LMEvalJobs: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	var jobs []models.LMEvalJobKind
+	for _, job := range lmEvalJobs.Items {
+		if job.Metadata.Labels[constants.ExperimentLabel] == name {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		app.notFoundResponse(w, r)
+		return
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Metadata.Name < jobs[j].Metadata.Name
+	})
+
+	experiment := &models.Experiment{
+		Name:        name,
+		Namespace:   namespace,
+		Status:      experimentStatus(jobs),
+		Evaluations: []models.ExperimentEvaluation{},
+		Results:     []models.ExperimentResult{},
+	}
+
+	for _, job := range jobs {
+		model := job.ModelName()
+		evaluation := models.ExperimentEvaluation{
+			Name:        job.Metadata.Name,
+			DisplayName: job.Metadata.Annotations[constants.DisplayNameAnnotation],
+			Model:       model,
+			Tasks:       job.Spec.TaskList.TaskNames,
+		}
+		if job.Status == nil {
+			experiment.Evaluations = append(experiment.Evaluations, evaluation)
+			continue
+		}
+		evaluation.State = job.Status.State
+		evaluation.Reason = job.Status.Reason
+		experiment.Evaluations = append(experiment.Evaluations, evaluation)
+
+		metrics, err := models.ParseLMEvalResults(job.Status.Results)
+		if err != nil {
+			app.logger.Warn("skipping unreadable evaluation results", "evaluation", job.Metadata.Name, "error", err)
+			continue
+		}
+		for _, metric := range metrics {
+			experiment.Results = append(experiment.Results, models.ExperimentResult{
+				Evaluation:   job.Metadata.Name,
+				Model:        model,
+				LMEvalMetric: metric,
+			})
+		}
+	}
+
+	response := ExperimentEnvelope{
+		Data: experiment,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// experimentStatus counts evaluations per state. The experiment is running while any
+// evaluation has not finished, and failed once all finished and at least one failed.
+func experimentStatus(jobs []models.LMEvalJobKind) models.ExperimentStatus {
+	status := models.ExperimentStatus{Total: len(jobs)}
+	for _, job := range jobs {
+		switch {
+		case job.Status.IsFailed():
+			status.Failed++
+		case job.Status.IsFinished():
+			status.Succeeded++
+		case job.Status != nil && job.Status.State == models.LMEvalJobStateRunning:
+			status.Running++
+		default:
+			status.Pending++
+		}
+	}
+
+	switch {
+	case status.Running > 0:
+		status.Phase = models.ExperimentPhaseRunning
+	case status.Pending > 0 && status.Pending == status.Total:
+		status.Phase = models.ExperimentPhasePending
+	case status.Pending > 0:
+		status.Phase = models.ExperimentPhaseRunning
+	case status.Failed > 0:
+		status.Phase = models.ExperimentPhaseFailed
+	default:
+		status.Phase = models.ExperimentPhaseSucceeded
+	}
+	return status
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExperimentsHandlers(t *testing.T) {
+	app, client := newTestApp()
+
+	for _, name := range []string{"release-7b", "release-13b", "unrelated"} {
+		createRequest := models.LMEvalCreateRequest{
+			EvaluationName: name,
+			K8sName:        name,
+			ModelType:      "llama",
+			Model:          models.LMEvalModelConfig{Name: name},
+			Tasks:          []string{"arc_easy"},
+			Labels:         map[string]string{"team": "evals"},
+		}
+		if name != "unrelated" {
+			createRequest.Experiment = "q3-release"
+		}
+		w := httptest.NewRecorder()
+		app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	_, err := client.PatchLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "release-7b",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.81}}}"}}`))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	app.ListExperimentsHandler(w, newTestRequest("GET", "/api/v1/experiments?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var list ExperimentListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, "q3-release", list.Data[0].Name)
+	assert.Equal(t, models.ExperimentStatus{Phase: models.ExperimentPhaseRunning, Total: 2, Pending: 1, Succeeded: 1}, list.Data[0].Status)
+
+	w = httptest.NewRecorder()
+	app.GetExperimentHandler(w, newTestRequest("GET", "/api/v1/experiments/q3-release?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "q3-release"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var experiment ExperimentEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &experiment))
+	assert.Len(t, experiment.Data.Evaluations, 2)
+	assert.Len(t, experiment.Data.Results, 1)
+	assert.Equal(t, "release-7b", experiment.Data.Results[0].Evaluation)
+	assert.Equal(t, 0.81, experiment.Data.Results[0].Value)
+
+	w = httptest.NewRecorder()
+	app.GetExperimentHandler(w, newTestRequest("GET", "/api/v1/experiments/missing?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "missing"}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListExperimentsHandlerForbidden(t *testing.T) {
+	mockFactory := &MockKubernetesClientFactory{}
+	mockClient := &MockKubernetesClient{}
+	app := &App{
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+	}
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "trustyai.opendatahub.io", Resource: "lmevaljobs"}, "", errors.New("no access"))
+	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
+	mockClient.On("ListLMEvalJobs", mock.Anything, mock.Anything, "project-1").Return((*models.LMEvalJobList)(nil), forbidden)
+
+	w := httptest.NewRecorder()
+	app.ListExperimentsHandler(w, newTestRequest("GET", "/api/v1/experiments?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCreateLMEvalHandlerRejectsReservedLabels(t *testing.T) {
+	app, _ := newTestApp()
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "Reserved",
+		K8sName:        "reserved",
+		ModelType:      "llama",
+		Tasks:          []string{"arc_easy"},
+		Labels:         map[string]string{"trustyai.opendatahub.io/batch": "spoofed"},
+	}
+	w := httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+type ExplanationEnvelope Envelope[*models.Explanation, None]
+
+// explanationWriteMargin is the time left to write an explanation the explainer returned
+// just before timing out
+const explanationWriteMargin = 10 * time.Second
+
+// CreateExplanationHandler handles POST /api/v1/explanations, which explains a stored
+// prediction of a model served in the namespace with the namespace's TrustyAI service
+func (app *App) CreateExplanationHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var request models.ExplanationRequest
+	if err := app.ReadJSON(w, r, &request); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := trustyai.ValidateExplanation(&request); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	servingServices, err := client.GetModelServingServices(ctx, namespace)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to discover the model serving services: %w", err))
+		return
+	}
+	model, found := explainedModel(servingServices, namespace, &request)
+	if !found {
+		app.badRequestResponse(w, r, fmt.Errorf("model %q is not served in namespace %q", request.Model, namespace))
+		return
+	}
+
+	// Explanations may outlive the write timeout of the server
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(trustyai.ExplainerTimeout + explanationWriteMargin))
+
+	explanation, err := service.Explain(ctx, request.Explainer, request.PredictionID, model, request.Samples)
+	if err != nil {
+		app.explanationErrorResponse(w, r, err)
+		return
+	}
+	explanation.Model = request.Model
+
+	if err := app.WriteJSON(w, http.StatusOK, ExplanationEnvelope{Data: explanation}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// explainedModel finds the serving service of the model option named in the request and
+// locates the model on it for the explainer
+func explainedModel(services []kubernetes.ServiceDetails, namespace string, request *models.ExplanationRequest) (trustyai.ModelConfig, bool) {
+	for _, service := range services {
+		if convertServiceToModelOption(service, namespace).Value != request.Model {
+			continue
+		}
+		name := request.ModelName
+		if name == "" {
+			name = strings.TrimSuffix(service.Name, "-predictor")
+		}
+		return trustyai.ModelConfig{
+			Target: fmt.Sprintf("%s.%s.svc.cluster.local:%d", service.Name, namespace, service.HTTPPort),
+			Name:   name,
+		}, true
+	}
+	return trustyai.ModelConfig{}, false
+}
+
+// explanationErrorResponse reports an error of an explanation. Explainers that time out
+// or fail on the service, typically because the model cannot be reached, are gateway
+// errors rather than errors of the BFF.
+func (app *App) explanationErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := 0, ""
+	var httpError *integrations.HTTPError
+	switch {
+	case errors.Is(err, trustyai.ErrExplanationTimeout):
+		status, message = http.StatusGatewayTimeout, trustyai.ErrExplanationTimeout.Error()
+	case errors.As(err, &httpError) && httpError.StatusCode >= 500:
+		status, message = http.StatusBadGateway, "TrustyAI service failed to explain the prediction: "+httpError.Message
+	default:
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+	app.LogError(r, err)
+	app.errorResponse(w, r, &integrations.HTTPError{
+		StatusCode: status,
+		ErrorResponse: integrations.ErrorResponse{
+			Code:    strconv.Itoa(status),
+			Message: message,
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+func TestCreateExplanation(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body["predictionId"] {
+		case "unknown":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("No prediction found with id=unknown"))
+		case "unreachable":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Connection refused: llama2-7b-service.project-1.svc.cluster.local"))
+		default:
+			_, _ = w.Write([]byte(`{"timestamp":"2026-03-01T10:00:00.000+00:00","type":"explanation","saliencies":{"output-0":[{"name":"x","score":0.3,"confidence":0}]}}`))
+		}
+	}))
+	defer server.Close()
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = &standInTrustyAIFactory{url: server.URL}
+
+	request := models.ExplanationRequest{Model: "llama2-7b-service-project-1", Explainer: models.ExplainerLIME, PredictionID: "inference-1"}
+	w := httptest.NewRecorder()
+	app.CreateExplanationHandler(w, newTestRequest("POST", "/api/v1/explanations?namespace=project-1", request, "test-user"), nil)
+	if !assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		return
+	}
+	var explanation ExplanationEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &explanation))
+	assert.Equal(t, "llama2-7b-service-project-1", explanation.Data.Model)
+	assert.Equal(t, []models.OutputSaliency{{Output: "output-0", Features: []models.FeatureSaliency{{Name: "x", Score: 0.3}}}}, explanation.Data.Saliencies)
+	assert.Equal(t, map[string]any{"target": "llama2-7b-service.project-1.svc.cluster.local:8080", "name": "llama2-7b-service"}, body["config"].(map[string]any)["model"])
+
+	for predictionID, status := range map[string]int{"unknown": http.StatusBadRequest, "unreachable": http.StatusBadGateway} {
+		request.PredictionID = predictionID
+		w = httptest.NewRecorder()
+		app.CreateExplanationHandler(w, newTestRequest("POST", "/api/v1/explanations?namespace=project-1", request, "test-user"), nil)
+		assert.Equal(t, status, w.Code, predictionID)
+	}
+}
+
+func TestCreateExplanationOfUnknownModel(t *testing.T) {
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = trustyai.NewMockClientFactory()
+
+	w := httptest.NewRecorder()
+	app.CreateExplanationHandler(w, newTestRequest("POST", "/api/v1/explanations?namespace=project-1", models.ExplanationRequest{
+		Model: "gpt-3.5-proxy-project-2", Explainer: models.ExplainerSHAP, PredictionID: "inference-1",
+	}, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "is not served in namespace")
+
+	w = httptest.NewRecorder()
+	app.CreateExplanationHandler(w, newTestRequest("POST", "/api/v1/explanations?namespace=project-1", models.ExplanationRequest{
+		Model: "llama2-7b-service-project-1", Explainer: "anchors", PredictionID: "inference-1",
+	}, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+type FairnessMetricEnvelope Envelope[*models.FairnessMetric, None]
+type FairnessMetricListEnvelope Envelope[[]models.FairnessMetric, None]
+
+const (
+	AuditActionCreateFairnessMetric = "fairness-metric.create"
+	AuditActionDeleteFairnessMetric = "fairness-metric.delete"
+)
+
+// ListFairnessMetricsHandler handles GET /api/v1/fairness/metrics, optionally filtered by model
+func (app *App) ListFairnessMetricsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, _, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metrics, err := service.ListFairnessMetrics(ctx)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	if model := r.URL.Query().Get("model"); model != "" {
+		filtered := []models.FairnessMetric{}
+		for _, metric := range metrics {
+			if metric.Request.ModelID == model {
+				filtered = append(filtered, metric)
+			}
+		}
+		metrics = filtered
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, FairnessMetricListEnvelope{Data: metrics}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetFairnessMetricHandler handles GET /api/v1/fairness/metrics/:id. The current value is
+// computed by the service on the latest inferences of the model.
+func (app *App) GetFairnessMetricHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, _, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metric, ok := app.getFairnessMetric(w, r, service, ps.ByName("id"))
+	if !ok {
+		return
+	}
+
+	value, err := service.ComputeFairnessMetric(ctx, metric.Request)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+	metric.Value = value
+
+	if err := app.WriteJSON(w, http.StatusOK, FairnessMetricEnvelope{Data: metric}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateFairnessMetricHandler handles POST /api/v1/fairness/metrics, which schedules the
+// metric on the namespace's TrustyAI service
+func (app *App) CreateFairnessMetricHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var request models.FairnessMetricRequest
+	if err := app.ReadJSON(w, r, &request); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := trustyai.Validate(&request); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	id, err := service.ScheduleFairnessMetric(ctx, request)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateFairnessMetric, namespace, trustyAIMetricResource(request.MetricName, id), err)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	metric := &models.FairnessMetric{ID: id, Request: request}
+	if err := app.WriteJSON(w, http.StatusCreated, FairnessMetricEnvelope{Data: metric}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteFairnessMetricHandler handles DELETE /api/v1/fairness/metrics/:id
+func (app *App) DeleteFairnessMetricHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	service, client, ok := app.trustyAIClient(w, r, identity, namespace)
+	if !ok {
+		return
+	}
+
+	metric, ok := app.getFairnessMetric(w, r, service, ps.ByName("id"))
+	if !ok {
+		return
+	}
+
+	err := service.DeleteFairnessMetric(ctx, metric.Request.MetricName, metric.ID)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteFairnessMetric, namespace, trustyAIMetricResource(metric.Request.MetricName, metric.ID), err)
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// trustyAIClient returns the client of the namespace's TrustyAI service once the user is
+// allowed to access it, along with the user's Kubernetes client. Otherwise it writes the
+// error response.
+func (app *App) trustyAIClient(w http.ResponseWriter, r *http.Request, identity *kubernetes.RequestIdentity, namespace string) (trustyai.Client, kubernetes.KubernetesClientInterface, bool) {
+	ctx := r.Context()
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return nil, nil, false
+	}
+
+	services, err := client.GetServiceDetails(ctx, namespace)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to discover the TrustyAI service: %w", err))
+		return nil, nil, false
+	}
+	if len(services) == 0 {
+		app.errorResponse(w, r, &integrations.HTTPError{
+			StatusCode: http.StatusNotFound,
+			ErrorResponse: integrations.ErrorResponse{
+				Code:    strconv.Itoa(http.StatusNotFound),
+				Message: fmt.Sprintf("no TrustyAI service found in namespace %q", namespace),
+			},
+		})
+		return nil, nil, false
+	}
+	service := services[0]
+
+	allowed, err := client.CanAccessServiceInNamespace(ctx, identity, namespace, service.Name)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return nil, nil, false
+	}
+	if !allowed {
+		app.forbiddenResponse(w, r, fmt.Sprintf("user is not allowed to access the TrustyAI service of namespace %q", namespace))
+		return nil, nil, false
+	}
+
+	token, err := client.BearerToken()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return nil, nil, false
+	}
+	trustyAIClient, err := app.trustyAIClientFactory.GetClient(namespace, service, token)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return nil, nil, false
+	}
+	return trustyAIClient, client, true
+}
+
+// getFairnessMetric finds a scheduled fairness metric, writing a not found response when
+// there is none with that id
+func (app *App) getFairnessMetric(w http.ResponseWriter, r *http.Request, service trustyai.Client, id string) (*models.FairnessMetric, bool) {
+	metrics, err := service.ListFairnessMetrics(r.Context())
+	if err != nil {
+		app.trustyAIErrorResponse(w, r, err)
+		return nil, false
+	}
+	for i := range metrics {
+		if metrics[i].ID == id {
+			return &metrics[i], true
+		}
+	}
+	app.notFoundResponse(w, r)
+	return nil, false
+}
+
+// trustyAIErrorResponse reports an error of the TrustyAI service. Its client errors, such
+// as an unknown model or attribute, are caused by the request and are passed on.
+func (app *App) trustyAIErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var httpError *integrations.HTTPError
+	if errors.As(err, &httpError) && httpError.StatusCode >= 400 && httpError.StatusCode < 500 {
+		app.badRequestResponse(w, r, fmt.Errorf("TrustyAI service: %s", httpError.Message))
+		return
+	}
+	app.serverErrorResponse(w, r, fmt.Errorf("TrustyAI service request failed: %w", err))
+}
+
+// trustyAIMetricResource names a scheduled metric of a TrustyAI service in audit events
+func trustyAIMetricResource(metric, id string) string {
+	return "trustyai/metrics/" + metric + "/" + id
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+func TestFairnessMetricLifecycle(t *testing.T) {
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = trustyai.NewMockClientFactory()
+
+	request := models.FairnessMetricRequest{
+		ModelID:               "credit-model",
+		MetricName:            models.FairnessMetricDIR,
+		ProtectedAttribute:    "age",
+		PrivilegedAttribute:   1,
+		UnprivilegedAttribute: 0,
+		OutcomeName:           "approved",
+		FavorableOutcome:      1,
+	}
+	w := httptest.NewRecorder()
+	app.CreateFairnessMetricHandler(w, newTestRequest("POST", "/api/v1/fairness/metrics?namespace=project-1", request, "test-user"), nil)
+	if !assert.Equal(t, http.StatusCreated, w.Code, w.Body.String()) {
+		return
+	}
+	var created FairnessMetricEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Data.ID)
+
+	w = httptest.NewRecorder()
+	app.ListFairnessMetricsHandler(w, newTestRequest("GET", "/api/v1/fairness/metrics?namespace=project-1&model=credit-model", nil, "test-user"), nil)
+	var list FairnessMetricListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	if assert.Len(t, list.Data, 1) {
+		assert.Equal(t, created.Data.ID, list.Data[0].ID)
+		assert.Nil(t, list.Data[0].Value)
+	}
+
+	// Metrics of other namespaces are on other services
+	w = httptest.NewRecorder()
+	app.ListFairnessMetricsHandler(w, newTestRequest("GET", "/api/v1/fairness/metrics?namespace=project-2&model=credit-model", nil, "test-user"), nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Empty(t, list.Data)
+
+	params := httprouter.Params{{Key: "id", Value: created.Data.ID}}
+	w = httptest.NewRecorder()
+	app.GetFairnessMetricHandler(w, newTestRequest("GET", "/api/v1/fairness/metrics/"+created.Data.ID+"?namespace=project-1", nil, "test-user"), params)
+	var got FairnessMetricEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	if assert.NotNil(t, got.Data.Value) && assert.NotNil(t, got.Data.Value.Thresholds) {
+		assert.InDelta(t, 0.9, got.Data.Value.Thresholds.LowerBound, 1e-9)
+		assert.False(t, got.Data.Value.Thresholds.OutsideBounds)
+	}
+
+	w = httptest.NewRecorder()
+	app.DeleteFairnessMetricHandler(w, newTestRequest("DELETE", "/api/v1/fairness/metrics/"+created.Data.ID+"?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	app.GetFairnessMetricHandler(w, newTestRequest("GET", "/api/v1/fairness/metrics/"+created.Data.ID+"?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateFairnessMetricValidation(t *testing.T) {
+	app, _ := newTestApp()
+	app.trustyAIClientFactory = trustyai.NewMockClientFactory()
+
+	w := httptest.NewRecorder()
+	app.CreateFairnessMetricHandler(w, newTestRequest("POST", "/api/v1/fairness/metrics?namespace=project-1", models.FairnessMetricRequest{
+		ModelID: "credit-model", MetricName: "EOD", ProtectedAttribute: "age", OutcomeName: "approved",
+	}, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/guardrails"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type GuardrailsOrchestratorListEnvelope Envelope[[]models.GuardrailsOrchestrator, None]
+type GuardrailsEvaluationEnvelope Envelope[*models.GuardrailsEvaluation, None]
+type GuardrailsEvaluationListEnvelope Envelope[[]models.GuardrailsEvaluation, None]
+
+const (
+	AuditActionCreateGuardrailsEvaluation = "guardrails-evaluation.create"
+	AuditActionDeleteGuardrailsEvaluation = "guardrails-evaluation.delete"
+)
+
+// ListGuardrailsOrchestratorsHandler handles GET /api/v1/guardrails/orchestrators
+func (app *App) ListGuardrailsOrchestratorsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	orchestrators, err := client.GetGuardrailsOrchestrators(ctx, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, GuardrailsOrchestratorListEnvelope{Data: orchestrators}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// ListGuardrailsEvaluationsHandler handles GET /api/v1/guardrails/evaluations
+func (app *App) ListGuardrailsEvaluationsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	evaluations, err := app.listGuardrailsEvaluations(ctx, client, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, GuardrailsEvaluationListEnvelope{Data: evaluations}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetGuardrailsEvaluationHandler handles GET /api/v1/guardrails/evaluations/:name
+func (app *App) GetGuardrailsEvaluationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	evaluation, ok := app.getGuardrailsEvaluation(w, r, client, namespace, ps.ByName("name"))
+	if !ok {
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, GuardrailsEvaluationEnvelope{Data: evaluation}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateGuardrailsEvaluationHandler handles POST /api/v1/guardrails/evaluations. The
+// evaluation is run in the background by the BFF.
+func (app *App) CreateGuardrailsEvaluationHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var evaluation models.GuardrailsEvaluation
+	if err := app.ReadJSON(w, r, &evaluation); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := guardrails.Validate(&evaluation); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	// The orchestrator may be left out when the namespace has only one
+	orchestrators, err := client.GetGuardrailsOrchestrators(ctx, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+	if evaluation.Orchestrator == "" {
+		if len(orchestrators) != 1 {
+			app.badRequestResponse(w, r, fmt.Errorf("orchestrator is required, the namespace has %d guardrails orchestrators", len(orchestrators)))
+			return
+		}
+		evaluation.Orchestrator = orchestrators[0].Name
+	}
+	found := false
+	for _, orchestrator := range orchestrators {
+		found = found || orchestrator.Name == evaluation.Orchestrator
+	}
+	if !found {
+		app.badRequestResponse(w, r, fmt.Errorf("guardrails orchestrator %q not found", evaluation.Orchestrator))
+		return
+	}
+
+	// The dataset is read by the BFF when the evaluation runs, so check that the user can read it
+	if dataset := evaluation.Dataset; dataset != nil {
+		configMap, err := client.GetConfigMap(ctx, namespace, dataset.ConfigMap)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				app.badRequestResponse(w, r, fmt.Errorf("dataset configmap %q not found", dataset.ConfigMap))
+				return
+			}
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+		if _, ok := configMap.Data[dataset.Key]; !ok {
+			app.badRequestResponse(w, r, fmt.Errorf("dataset configmap %q has no key %q", dataset.ConfigMap, dataset.Key))
+			return
+		}
+	}
+
+	user := app.resolveUser(client, identity)
+	evaluation.Namespace = namespace
+	evaluation.CreatedBy = user
+	evaluation.ResourceVersion = ""
+	evaluation.Status = models.GuardrailsEvaluationStatus{State: models.GuardrailsStatePending}
+
+	// The evaluation runs later with the BFF's service account, as the user creating it
+	runAs, ok := app.resolveRunAs(w, r, client, identity)
+	if !ok {
+		return
+	}
+	evaluation.RunAs = runAs
+	if err := guardrails.Sign(app.signer, &evaluation); err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	configMap, err := guardrails.ToConfigMap(&evaluation)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	created, err := client.CreateConfigMap(ctx, namespace, configMap)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateGuardrailsEvaluation, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	createdEvaluation, err := guardrails.FromConfigMap(created)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusCreated, GuardrailsEvaluationEnvelope{Data: createdEvaluation}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteGuardrailsEvaluationHandler handles DELETE /api/v1/guardrails/evaluations/:name.
+// A running evaluation stops at its next progress update.
+func (app *App) DeleteGuardrailsEvaluationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if _, ok := app.getGuardrailsEvaluation(w, r, client, namespace, name); !ok {
+		return
+	}
+
+	configMapName := guardrails.ConfigMapName(name)
+	err = client.DeleteConfigMap(ctx, namespace, configMapName)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteGuardrailsEvaluation, namespace, "configmaps/"+configMapName, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listGuardrailsEvaluations returns the evaluations of a namespace, of every namespace when
+// it is empty, newest first. Prompts are left out of lists, they can be long.
+func (app *App) listGuardrailsEvaluations(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace string) ([]models.GuardrailsEvaluation, error) {
+	configMaps, err := client.ListConfigMaps(ctx, namespace, guardrails.LabelSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := []models.GuardrailsEvaluation{}
+	for i := range configMaps {
+		evaluation, err := guardrails.FromConfigMap(&configMaps[i])
+		if err != nil {
+			app.logger.Warn("skipping unreadable guardrails evaluation", "configmap", configMaps[i].Name, "error", err)
+			continue
+		}
+		evaluation.Prompts = nil
+		evaluations = append(evaluations, *evaluation)
+	}
+	sort.Slice(evaluations, func(i, j int) bool {
+		if evaluations[i].CreationTimestamp.Equal(evaluations[j].CreationTimestamp) {
+			return evaluations[i].Name < evaluations[j].Name
+		}
+		return evaluations[i].CreationTimestamp.After(evaluations[j].CreationTimestamp)
+	})
+	return evaluations, nil
+}
+
+// getGuardrailsEvaluation loads an evaluation, writing a 404 when the ConfigMap is not one
+func (app *App) getGuardrailsEvaluation(w http.ResponseWriter, r *http.Request, client kubernetes.KubernetesClientInterface, namespace, name string) (*models.GuardrailsEvaluation, bool) {
+	configMap, err := client.GetConfigMap(r.Context(), namespace, guardrails.ConfigMapName(name))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return nil, false
+	}
+	if configMap.Labels[constants.GuardrailsEvaluationLabel] != "true" {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	evaluation, err := guardrails.FromConfigMap(configMap)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return nil, false
+	}
+	return evaluation, true
+}
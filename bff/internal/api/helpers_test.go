@@ -1,11 +1,52 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
 )
 
+// newTestApp returns an App backed by the in-memory mock Kubernetes client, which is returned
+// too for tests to inspect the resources handlers created
+func newTestApp() (*App, kubernetes.KubernetesClientInterface) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	mockFactory := &MockKubernetesClientFactory{}
+	mockFactory.On("GetClient", mock.Anything).Return(client, nil)
+	mockFactory.On("GetServiceAccountClient").Return(client, nil)
+
+	app := &App{
+		config:                  config.EnvConfig{TemplatesNamespace: "trustyai-dashboard"},
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+		signer:                  signing.New([]byte("0123456789abcdef0123456789abcdef")),
+		publisher:               notifications.NewPublisher(client, slog.Default(), ""),
+	}
+	return app, client
+}
+
+// newTestRequest returns a request with a JSON body, made by the given user
+func newTestRequest(method, target string, body any, userID string) *http.Request {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, target, &buf)
+	identity := &kubernetes.RequestIdentity{UserID: userID}
+	return req.WithContext(context.WithValue(req.Context(), constants.RequestIdentityKey, identity))
+}
+
 func TestParseURLTemplate(t *testing.T) {
 	expected := "/v1/model_registry/demo-registry/registered_models/111-222-333/versions"
 	tmpl := "/v1/model_registry/:model_registry_id/registered_models/:registered_model_id/versions"
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type EvaluationHistoryEnvelope Envelope[[]models.EvaluationHistoryRecord, None]
+
+var errHistoryDisabled = errors.New("the evaluation history is not enabled")
+
+// EvaluationHistoryHandler handles GET /api/v1/history
+func (app *App) EvaluationHistoryHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if app.history == nil {
+		app.notImplementedResponse(w, r, errHistoryDisabled)
+		return
+	}
+
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	namespace, ok := app.authorizeEvaluationReads(w, r)
+	if !ok {
+		return
+	}
+	query.Namespace = namespace
+
+	records, err := app.history.Query(query)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, EvaluationHistoryEnvelope{Data: records}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func parseHistoryQuery(r *http.Request) (history.Query, error) {
+	params := r.URL.Query()
+	query := history.Query{
+		Model:  params.Get("model"),
+		Task:   params.Get("task"),
+		Metric: params.Get("metric"),
+	}
+
+	var err error
+	if query.Since, err = parseHistoryTime("since", params.Get("since")); err != nil {
+		return query, err
+	}
+	if query.Until, err = parseHistoryTime("until", params.Get("until")); err != nil {
+		return query, err
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return query, fmt.Errorf("since must be before until")
+	}
+
+	if value := params.Get("live"); value != "" {
+		if query.Live, err = strconv.ParseBool(value); err != nil {
+			return query, fmt.Errorf("invalid live parameter %q: expected true or false", value)
+		}
+	}
+	return query, nil
+}
+
+// parseHistoryTime accepts RFC 3339 times and dates, which stand for midnight UTC
+func parseHistoryTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s parameter %q: expected a date (2026-01-31) or an RFC 3339 time", name, value)
+}
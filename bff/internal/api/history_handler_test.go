@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestEvaluationHistoryHandler(t *testing.T) {
+	app, _ := newTestApp()
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+	app.history = store
+
+	january := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, model := range []string{"llama", "mistral", "llama"} {
+		completeTime := january.AddDate(0, i, 0)
+		_, err := store.Record(models.EvaluationHistoryRecord{
+			UID:               model + completeTime.Format("0102"),
+			Name:              model + "-eval",
+			Namespace:         "project-1",
+			Model:             model,
+			Tasks:             []string{"arc_easy"},
+			State:             models.LMEvalJobStateComplete,
+			CreationTimestamp: completeTime.Add(-time.Hour),
+			CompleteTime:      &completeTime,
+			Metrics:           []models.LMEvalMetric{{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.7}},
+		})
+		assert.NoError(t, err)
+	}
+
+	query := func(params string) (int, []models.EvaluationHistoryRecord) {
+		w := httptest.NewRecorder()
+		app.EvaluationHistoryHandler(w, newTestRequest("GET", "/api/v1/history?namespace=project-1"+params, nil, "test-user"), nil)
+		var response EvaluationHistoryEnvelope
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	status, records := query("&model=llama&since=2026-02-01")
+	assert.Equal(t, http.StatusOK, status)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, january.AddDate(0, 2, 0), *records[0].CompleteTime)
+	}
+
+	status, records = query("&metric=arc_easy.acc&until=2026-02-15T10:00:00Z")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, records, 1)
+
+	for _, params := range []string{"&since=last-week", "&since=2026-03-01&until=2026-02-01", "&live=maybe"} {
+		status, _ := query(params)
+		assert.Equal(t, http.StatusBadRequest, status, params)
+	}
+
+	app.history = nil
+	status, _ = query("")
+	assert.Equal(t, http.StatusNotImplemented, status)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/leaderboard"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type LeaderboardEnvelope Envelope[*models.Leaderboard, None]
+
+// LeaderboardHandler handles GET /api/v1/leaderboard
+func (app *App) LeaderboardHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	opts := leaderboard.Options{
+		Selection: query.Get("selection"),
+	}
+	if opts.Selection != "" && opts.Selection != models.LeaderboardSelectionLatest && opts.Selection != models.LeaderboardSelectionBest {
+		app.badRequestResponse(w, r, fmt.Errorf("selection must be %q or %q", models.LeaderboardSelectionLatest, models.LeaderboardSelectionBest))
+		return
+	}
+	for _, task := range strings.Split(query.Get("tasks"), ",") {
+		if task = strings.TrimSpace(task); task != "" {
+			opts.Tasks = append(opts.Tasks, task)
+		}
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	response := LeaderboardEnvelope{
+		Data: leaderboard.Build(namespace, lmEvalJobs.Items, opts),
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestLeaderboardHandler(t *testing.T) {
+	app, client := newTestApp()
+
+	for name, results := range map[string]string{
+		"llama-eval":   `{\"results\":{\"arc_easy\":{\"acc,none\":0.81},\"hellaswag\":{\"acc,none\":0.6}}}`,
+		"mistral-eval": `{\"results\":{\"arc_easy\":{\"acc,none\":0.77},\"hellaswag\":{\"acc,none\":0.7}}}`,
+	} {
+		createRequest := models.LMEvalCreateRequest{
+			EvaluationName: name,
+			K8sName:        name,
+			ModelType:      "local-completions",
+			Model:          models.LMEvalModelConfig{Name: name},
+			Tasks:          []string{"arc_easy", "hellaswag"},
+		}
+		w := httptest.NewRecorder()
+		app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		_, err := client.PatchLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", name,
+			[]byte(`{"status":{"state":"Complete","results":"`+results+`"}}`))
+		assert.NoError(t, err)
+	}
+
+	w := httptest.NewRecorder()
+	app.LeaderboardHandler(w, newTestRequest("GET", "/api/v1/leaderboard", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	app.LeaderboardHandler(w, newTestRequest("GET", "/api/v1/leaderboard?namespace=project-1&selection=worst", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	app.LeaderboardHandler(w, newTestRequest("GET", "/api/v1/leaderboard?namespace=project-1&selection=best&tasks=arc_easy,%20", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LeaderboardEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.LeaderboardSelectionBest, response.Data.Selection)
+	assert.Len(t, response.Data.Boards, 1)
+	board := response.Data.Boards[0]
+	assert.Equal(t, "arc_easy", board.Task)
+	assert.Len(t, board.Entries, 2)
+	assert.Equal(t, "llama-eval", board.Entries[0].Evaluation)
+	assert.Equal(t, 1, board.Entries[0].Rank)
+
+	w = httptest.NewRecorder()
+	app.LeaderboardHandler(w, newTestRequest("GET", "/api/v1/leaderboard?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.LeaderboardSelectionLatest, response.Data.Selection)
+	assert.Len(t, response.Data.Boards, 2)
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/gate"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/regression"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type LMEvalBatchEnvelope Envelope[*models.LMEvalBatchResult, None]
+
+const (
+	// maxBatchSize bounds the number of evaluations a single batch request can create
+	maxBatchSize = 100
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// CreateLMEvalBatchHandler handles POST /api/v1/evaluations/batch.
+// It creates one evaluation per model and task set pair and reports the outcome of each.
+// The response is 201 when every evaluation was created and 207 when some failed.
+func (app *App) CreateLMEvalBatchHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var batchRequest models.LMEvalBatchRequest
+	err := app.ReadJSON(w, r, &batchRequest)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if err := validateLMEvalBatchRequest(&batchRequest); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	// Resolve templates once per task set rather than once per evaluation
+	taskSets := make([]models.LMEvalCreateRequest, len(batchRequest.TaskSets))
+	for i, taskSet := range batchRequest.TaskSets {
+		taskSets[i] = models.LMEvalCreateRequest{
+			Tasks:           taskSet.Tasks,
+			AllowRemoteCode: batchRequest.AllowRemoteCode,
+			AllowOnline:     batchRequest.AllowOnline,
+			BatchSize:       batchRequest.BatchSize,
+			NumFewShot:      batchRequest.NumFewShot,
+			Limit:           batchRequest.Limit,
+			TemplateRef:     taskSet.TemplateRef,
+			Labels:          batchRequest.Labels,
+			Experiment:      batchRequest.Experiment,
+			Regression:      batchRequest.Regression,
+			Thresholds:      batchRequest.Thresholds,
+		}
+		if taskSet.TemplateRef != nil {
+			template, err := app.resolveEvaluationTemplate(ctx, namespace, *taskSet.TemplateRef)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					app.badRequestResponse(w, r, fmt.Errorf("template %q not found", taskSet.TemplateRef.Name))
+					return
+				}
+				app.evaluationTemplateErrorResponse(w, r, err)
+				return
+			}
+			applyEvaluationTemplate(&taskSets[i], template)
+		}
+		if len(taskSets[i].Tasks) == 0 {
+			app.badRequestResponse(w, r, fmt.Errorf("task set %q has no tasks", taskSet.Name))
+			return
+		}
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	user := app.resolveUser(client, identity)
+	auditUser := app.auditUser(client, identity)
+
+	result := &models.LMEvalBatchResult{
+		BatchName: batchRequest.BatchName,
+		Items:     []models.LMEvalBatchItemResult{},
+	}
+	seen := map[string]bool{}
+	started := 0
+
+	for _, model := range batchRequest.Models {
+		for i, taskSet := range batchRequest.TaskSets {
+			name := lmEvalBatchItemName(batchRequest.BatchName, model.Model.Name, taskSet.Name)
+			item := models.LMEvalBatchItemResult{
+				Name:      name,
+				ModelName: model.Model.Name,
+				TaskSet:   taskSet.Name,
+			}
+
+			if seen[name] {
+				item.Status = models.BatchItemFailed
+				item.Error = fmt.Sprintf("duplicate evaluation name %q", name)
+				result.Items = append(result.Items, item)
+				result.Failed++
+				continue
+			}
+			seen[name] = true
+
+			createRequest := taskSets[i]
+			createRequest.K8sName = name
+			createRequest.ModelType = model.ModelType
+			createRequest.Model = model.Model
+			createRequest.EvaluationName = lmEvalBatchItemDisplayName(&batchRequest, model.Model.Name, taskSet.Name)
+
+			lmEvalJob := newLMEvalJob(namespace, createRequest, user)
+			lmEvalJob.Metadata.Labels[constants.BatchLabel] = batchRequest.BatchName
+			if batchRequest.MaxConcurrent > 0 {
+				lmEvalJob.Metadata.Annotations[constants.BatchMaxConcurrentAnnotation] = strconv.Itoa(batchRequest.MaxConcurrent)
+				lmEvalJob.Spec.Suspend = started >= batchRequest.MaxConcurrent
+			}
+
+			created, err := client.CreateLMEvalJob(ctx, identity, namespace, lmEvalJob)
+			app.recordAudit(r, auditUser, AuditActionCreateEvaluation, namespace, "lmevaljobs/"+name, err)
+			if err != nil {
+				item.Status = models.BatchItemFailed
+				item.Error = err.Error()
+				result.Items = append(result.Items, item)
+				result.Failed++
+				continue
+			}
+			app.publisher.Created(ctx, created, user)
+
+			item.Status = models.BatchItemCreated
+			if lmEvalJob.Spec.Suspend {
+				item.Status = models.BatchItemQueued
+			} else {
+				started++
+			}
+			result.Items = append(result.Items, item)
+			result.Created++
+		}
+	}
+
+	status := http.StatusCreated
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	response := LMEvalBatchEnvelope{
+		Data: result,
+	}
+
+	err = app.WriteJSON(w, status, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func validateLMEvalBatchRequest(batchRequest *models.LMEvalBatchRequest) error {
+	if batchRequest.BatchName == "" {
+		return fmt.Errorf("batchName is required")
+	}
+	if errs := validation.IsDNS1123Label(batchRequest.BatchName); len(errs) > 0 {
+		return fmt.Errorf("invalid batchName %q: %s", batchRequest.BatchName, strings.Join(errs, ", "))
+	}
+	if len(batchRequest.Models) == 0 {
+		return fmt.Errorf("at least one model is required")
+	}
+	if len(batchRequest.TaskSets) == 0 {
+		return fmt.Errorf("at least one task set is required")
+	}
+	if size := len(batchRequest.Models) * len(batchRequest.TaskSets); size > maxBatchSize {
+		return fmt.Errorf("batch expands to %d evaluations, the maximum is %d", size, maxBatchSize)
+	}
+	if batchRequest.MaxConcurrent < 0 {
+		return fmt.Errorf("maxConcurrent must not be negative")
+	}
+	if batchRequest.NumFewShot != nil && *batchRequest.NumFewShot < 0 {
+		return fmt.Errorf("numFewShot must not be negative")
+	}
+	if err := validateEvaluationLabels(batchRequest.Labels, batchRequest.Experiment); err != nil {
+		return err
+	}
+	if batchRequest.Regression != nil {
+		if err := regression.Validate(batchRequest.Regression); err != nil {
+			return err
+		}
+	}
+	if err := gate.Validate(batchRequest.Thresholds); err != nil {
+		return err
+	}
+
+	for _, model := range batchRequest.Models {
+		if model.ModelType == "" {
+			return fmt.Errorf("modelType is required for every model")
+		}
+		if model.Model.Name == "" {
+			return fmt.Errorf("model.name is required for every model")
+		}
+	}
+
+	taskSetNames := map[string]bool{}
+	for _, taskSet := range batchRequest.TaskSets {
+		if errs := validation.IsDNS1123Label(taskSet.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid task set name %q: %s", taskSet.Name, strings.Join(errs, ", "))
+		}
+		if taskSetNames[taskSet.Name] {
+			return fmt.Errorf("duplicate task set name %q", taskSet.Name)
+		}
+		taskSetNames[taskSet.Name] = true
+		if len(taskSet.Tasks) == 0 && taskSet.TemplateRef == nil {
+			return fmt.Errorf("task set %q needs tasks or a templateRef", taskSet.Name)
+		}
+	}
+
+	return nil
+}
+
+// lmEvalBatchItemName derives "<batch>-<model>-<task set>", trimmed to a valid resource name
+func lmEvalBatchItemName(batchName, modelName, taskSetName string) string {
+	model := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(modelName), "-"), "-")
+	name := batchName + "-" + model + "-" + taskSetName
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+func lmEvalBatchItemDisplayName(batchRequest *models.LMEvalBatchRequest, modelName, taskSetName string) string {
+	prefix := batchRequest.EvaluationName
+	if prefix == "" {
+		prefix = batchRequest.BatchName
+	}
+	return fmt.Sprintf("%s: %s / %s", prefix, modelName, taskSetName)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestCreateLMEvalBatchHandler(t *testing.T) {
+	app, client := newTestApp()
+
+	batchRequest := models.LMEvalBatchRequest{
+		BatchName: "llama-sweep",
+		Models: []models.LMEvalBatchModel{
+			{ModelType: "local-completions", Model: models.LMEvalModelConfig{Name: "Llama-2-7B"}},
+			{ModelType: "local-completions", Model: models.LMEvalModelConfig{Name: "Llama-2-13B"}},
+		},
+		TaskSets: []models.LMEvalBatchTaskSet{
+			{Name: "reasoning", Tasks: []string{"arc_easy", "hellaswag"}},
+			{Name: "safety", Tasks: []string{"toxigen"}},
+		},
+		MaxConcurrent: 3,
+	}
+
+	w := httptest.NewRecorder()
+	app.CreateLMEvalBatchHandler(w, newTestRequest("POST", "/api/v1/evaluations/batch?namespace=project-1", batchRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response LMEvalBatchEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 4, response.Data.Created)
+	assert.Equal(t, 0, response.Data.Failed)
+	assert.Equal(t, "llama-sweep-llama-2-7b-reasoning", response.Data.Items[0].Name)
+
+	// Items over the concurrency cap are queued
+	statuses := map[string]int{}
+	for _, item := range response.Data.Items {
+		statuses[item.Status]++
+	}
+	assert.Equal(t, map[string]int{models.BatchItemCreated: 3, models.BatchItemQueued: 1}, statuses)
+
+	job, err := client.GetLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "llama-sweep-llama-2-13b-safety")
+	assert.NoError(t, err)
+	assert.True(t, job.Spec.Suspend)
+	assert.Equal(t, "llama-sweep", job.Metadata.Labels[constants.BatchLabel])
+	assert.Equal(t, "test-user", job.Metadata.Annotations[constants.CreatedByAnnotation])
+
+	// Resubmitting reports every item as failed instead of aborting the batch
+	w = httptest.NewRecorder()
+	app.CreateLMEvalBatchHandler(w, newTestRequest("POST", "/api/v1/evaluations/batch?namespace=project-1", batchRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 0, response.Data.Created)
+	assert.Equal(t, 4, response.Data.Failed)
+	assert.NotEmpty(t, response.Data.Items[0].Error)
+}
+
+func TestCreateLMEvalBatchHandlerValidation(t *testing.T) {
+	app, _ := newTestApp()
+
+	tests := map[string]models.LMEvalBatchRequest{
+		"missing batch name": {
+			Models:   []models.LMEvalBatchModel{{ModelType: "hf", Model: models.LMEvalModelConfig{Name: "gpt2"}}},
+			TaskSets: []models.LMEvalBatchTaskSet{{Name: "reasoning", Tasks: []string{"arc_easy"}}},
+		},
+		"no task sets": {
+			BatchName: "sweep",
+			Models:    []models.LMEvalBatchModel{{ModelType: "hf", Model: models.LMEvalModelConfig{Name: "gpt2"}}},
+		},
+		"empty task set": {
+			BatchName: "sweep",
+			Models:    []models.LMEvalBatchModel{{ModelType: "hf", Model: models.LMEvalModelConfig{Name: "gpt2"}}},
+			TaskSets:  []models.LMEvalBatchTaskSet{{Name: "reasoning"}},
+		},
+		"missing template": {
+			BatchName: "sweep",
+			Models:    []models.LMEvalBatchModel{{ModelType: "hf", Model: models.LMEvalModelConfig{Name: "gpt2"}}},
+			TaskSets:  []models.LMEvalBatchTaskSet{{Name: "reasoning", TemplateRef: &models.EvaluationTemplateRef{Name: "missing"}}},
+		},
+	}
+
+	for name, batchRequest := range tests {
+		w := httptest.NewRecorder()
+		app.CreateLMEvalBatchHandler(w, newTestRequest("POST", "/api/v1/evaluations/batch?namespace=project-1", batchRequest, "test-user"), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type LMEvalBulkDeleteEnvelope Envelope[*models.LMEvalBulkDeleteResult, None]
+
+// bulkDeleteStates are the displayed states evaluations can be selected by
+var bulkDeleteStates = map[string]bool{
+	models.LMEvalJobStatePending:   true,
+	models.LMEvalJobStateNew:       true,
+	models.LMEvalJobStateScheduled: true,
+	models.LMEvalJobStateRunning:   true,
+	models.LMEvalJobStateSuspended: true,
+	models.LMEvalJobStateComplete:  true,
+	models.LMEvalJobReasonFailed:   true,
+	models.LMEvalJobStateCancelled: true,
+}
+
+// lmEvalJobFilter is a validated bulk delete request
+type lmEvalJobFilter struct {
+	selector  labels.Selector
+	olderThan time.Duration
+	states    map[string]bool
+}
+
+func (f *lmEvalJobFilter) matches(job *models.LMEvalJobKind, now time.Time) bool {
+	if !f.selector.Matches(labels.Set(job.Metadata.Labels)) {
+		return false
+	}
+	if f.olderThan > 0 && now.Sub(job.Metadata.CreationTimestamp) < f.olderThan {
+		return false
+	}
+	return len(f.states) == 0 || f.states[job.DisplayState()]
+}
+
+// BulkDeleteLMEvalsHandler handles POST /api/v1/evaluations:bulkDelete. It deletes the
+// evaluations of a namespace matching every filter of the request, or only lists them in
+// a dry run. Results stored on a PVC managed by the operator are deleted with their job.
+// The response is 200 when every evaluation was deleted and 207 when some failed.
+func (app *App) BulkDeleteLMEvalsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var deleteRequest models.LMEvalBulkDeleteRequest
+	if err := app.ReadJSON(w, r, &deleteRequest); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	filter, err := newLMEvalJobFilter(&deleteRequest)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	jobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	var matching []models.LMEvalJobKind
+	for _, job := range jobs.Items {
+		if filter.matches(&job, now) {
+			matching = append(matching, job)
+		}
+	}
+	// Oldest first, the order a namespace is usually cleaned up in
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].Metadata.CreationTimestamp.Equal(matching[j].Metadata.CreationTimestamp) {
+			return matching[i].Metadata.Name < matching[j].Metadata.Name
+		}
+		return matching[i].Metadata.CreationTimestamp.Before(matching[j].Metadata.CreationTimestamp)
+	})
+
+	result := &models.LMEvalBulkDeleteResult{
+		DryRun:  deleteRequest.DryRun,
+		Matched: len(matching),
+		Items:   []models.LMEvalBulkDeleteItemResult{},
+	}
+	auditUser := app.auditUser(client, identity)
+	for _, job := range matching {
+		item := models.LMEvalBulkDeleteItemResult{
+			Name:              job.Metadata.Name,
+			State:             job.DisplayState(),
+			CreationTimestamp: job.Metadata.CreationTimestamp,
+			Status:            models.BulkDeleteItemMatched,
+		}
+		if !deleteRequest.DryRun {
+			// Evaluations that could not be archived are kept, like failed deletions
+			err := app.archiveBeforeDelete(ctx, &job)
+			if err != nil {
+				err = fmt.Errorf("failed to archive evaluation: %w", err)
+			} else {
+				err = client.DeleteLMEvalJob(ctx, identity, namespace, job.Metadata.Name)
+			}
+			app.recordAudit(r, auditUser, AuditActionDeleteEvaluation, namespace, "lmevaljobs/"+job.Metadata.Name, err)
+			if err != nil {
+				item.Status = models.BulkDeleteItemFailed
+				item.Error = err.Error()
+				result.Failed++
+			} else {
+				item.Status = models.BulkDeleteItemDeleted
+				result.Deleted++
+			}
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	status := http.StatusOK
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	if err := app.WriteJSON(w, status, LMEvalBulkDeleteEnvelope{Data: result}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func newLMEvalJobFilter(req *models.LMEvalBulkDeleteRequest) (*lmEvalJobFilter, error) {
+	if req.LabelSelector == "" && req.OlderThan == "" && len(req.States) == 0 {
+		return nil, errors.New("at least one of labelSelector, olderThan or states is required")
+	}
+
+	selector, err := labels.Parse(req.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labelSelector: %w", err)
+	}
+	filter := &lmEvalJobFilter{selector: selector, states: map[string]bool{}}
+
+	if req.OlderThan != "" {
+		if filter.olderThan, err = parseAge(req.OlderThan); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, state := range req.States {
+		if !bulkDeleteStates[state] {
+			return nil, fmt.Errorf("invalid state %q", state)
+		}
+		filter.states[state] = true
+	}
+	return filter, nil
+}
+
+// parseAge parses a positive age given in days, e.g. 30d, or as a Go duration, e.g. 12h
+func parseAge(value string) (time.Duration, error) {
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid olderThan %q: expected a number of days such as 30d or a duration such as 12h", value)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if age, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid olderThan %q: expected a number of days such as 30d or a duration such as 12h", value)
+		}
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("olderThan must be positive")
+	}
+	return age, nil
+}
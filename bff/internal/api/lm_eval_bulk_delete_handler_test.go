@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func createBulkDeleteTestJob(t *testing.T, client kubernetes.KubernetesClientInterface, name, team, status string, age time.Duration) {
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err := client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: name, Labels: map[string]string{"team": team}},
+	})
+	assert.NoError(t, err)
+
+	created := time.Now().Add(-age).UTC().Format(time.RFC3339)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", name,
+		[]byte(`{"metadata":{"creationTimestamp":"`+created+`"},"status":`+status+`}`))
+	assert.NoError(t, err)
+}
+
+func TestBulkDeleteLMEvalsHandler(t *testing.T) {
+	app, client := newTestApp()
+	createBulkDeleteTestJob(t, client, "old-complete", "nlp", `{"state":"Complete"}`, 40*24*time.Hour)
+	createBulkDeleteTestJob(t, client, "old-failed", "nlp", `{"state":"Complete","reason":"Failed"}`, 35*24*time.Hour)
+	createBulkDeleteTestJob(t, client, "recent-complete", "nlp", `{"state":"Complete"}`, time.Hour)
+	createBulkDeleteTestJob(t, client, "old-running", "nlp", `{"state":"Running"}`, 40*24*time.Hour)
+	createBulkDeleteTestJob(t, client, "old-vision", "vision", `{"state":"Complete"}`, 40*24*time.Hour)
+
+	bulkDelete := func(body string) (*httptest.ResponseRecorder, *models.LMEvalBulkDeleteResult) {
+		w := httptest.NewRecorder()
+		app.BulkDeleteLMEvalsHandler(w, newTestRequest("POST", "/api/v1/evaluations:bulkDelete?namespace=project-1", json.RawMessage(body), "test-user"), nil)
+		var response LMEvalBulkDeleteEnvelope
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response.Data
+	}
+	names := func(result *models.LMEvalBulkDeleteResult) []string {
+		var names []string
+		for _, item := range result.Items {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+
+	// A dry run lists the matching evaluations, oldest first, and keeps them
+	w, result := bulkDelete(`{"labelSelector":"team=nlp","olderThan":"30d","states":["Complete","Failed"],"dryRun":true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if !assert.NotNil(t, result) {
+		return
+	}
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, 0, result.Deleted)
+	assert.Equal(t, []string{"old-complete", "old-failed"}, names(result))
+	assert.Equal(t, models.BulkDeleteItemMatched, result.Items[0].Status)
+	assert.Equal(t, models.LMEvalJobReasonFailed, result.Items[1].State)
+
+	jobs, err := client.ListLMEvalJobs(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1")
+	assert.NoError(t, err)
+	assert.Len(t, jobs.Items, 5)
+
+	w, result = bulkDelete(`{"labelSelector":"team=nlp","olderThan":"30d","states":["Complete","Failed"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if !assert.NotNil(t, result) {
+		return
+	}
+	assert.Equal(t, 2, result.Deleted)
+	assert.Equal(t, models.BulkDeleteItemDeleted, result.Items[0].Status)
+
+	jobs, err = client.ListLMEvalJobs(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1")
+	assert.NoError(t, err)
+	var remaining []string
+	for _, job := range jobs.Items {
+		remaining = append(remaining, job.Metadata.Name)
+	}
+	assert.ElementsMatch(t, []string{"recent-complete", "old-running", "old-vision"}, remaining)
+}
+
+func TestBulkDeleteLMEvalsHandlerValidatesTheRequest(t *testing.T) {
+	app, _ := newTestApp()
+
+	for _, body := range []string{
+		`{}`,
+		`{"dryRun":true}`,
+		`{"labelSelector":"team in (nlp"}`,
+		`{"olderThan":"a month"}`,
+		`{"olderThan":"0d"}`,
+		`{"states":["Succeeded"]}`,
+	} {
+		w := httptest.NewRecorder()
+		app.BulkDeleteLMEvalsHandler(w, newTestRequest("POST", "/api/v1/evaluations:bulkDelete?namespace=project-1", json.RawMessage(body), "test-user"), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := httptest.NewRecorder()
+	app.BulkDeleteLMEvalsHandler(w, newTestRequest("POST", "/api/v1/evaluations:bulkDelete", json.RawMessage(`{"olderThan":"12h"}`), "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,20 +1,29 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/datasets"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/gate"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/guardrails"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/regression"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type LMEvalEnvelope Envelope[*models.LMEvalKind, None]
 type LMEvalListEnvelope Envelope[*models.LMEvalList, None]
 type LMEvalJobEnvelope Envelope[*models.LMEvalJobKind, None]
 type LMEvalJobListEnvelope Envelope[*models.LMEvalJobList, None]
+type LMEvalJobDetailEnvelope Envelope[*models.LMEvalJobKind, *models.LMEvalJobInsights]
 
 // CreateLMEvalHandler handles POST /api/v1/evaluations
 func (app *App) CreateLMEvalHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -49,8 +58,45 @@ func (app *App) CreateLMEvalHandler(w http.ResponseWriter, r *http.Request, ps h
 		app.badRequestResponse(w, r, fmt.Errorf("modelType is required"))
 		return
 	}
-	if len(createRequest.Tasks) == 0 {
-		app.badRequestResponse(w, r, fmt.Errorf("at least one task is required"))
+
+	// Apply the referenced template before validating, it may supply the task list
+	if createRequest.TemplateRef != nil {
+		template, err := app.resolveEvaluationTemplate(ctx, namespace, *createRequest.TemplateRef)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				app.badRequestResponse(w, r, fmt.Errorf("template %q not found", createRequest.TemplateRef.Name))
+				return
+			}
+			app.evaluationTemplateErrorResponse(w, r, err)
+			return
+		}
+		applyEvaluationTemplate(&createRequest, template)
+	}
+
+	if len(createRequest.Tasks) == 0 && createRequest.Dataset == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("at least one task or a dataset is required"))
+		return
+	}
+	if createRequest.Dataset != nil && createRequest.AllowOnline != nil && *createRequest.AllowOnline {
+		app.badRequestResponse(w, r, fmt.Errorf("evaluations of a dataset run offline, allowOnline must be false"))
+		return
+	}
+	if createRequest.NumFewShot != nil && *createRequest.NumFewShot < 0 {
+		app.badRequestResponse(w, r, fmt.Errorf("numFewShot must not be negative"))
+		return
+	}
+	if err := validateEvaluationLabels(createRequest.Labels, createRequest.Experiment); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if createRequest.Regression != nil {
+		if err := regression.Validate(createRequest.Regression); err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+	if err := gate.Validate(createRequest.Thresholds); err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
@@ -61,41 +107,58 @@ func (app *App) CreateLMEvalHandler(w http.ResponseWriter, r *http.Request, ps h
 		return
 	}
 
+	// An explicit baseline has to exist when the evaluation is created
+	if createRequest.Regression != nil && createRequest.Regression.Baseline != models.RegressionBaselinePrevious {
+		if _, err := client.GetLMEvalJob(ctx, identity, namespace, createRequest.Regression.Baseline); err != nil {
+			if apierrors.IsNotFound(err) {
+				app.badRequestResponse(w, r, fmt.Errorf("baseline evaluation %q not found", createRequest.Regression.Baseline))
+				return
+			}
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	var dataset *models.Dataset
+	if createRequest.Dataset != nil {
+		dataset, err = loadDataset(ctx, client, namespace, createRequest.Dataset.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				app.badRequestResponse(w, r, fmt.Errorf("dataset %q not found", createRequest.Dataset.Name))
+				return
+			}
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+		switch dataset.State {
+		case models.DatasetStateLoading:
+			app.conflictResponse(w, r, fmt.Errorf("dataset %q is still loading", dataset.Name))
+			return
+		case models.DatasetStateFailed:
+			app.badRequestResponse(w, r, fmt.Errorf("dataset %q failed to load, upload it again", dataset.Name))
+			return
+		}
+	}
+
+	user := app.resolveUser(client, identity)
+
 	// Convert create request to LMEvalJobKind
-	lmEvalJob := &models.LMEvalJobKind{
-		APIVersion: "trustyai.opendatahub.io/v1alpha1",
-		Kind:       "LMEvalJob",
-		Metadata: models.LMEvalJobMetadata{
-			Name:      createRequest.K8sName,
-			Namespace: namespace,
-			Annotations: map[string]string{
-				"opendatahub.io/display-name": createRequest.EvaluationName,
-			},
-		},
-		Spec: models.LMEvalJobSpec{
-			AllowCodeExecution: createRequest.AllowRemoteCode,
-			AllowOnline:        createRequest.AllowOnline,
-			BatchSize:          createRequest.BatchSize,
-			LogSamples:         true,
-			Model:              mapModelTypeToSupportedType(createRequest.ModelType),
-			ModelArgs:          convertModelArgsToJob(createRequest.Model),
-			TaskList: models.LMEvalJobTaskList{
-				TaskNames: createRequest.Tasks,
-			},
-			Outputs: &models.LMEvalJobOutputs{
-				PVCManaged: &models.LMEvalJobPVCManaged{
-					Size: "100Mi",
-				},
-			},
-		},
+	lmEvalJob := newLMEvalJob(namespace, createRequest, user)
+	if dataset != nil {
+		if err := datasets.Apply(lmEvalJob, dataset, *createRequest.Dataset); err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
 	}
 
 	// Create the LMEvalJob resource
 	createdLMEvalJob, err := client.CreateLMEvalJob(ctx, identity, namespace, lmEvalJob)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateEvaluation, namespace, "lmevaljobs/"+createRequest.K8sName, err)
 	if err != nil {
 		app.serverErrorResponse(w, r, fmt.Errorf("failed to create LMEvalJob: %w", err))
 		return
 	}
+	app.publisher.Created(ctx, createdLMEvalJob, user)
 
 	// Return the created resource
 	response := LMEvalJobEnvelope{
@@ -144,9 +207,10 @@ func (app *App) GetLMEvalHandler(w http.ResponseWriter, r *http.Request, ps http
 		return
 	}
 
-	// Return the resource
-	response := LMEvalJobEnvelope{
-		Data: lmEvalJob,
+	// Return the resource together with anything derived from it
+	response := LMEvalJobDetailEnvelope{
+		Data:     lmEvalJob,
+		Metadata: app.lmEvalJobInsights(ctx, client, identity, lmEvalJob),
 	}
 
 	err = app.WriteJSON(w, http.StatusOK, response, nil)
@@ -167,6 +231,20 @@ func (app *App) ListLMEvalsHandler(w http.ResponseWriter, r *http.Request, ps ht
 	// Parse namespace from query parameter (optional for listing)
 	namespace := r.URL.Query().Get("namespace")
 
+	// Optional label selector, e.g. trustyai.opendatahub.io/experiment=q3-release
+	selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid labelSelector: %w", err))
+		return
+	}
+
+	// Optional kind, the list has both LMEvalJobs and guardrails evaluations by default
+	kind := r.URL.Query().Get("kind")
+	if kind != "" && kind != models.EvaluationKindLMEval && kind != models.EvaluationKindGuardrails {
+		app.badRequestResponse(w, r, fmt.Errorf("kind must be %q or %q", models.EvaluationKindLMEval, models.EvaluationKindGuardrails))
+		return
+	}
+
 	// Get Kubernetes client
 	client, err := app.kubernetesClientFactory.GetClient(r.Context())
 	if err != nil {
@@ -181,6 +259,35 @@ func (app *App) ListLMEvalsHandler(w http.ResponseWriter, r *http.Request, ps ht
 		return
 	}
 
+	// Guardrails evaluations are stored in ConfigMaps; users who cannot list them still get
+	// their LMEvalJobs unless they asked for guardrails evaluations only
+	if kind == models.EvaluationKindGuardrails {
+		lmEvalJobList.Items = []models.LMEvalJobKind{}
+	}
+	if kind != models.EvaluationKindLMEval {
+		evaluations, err := app.listGuardrailsEvaluations(ctx, client, namespace)
+		if err != nil {
+			if kind == models.EvaluationKindGuardrails {
+				app.kubernetesErrorResponse(w, r, err)
+				return
+			}
+			app.logger.Warn("leaving guardrails evaluations out of the evaluation list", "namespace", namespace, "error", err)
+		}
+		for i := range evaluations {
+			lmEvalJobList.Items = append(lmEvalJobList.Items, guardrails.AsLMEvalJob(&evaluations[i]))
+		}
+	}
+
+	if !selector.Empty() {
+		matching := []models.LMEvalJobKind{}
+		for _, job := range lmEvalJobList.Items {
+			if selector.Matches(labels.Set(job.Metadata.Labels)) {
+				matching = append(matching, job)
+			}
+		}
+		lmEvalJobList.Items = matching
+	}
+
 	// Return the list
 	response := LMEvalJobListEnvelope{
 		Data: lmEvalJobList,
@@ -221,8 +328,22 @@ func (app *App) DeleteLMEvalHandler(w http.ResponseWriter, r *http.Request, ps h
 		return
 	}
 
+	// Snapshot the evaluation first, its results are lost with the resource
+	if app.archive != nil {
+		lmEvalJob, err := client.GetLMEvalJob(ctx, identity, namespace, name)
+		if err != nil {
+			app.kubernetesErrorResponse(w, r, err)
+			return
+		}
+		if err := app.archiveBeforeDelete(ctx, lmEvalJob); err != nil {
+			app.serverErrorResponse(w, r, fmt.Errorf("failed to archive LMEvalJob, it was not deleted: %w", err))
+			return
+		}
+	}
+
 	// Delete the LMEvalJob resource
 	err = client.DeleteLMEvalJob(ctx, identity, namespace, name)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteEvaluation, namespace, "lmevaljobs/"+name, err)
 	if err != nil {
 		app.serverErrorResponse(w, r, fmt.Errorf("failed to delete LMEvalJob: %w", err))
 		return
@@ -232,6 +353,139 @@ func (app *App) DeleteLMEvalHandler(w http.ResponseWriter, r *http.Request, ps h
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// lmEvalJobInsights derives the regression verdict and quality gate of an evaluation. Insights
+// are best effort: failures are logged and reported in the verdict rather than failing the request.
+func (app *App) lmEvalJobInsights(ctx context.Context, client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity, lmEvalJob *models.LMEvalJobKind) *models.LMEvalJobInsights {
+	insights := &models.LMEvalJobInsights{
+		Regression:  app.lmEvalJobRegression(ctx, client, identity, lmEvalJob),
+		QualityGate: app.lmEvalJobQualityGate(lmEvalJob),
+	}
+	if insights.Regression == nil && insights.QualityGate == nil {
+		return nil
+	}
+	return insights
+}
+
+func (app *App) lmEvalJobRegression(ctx context.Context, client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity, lmEvalJob *models.LMEvalJobKind) *models.RegressionReport {
+	policy, err := regression.PolicyFor(lmEvalJob)
+	if err != nil {
+		app.logger.Warn("ignoring regression policy", "evaluation", lmEvalJob.Metadata.Name, "error", err)
+		return nil
+	}
+	if policy == nil {
+		return nil
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, lmEvalJob.Metadata.Namespace)
+	if err != nil {
+		app.logger.Warn("failed to list baseline candidates", "evaluation", lmEvalJob.Metadata.Name, "error", err)
+		return &models.RegressionReport{
+			Verdict:     models.RegressionVerdictUnknown,
+			Reason:      "failed to look up the baseline evaluation",
+			Comparisons: []models.MetricComparison{},
+		}
+	}
+
+	baseline := regression.FindBaseline(lmEvalJob, policy, lmEvalJobs.Items)
+	return regression.Compare(lmEvalJob, baseline, policy)
+}
+
+func (app *App) lmEvalJobQualityGate(lmEvalJob *models.LMEvalJobKind) *models.QualityGateResult {
+	thresholds, err := gate.ThresholdsFor(lmEvalJob)
+	if err != nil {
+		app.logger.Warn("ignoring quality gate", "evaluation", lmEvalJob.Metadata.Name, "error", err)
+		return nil
+	}
+	if thresholds == nil {
+		return nil
+	}
+	return gate.Evaluate(lmEvalJob, thresholds)
+}
+
+// validateEvaluationLabels checks user supplied labels. Labels in the BFF's own
+// trustyai.opendatahub.io domain are reserved, except for the experiment label.
+func validateEvaluationLabels(labels map[string]string, experiment string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value for label %q: %s", key, strings.Join(errs, ", "))
+		}
+		if strings.HasPrefix(key, constants.ManagedLabelPrefix) && key != constants.ExperimentLabel {
+			return fmt.Errorf("label %q is reserved", key)
+		}
+	}
+	if errs := validation.IsValidLabelValue(experiment); len(errs) > 0 {
+		return fmt.Errorf("invalid experiment %q: %s", experiment, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// newLMEvalJob converts a create request into the LMEvalJob submitted to the cluster.
+// It is shared by the create handler and the scheduler.
+func newLMEvalJob(namespace string, createRequest models.LMEvalCreateRequest, createdBy string) *models.LMEvalJobKind {
+	lmEvalJob := &models.LMEvalJobKind{
+		APIVersion: "trustyai.opendatahub.io/v1alpha1",
+		Kind:       "LMEvalJob",
+		Metadata: models.LMEvalJobMetadata{
+			Name:      createRequest.K8sName,
+			Namespace: namespace,
+			Labels:    map[string]string{},
+			Annotations: map[string]string{
+				constants.DisplayNameAnnotation: createRequest.EvaluationName,
+				constants.CreatedByAnnotation:   createdBy,
+			},
+		},
+		Spec: models.LMEvalJobSpec{
+			AllowCodeExecution: createRequest.AllowRemoteCode != nil && *createRequest.AllowRemoteCode,
+			AllowOnline:        createRequest.AllowOnline != nil && *createRequest.AllowOnline,
+			BatchSize:          createRequest.BatchSize,
+			LogSamples:         true,
+			Model:              mapModelTypeToSupportedType(createRequest.ModelType),
+			ModelArgs:          convertModelArgsToJob(createRequest.Model),
+			NumFewShot:         createRequest.NumFewShot,
+			Limit:              createRequest.Limit,
+			TaskList: models.LMEvalJobTaskList{
+				TaskNames: createRequest.Tasks,
+			},
+			Outputs: &models.LMEvalJobOutputs{
+				PVCManaged: &models.LMEvalJobPVCManaged{
+					Size: "100Mi",
+				},
+			},
+		},
+	}
+
+	for key, value := range createRequest.Labels {
+		lmEvalJob.Metadata.Labels[key] = value
+	}
+	if createRequest.Experiment != "" {
+		lmEvalJob.Metadata.Labels[constants.ExperimentLabel] = createRequest.Experiment
+	}
+
+	if createRequest.Regression != nil {
+		if policy, err := regression.EncodePolicy(createRequest.Regression); err == nil {
+			lmEvalJob.Metadata.Annotations[constants.RegressionPolicyAnnotation] = policy
+		}
+	}
+	if len(createRequest.Thresholds) > 0 {
+		if thresholds, err := gate.Encode(createRequest.Thresholds); err == nil {
+			lmEvalJob.Metadata.Annotations[constants.QualityGateAnnotation] = thresholds
+		}
+	}
+
+	if createRequest.TemplateRef != nil {
+		scope := createRequest.TemplateRef.Scope
+		if scope == "" {
+			scope = models.TemplateScopeNamespace
+		}
+		lmEvalJob.Metadata.Annotations[constants.TemplateAnnotation] = scope + "/" + createRequest.TemplateRef.Name
+	}
+
+	return lmEvalJob
+}
+
 // Helper function to convert model configuration to LMEvalJob model arguments
 func convertModelArgsToJob(modelConfig models.LMEvalModelConfig) []models.LMEvalJobModelArg {
 	var args []models.LMEvalJobModelArg
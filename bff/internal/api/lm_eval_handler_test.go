@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,10 +13,14 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	authv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -28,6 +34,11 @@ func (m *MockKubernetesClientFactory) GetClient(ctx context.Context) (kubernetes
 	return args.Get(0).(kubernetes.KubernetesClientInterface), args.Error(1)
 }
 
+func (m *MockKubernetesClientFactory) GetServiceAccountClient() (kubernetes.KubernetesClientInterface, error) {
+	args := m.Called()
+	return args.Get(0).(kubernetes.KubernetesClientInterface), args.Error(1)
+}
+
 func (m *MockKubernetesClientFactory) ExtractRequestIdentity(httpHeader http.Header) (*kubernetes.RequestIdentity, error) {
 	args := m.Called(httpHeader)
 	return args.Get(0).(*kubernetes.RequestIdentity), args.Error(1)
@@ -63,6 +74,11 @@ func (m *MockKubernetesClient) GetModelServingServices(ctx context.Context, name
 	return args.Get(0).([]kubernetes.ServiceDetails), args.Error(1)
 }
 
+func (m *MockKubernetesClient) GetGuardrailsOrchestrators(ctx context.Context, namespace string) ([]models.GuardrailsOrchestrator, error) {
+	args := m.Called(ctx, namespace)
+	return args.Get(0).([]models.GuardrailsOrchestrator), args.Error(1)
+}
+
 func (m *MockKubernetesClient) GetNamespaces(ctx context.Context, identity *kubernetes.RequestIdentity) ([]corev1.Namespace, error) {
 	args := m.Called(ctx, identity)
 	return args.Get(0).([]corev1.Namespace), args.Error(1)
@@ -113,11 +129,116 @@ func (m *MockKubernetesClient) ListLMEvalJobs(ctx context.Context, identity *kub
 	return args.Get(0).(*models.LMEvalJobList), args.Error(1)
 }
 
+func (m *MockKubernetesClient) WatchLMEvalJobs(ctx context.Context, identity *kubernetes.RequestIdentity, namespace string) (<-chan kubernetes.LMEvalJobEvent, error) {
+	args := m.Called(ctx, identity, namespace)
+	return args.Get(0).(<-chan kubernetes.LMEvalJobEvent), args.Error(1)
+}
+
 func (m *MockKubernetesClient) DeleteLMEvalJob(ctx context.Context, identity *kubernetes.RequestIdentity, namespace, name string) error {
 	args := m.Called(ctx, identity, namespace, name)
 	return args.Error(0)
 }
 
+func (m *MockKubernetesClient) PatchLMEvalJob(ctx context.Context, identity *kubernetes.RequestIdentity, namespace, name string, patch []byte) (*models.LMEvalJobKind, error) {
+	args := m.Called(ctx, identity, namespace, name, patch)
+	return args.Get(0).(*models.LMEvalJobKind), args.Error(1)
+}
+
+func (m *MockKubernetesClient) ListConfigMaps(ctx context.Context, namespace, labelSelector string) ([]corev1.ConfigMap, error) {
+	args := m.Called(ctx, namespace, labelSelector)
+	return args.Get(0).([]corev1.ConfigMap), args.Error(1)
+}
+
+func (m *MockKubernetesClient) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*corev1.Namespace), args.Error(1)
+}
+
+func (m *MockKubernetesClient) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	args := m.Called(ctx, namespace, name)
+	return args.Get(0).(*corev1.ConfigMap), args.Error(1)
+}
+
+func (m *MockKubernetesClient) CreateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	args := m.Called(ctx, namespace, configMap)
+	return args.Get(0).(*corev1.ConfigMap), args.Error(1)
+}
+
+func (m *MockKubernetesClient) UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	args := m.Called(ctx, namespace, configMap)
+	return args.Get(0).(*corev1.ConfigMap), args.Error(1)
+}
+
+func (m *MockKubernetesClient) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	args := m.Called(ctx, namespace, name)
+	return args.Error(0)
+}
+
+func (m *MockKubernetesClient) ListSecrets(ctx context.Context, namespace, labelSelector string) ([]corev1.Secret, error) {
+	args := m.Called(ctx, namespace, labelSelector)
+	return args.Get(0).([]corev1.Secret), args.Error(1)
+}
+
+func (m *MockKubernetesClient) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	args := m.Called(ctx, namespace, name)
+	return args.Get(0).(*corev1.Secret), args.Error(1)
+}
+
+func (m *MockKubernetesClient) CreateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	args := m.Called(ctx, namespace, secret)
+	return args.Get(0).(*corev1.Secret), args.Error(1)
+}
+
+func (m *MockKubernetesClient) UpdateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	args := m.Called(ctx, namespace, secret)
+	return args.Get(0).(*corev1.Secret), args.Error(1)
+}
+
+func (m *MockKubernetesClient) DeleteSecret(ctx context.Context, namespace, name string) error {
+	args := m.Called(ctx, namespace, name)
+	return args.Error(0)
+}
+
+func (m *MockKubernetesClient) CreatePersistentVolumeClaim(ctx context.Context, namespace string, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	args := m.Called(ctx, namespace, pvc)
+	return args.Get(0).(*corev1.PersistentVolumeClaim), args.Error(1)
+}
+
+func (m *MockKubernetesClient) DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error {
+	args := m.Called(ctx, namespace, name)
+	return args.Error(0)
+}
+
+func (m *MockKubernetesClient) CreateJob(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error) {
+	args := m.Called(ctx, namespace, job)
+	return args.Get(0).(*batchv1.Job), args.Error(1)
+}
+
+func (m *MockKubernetesClient) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	args := m.Called(ctx, namespace, name)
+	return args.Get(0).(*batchv1.Job), args.Error(1)
+}
+
+func (m *MockKubernetesClient) DeleteJob(ctx context.Context, namespace, name string) error {
+	args := m.Called(ctx, namespace, name)
+	return args.Error(0)
+}
+
+func (m *MockKubernetesClient) CreateEvent(ctx context.Context, namespace string, event *corev1.Event) error {
+	args := m.Called(ctx, namespace, event)
+	return args.Error(0)
+}
+
+func (m *MockKubernetesClient) ListEvents(ctx context.Context, namespace, involvedObjectName string) ([]corev1.Event, error) {
+	args := m.Called(ctx, namespace, involvedObjectName)
+	return args.Get(0).([]corev1.Event), args.Error(1)
+}
+
+func (m *MockKubernetesClient) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int64) (string, error) {
+	args := m.Called(ctx, namespace, podName, tailLines)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockKubernetesClient) IsClusterAdmin(identity *kubernetes.RequestIdentity) (bool, error) {
 	args := m.Called(identity)
 	return args.Bool(0), args.Error(1)
@@ -133,6 +254,21 @@ func (m *MockKubernetesClient) GetUser(identity *kubernetes.RequestIdentity) (st
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockKubernetesClient) GetUserInfo(identity *kubernetes.RequestIdentity) (*kubernetes.RequestIdentity, error) {
+	args := m.Called(identity)
+	return args.Get(0).(*kubernetes.RequestIdentity), args.Error(1)
+}
+
+func (m *MockKubernetesClient) CanSubjectCreateLMEvalJobs(ctx context.Context, subject *kubernetes.RequestIdentity, namespace string) (bool, error) {
+	args := m.Called(ctx, subject, namespace)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKubernetesClient) CanSubjectAccess(ctx context.Context, subject *kubernetes.RequestIdentity, attributes authv1.ResourceAttributes) (bool, error) {
+	args := m.Called(ctx, subject, attributes)
+	return args.Bool(0), args.Error(1)
+}
+
 func TestCreateLMEvalHandler(t *testing.T) {
 	// Setup
 	mockFactory := &MockKubernetesClientFactory{}
@@ -140,11 +276,13 @@ func TestCreateLMEvalHandler(t *testing.T) {
 
 	app := &App{
 		config:                  config.EnvConfig{},
-		logger:                  nil, // Will be set by test
+		logger:                  slog.Default(),
 		kubernetesClientFactory: mockFactory,
+		publisher:               notifications.NewPublisher(mockClient, slog.Default(), ""),
 	}
 
 	// Test data
+	allowRemoteCode, allowOnline := false, true
 	createRequest := models.LMEvalCreateRequest{
 		EvaluationName: "test-evaluation",
 		K8sName:        "test-evaluation",
@@ -153,8 +291,8 @@ func TestCreateLMEvalHandler(t *testing.T) {
 			Name: "test-model",
 		},
 		Tasks:           []string{"hellaswag"},
-		AllowRemoteCode: false,
-		AllowOnline:     true,
+		AllowRemoteCode: &allowRemoteCode,
+		AllowOnline:     &allowOnline,
 		BatchSize:       "8",
 	}
 
@@ -191,6 +329,9 @@ func TestCreateLMEvalHandler(t *testing.T) {
 	// Setup expectations
 	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
 	mockClient.On("CreateLMEvalJob", mock.Anything, mock.Anything, "test-namespace", mock.Anything).Return(expectedLMEvalJob, nil)
+	mockClient.On("CreateEvent", mock.Anything, "test-namespace", mock.MatchedBy(func(event *corev1.Event) bool {
+		return event.Reason == "EvaluationCreated" && event.InvolvedObject.Name == "test-evaluation"
+	})).Return(nil)
 
 	// Create request
 	requestBody, _ := json.Marshal(createRequest)
@@ -259,6 +400,7 @@ func TestListLMEvalsHandler(t *testing.T) {
 	// Setup expectations
 	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
 	mockClient.On("ListLMEvalJobs", mock.Anything, mock.Anything, "test-namespace").Return(expectedList, nil)
+	mockClient.On("ListConfigMaps", mock.Anything, "test-namespace", mock.Anything).Return([]corev1.ConfigMap{}, nil)
 
 	// Create request
 	req := httptest.NewRequest("GET", "/api/v1/evaluations?namespace=test-namespace", nil)
@@ -287,3 +429,121 @@ func TestListLMEvalsHandler(t *testing.T) {
 	mockFactory.AssertExpectations(t)
 	mockClient.AssertExpectations(t)
 }
+
+func TestCreateLMEvalHandlerStampsCreatedByAndAudits(t *testing.T) {
+	mockFactory := &MockKubernetesClientFactory{}
+	mockClient := &MockKubernetesClient{}
+	var auditBuf bytes.Buffer
+
+	app := &App{
+		config:                  config.EnvConfig{},
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+		auditLogger:             audit.NewLogger(&auditBuf),
+		publisher:               notifications.NewPublisher(mockClient, slog.Default(), ""),
+	}
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "test-evaluation",
+		K8sName:        "test-evaluation",
+		ModelType:      "test-model",
+		Tasks:          []string{"hellaswag"},
+	}
+
+	hasCreatedBy := mock.MatchedBy(func(job *models.LMEvalJobKind) bool {
+		return job.Metadata.Annotations[constants.CreatedByAnnotation] == "test-user"
+	})
+	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
+	mockClient.On("CreateLMEvalJob", mock.Anything, mock.Anything, "test-namespace", hasCreatedBy).
+		Return(&models.LMEvalJobKind{Metadata: models.LMEvalJobMetadata{Name: "test-evaluation", Namespace: "test-namespace"}}, nil)
+	mockClient.On("CreateEvent", mock.Anything, "test-namespace", mock.Anything).Return(nil)
+
+	requestBody, _ := json.Marshal(createRequest)
+	req := httptest.NewRequest("POST", "/api/v1/evaluations?namespace=test-namespace", bytes.NewBuffer(requestBody))
+	identity := &kubernetes.RequestIdentity{UserID: "test-user", Groups: []string{"team-a"}}
+	ctx := context.WithValue(req.Context(), constants.RequestIdentityKey, identity)
+	ctx = context.WithValue(ctx, constants.TraceIdKey, "trace-1")
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, req, httprouter.Params{})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockClient.AssertExpectations(t)
+
+	var entry map[string]any
+	err := json.Unmarshal(auditBuf.Bytes(), &entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-user", entry["user"])
+	assert.Equal(t, AuditActionCreateEvaluation, entry["action"])
+	assert.Equal(t, "test-namespace", entry["namespace"])
+	assert.Equal(t, "lmevaljobs/test-evaluation", entry["resource"])
+	assert.Equal(t, audit.OutcomeSuccess, entry["outcome"])
+	assert.Equal(t, "trace-1", entry["trace_id"])
+}
+
+func TestDeleteLMEvalHandlerAuditsFailure(t *testing.T) {
+	mockFactory := &MockKubernetesClientFactory{}
+	mockClient := &MockKubernetesClient{}
+	var auditBuf bytes.Buffer
+
+	app := &App{
+		config:                  config.EnvConfig{},
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+		auditLogger:             audit.NewLogger(&auditBuf),
+	}
+
+	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
+	mockClient.On("DeleteLMEvalJob", mock.Anything, mock.Anything, "test-namespace", "test-eval").Return(errors.New("forbidden"))
+
+	req := httptest.NewRequest("DELETE", "/api/v1/evaluations/test-eval?namespace=test-namespace", nil)
+	identity := &kubernetes.RequestIdentity{UserID: "test-user"}
+	req = req.WithContext(context.WithValue(req.Context(), constants.RequestIdentityKey, identity))
+
+	w := httptest.NewRecorder()
+	app.DeleteLMEvalHandler(w, req, httprouter.Params{{Key: "name", Value: "test-eval"}})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var entry map[string]any
+	err := json.Unmarshal(auditBuf.Bytes(), &entry)
+	assert.NoError(t, err)
+	assert.Equal(t, AuditActionDeleteEvaluation, entry["action"])
+	assert.Equal(t, audit.OutcomeFailure, entry["outcome"])
+	assert.Equal(t, "forbidden", entry["error"])
+}
+
+func TestDeleteLMEvalHandlerAuditsTokenUserWithGroups(t *testing.T) {
+	mockFactory := &MockKubernetesClientFactory{}
+	mockClient := &MockKubernetesClient{}
+	var auditBuf bytes.Buffer
+
+	app := &App{
+		config:                  config.EnvConfig{},
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+		auditLogger:             audit.NewLogger(&auditBuf),
+	}
+
+	identity := &kubernetes.RequestIdentity{Token: "token"}
+	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
+	mockClient.On("DeleteLMEvalJob", mock.Anything, mock.Anything, "test-namespace", "test-eval").Return(nil)
+	mockClient.On("GetUserInfo", identity).
+		Return(&kubernetes.RequestIdentity{UserID: "system:serviceaccount:ci:runner", Groups: []string{"system:serviceaccounts"}}, nil)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/evaluations/test-eval?namespace=test-namespace", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.RequestIdentityKey, identity))
+
+	w := httptest.NewRecorder()
+	app.DeleteLMEvalHandler(w, req, httprouter.Params{{Key: "name", Value: "test-eval"}})
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockClient.AssertExpectations(t)
+
+	var entry map[string]any
+	err := json.Unmarshal(auditBuf.Bytes(), &entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "system:serviceaccount:ci:runner", entry["user"])
+	assert.Equal(t, []any{"system:serviceaccounts"}, entry["groups"])
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type LMEvalJobLogsEnvelope Envelope[*models.LMEvalJobLogs, None]
+
+// LMEvalLogsHandler handles GET /api/v1/evaluations/:name/logs. The optional tailLines
+// parameter limits the response to the last lines of the evaluation pod output.
+func (app *App) LMEvalLogsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var tailLines int64
+	if value := r.URL.Query().Get("tailLines"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			app.badRequestResponse(w, r, fmt.Errorf("tailLines must be a non-negative integer"))
+			return
+		}
+		tailLines = parsed
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	lmEvalJob, err := client.GetLMEvalJob(ctx, identity, namespace, name)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	// The operator names the pod after the job until it reports the pod in the status
+	podName := name
+	if lmEvalJob.Status != nil && lmEvalJob.Status.PodName != "" {
+		podName = lmEvalJob.Status.PodName
+	}
+
+	logs, err := client.GetPodLogs(ctx, namespace, podName, tailLines)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	response := LMEvalJobLogsEnvelope{
+		Data: &models.LMEvalJobLogs{
+			Evaluation: name,
+			Namespace:  namespace,
+			Pod:        podName,
+			Logs:       logs,
+		},
+	}
+	if err := app.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestLMEvalLogsHandler(t *testing.T) {
+	mockFactory := &MockKubernetesClientFactory{}
+	mockClient := &MockKubernetesClient{}
+	app := &App{
+		config:                  config.EnvConfig{},
+		logger:                  slog.Default(),
+		kubernetesClientFactory: mockFactory,
+	}
+
+	mockFactory.On("GetClient", mock.Anything).Return(mockClient, nil)
+	mockClient.On("GetLMEvalJob", mock.Anything, mock.Anything, "project-1", "llama").
+		Return(&models.LMEvalJobKind{
+			Metadata: models.LMEvalJobMetadata{Name: "llama", Namespace: "project-1"},
+			Status:   &models.LMEvalJobStatus{State: models.LMEvalJobStateRunning, PodName: "llama-pod"},
+		}, nil)
+	mockClient.On("GetPodLogs", mock.Anything, "project-1", "llama-pod", int64(20)).Return("Running loglikelihood requests\n", nil)
+
+	w := httptest.NewRecorder()
+	app.LMEvalLogsHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama/logs?namespace=project-1&tailLines=20", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LMEvalJobLogsEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "llama-pod", response.Data.Pod)
+	assert.Equal(t, "Running loglikelihood requests\n", response.Data.Logs)
+	mockClient.AssertExpectations(t)
+
+	w = httptest.NewRecorder()
+	app.LMEvalLogsHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama/logs?namespace=project-1&tailLines=-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLMEvalLogsHandlerDefaultsToJobPod(t *testing.T) {
+	app, _ := newTestApp()
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "llama",
+		K8sName:        "llama",
+		ModelType:      "llama",
+		Model:          models.LMEvalModelConfig{Name: "llama"},
+		Tasks:          []string{"arc_easy"},
+	}
+	w := httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	app.LMEvalLogsHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama/logs?namespace=project-1&tailLines=1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LMEvalJobLogsEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "llama", response.Data.Pod)
+	assert.Equal(t, "INFO Evaluation finished, writing results\n", response.Data.Logs)
+}
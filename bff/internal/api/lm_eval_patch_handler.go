@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PatchLMEvalHandler handles PATCH /api/v1/evaluations/:name. Only the metadata the
+// dashboard shows can change, the spec of a submitted evaluation is immutable.
+func (app *App) PatchLMEvalHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	name := ps.ByName("name")
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var patchRequest models.LMEvalJobPatchRequest
+	if err := app.ReadJSON(w, r, &patchRequest); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("error decoding JSON: %w", err))
+		return
+	}
+
+	patch, err := lmEvalJobMetadataPatch(&patchRequest)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	// The API server rejects the patch with a conflict when the resourceVersion it carries is stale
+	patched, err := client.PatchLMEvalJob(ctx, identity, namespace, name, patch)
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionUpdateEvaluation, namespace, "lmevaljobs/"+name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, LMEvalJobEnvelope{Data: patched}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// lmEvalJobMetadataPatch turns a patch request into a JSON merge patch of the
+// LMEvalJob metadata, null values removing annotations and labels
+func lmEvalJobMetadataPatch(req *models.LMEvalJobPatchRequest) ([]byte, error) {
+	// Without it, concurrent edits of the labels and tags would silently overwrite each other
+	if req.ResourceVersion == "" {
+		return nil, errors.New("resourceVersion is required, send the one of the evaluation read")
+	}
+
+	annotations := map[string]any{}
+	if req.DisplayName != nil {
+		annotations[constants.DisplayNameAnnotation] = nullIfEmpty(*req.DisplayName)
+	}
+	if req.Description != nil {
+		annotations[constants.DescriptionAnnotation] = nullIfEmpty(*req.Description)
+	}
+	if req.Tags != nil {
+		tags, err := normalizeTags(req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		annotations[constants.TagsAnnotation] = nullIfEmpty(strings.Join(tags, ","))
+	}
+
+	labels := map[string]any{}
+	for key, value := range req.Labels {
+		if strings.HasPrefix(key, constants.ManagedLabelPrefix) {
+			return nil, fmt.Errorf("label %q is managed by the dashboard and cannot be changed", key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if value == nil {
+			labels[key] = nil
+			continue
+		}
+		if errs := validation.IsValidLabelValue(*value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid value for label %q: %s", key, strings.Join(errs, "; "))
+		}
+		labels[key] = *value
+	}
+
+	if len(annotations) == 0 && len(labels) == 0 {
+		return nil, errors.New("nothing to update: set displayName, description, labels or tags")
+	}
+
+	metadata := map[string]any{"resourceVersion": req.ResourceVersion}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	return json.Marshal(map[string]any{"metadata": metadata})
+}
+
+// normalizeTags trims the tags and drops duplicates, keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || strings.Contains(tag, ",") {
+			return nil, fmt.Errorf("invalid tag %q: tags must be non-empty and cannot contain commas", tag)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+func nullIfEmpty(value string) any {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestPatchLMEvalHandler(t *testing.T) {
+	app, _ := newTestApp()
+	params := httprouter.Params{{Key: "name", Value: "llama-arc"}}
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "Llama ARC",
+		K8sName:        "llama-arc",
+		ModelType:      "llama",
+		Model:          models.LMEvalModelConfig{Name: "llama-2-7b"},
+		Tasks:          []string{"arc_easy"},
+		Labels:         map[string]string{"team": "nlp", "stage": "dev"},
+		Experiment:     "q3-release",
+	}
+	w := httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		app.PatchLMEvalHandler(w, newTestRequest("PATCH", "/api/v1/evaluations/llama-arc?namespace=project-1", json.RawMessage(body), "test-user"), params)
+		return w
+	}
+
+	w = patch(`{"resourceVersion":"` + created.Data.Metadata.ResourceVersion + `","displayName":"Llama ARC v2","description":"Baseline",` +
+		`"labels":{"stage":null,"owner":"alice"},"tags":["release"," baseline","release"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var patched LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	metadata := patched.Data.Metadata
+	assert.Equal(t, "Llama ARC v2", metadata.Annotations[constants.DisplayNameAnnotation])
+	assert.Equal(t, "Baseline", metadata.Annotations[constants.DescriptionAnnotation])
+	assert.Equal(t, "release,baseline", metadata.Annotations[constants.TagsAnnotation])
+	assert.Equal(t, "alice", metadata.Labels["owner"])
+	assert.Equal(t, "nlp", metadata.Labels["team"])
+	assert.Equal(t, "q3-release", metadata.Labels[constants.ExperimentLabel])
+	assert.NotContains(t, metadata.Labels, "stage")
+	assert.NotEqual(t, created.Data.Metadata.ResourceVersion, metadata.ResourceVersion)
+
+	// The evaluation changed since the version sent
+	w = patch(`{"resourceVersion":"` + created.Data.Metadata.ResourceVersion + `","displayName":"Stale"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Patches must say which version they are based on
+	w = patch(`{"displayName":"Unversioned"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "resourceVersion is required")
+
+	w = patch(`{"resourceVersion":"` + metadata.ResourceVersion + `","description":"","tags":[]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var cleared LMEvalJobEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &cleared))
+	assert.NotContains(t, cleared.Data.Metadata.Annotations, constants.DescriptionAnnotation)
+	assert.NotContains(t, cleared.Data.Metadata.Annotations, constants.TagsAnnotation)
+	assert.Equal(t, "Llama ARC v2", cleared.Data.Metadata.Annotations[constants.DisplayNameAnnotation])
+}
+
+func TestPatchLMEvalHandlerValidatesTheRequest(t *testing.T) {
+	app, _ := newTestApp()
+
+	for body, expected := range map[string]int{
+		`{"resourceVersion":"1"}`:   http.StatusBadRequest,
+		`{"displayName":"Missing"}`: http.StatusBadRequest,
+		`{"resourceVersion":"1","labels":{"trustyai.opendatahub.io/experiment":"other"}}`: http.StatusBadRequest,
+		`{"resourceVersion":"1","labels":{"team":"not a valid value"}}`:                   http.StatusBadRequest,
+		`{"resourceVersion":"1","tags":["a,b"]}`:                                          http.StatusBadRequest,
+		`{"resourceVersion":"1","displayName":"Missing"}`:                                 http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		app.PatchLMEvalHandler(w, newTestRequest("PATCH", "/api/v1/evaluations/missing?namespace=project-1", json.RawMessage(body), "test-user"),
+			httprouter.Params{{Key: "name", Value: "missing"}})
+		assert.Equal(t, expected, w.Code, body)
+	}
+}
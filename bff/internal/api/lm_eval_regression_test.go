@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestGetLMEvalHandlerReportsRegression(t *testing.T) {
+	app, client := newTestApp()
+
+	for _, name := range []string{"llama-v1", "llama-v2"} {
+		createRequest := models.LMEvalCreateRequest{
+			EvaluationName: name,
+			K8sName:        name,
+			ModelType:      "llama",
+			Model:          models.LMEvalModelConfig{Name: "llama"},
+			Tasks:          []string{"arc_easy"},
+		}
+		if name == "llama-v2" {
+			createRequest.Regression = &models.RegressionPolicy{Baseline: models.RegressionBaselinePrevious}
+		}
+		w := httptest.NewRecorder()
+		app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err := client.PatchLMEvalJob(context.Background(), identity, "project-1", "llama-v1",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8,\"acc_stderr,none\":0.01}}}"}}`))
+	assert.NoError(t, err)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", "llama-v2",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.7,\"acc_stderr,none\":0.01}}}"}}`))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	app.GetLMEvalHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama-v2?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama-v2"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LMEvalJobDetailEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.RegressionVerdictFail, response.Metadata.Regression.Verdict)
+	assert.Equal(t, "llama-v1", response.Metadata.Regression.Baseline)
+
+	// Evaluations without a policy have no insights
+	w = httptest.NewRecorder()
+	app.GetLMEvalHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama-v1?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama-v1"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"regression"`)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trends"
+)
+
+type ModelTrendsEnvelope Envelope[*models.ModelTrends, None]
+
+// ModelTrendsHandler handles GET /api/v1/models/:id/trends
+func (app *App) ModelTrendsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+	model := ps.ByName("id")
+	opts := trends.Options{
+		Task:   query.Get("task"),
+		Metric: query.Get("metric"),
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	// The history keeps the evaluations that were deleted since, the live jobs win
+	// over their recorded state which may lag behind
+	live := map[string]bool{}
+	var records []models.EvaluationHistoryRecord
+	for i := range lmEvalJobs.Items {
+		record := history.NewRecord(&lmEvalJobs.Items[i])
+		live[record.UID] = true
+		records = append(records, record)
+	}
+	if app.history != nil {
+		recorded, err := app.history.Query(history.Query{Namespace: namespace})
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		for _, record := range recorded {
+			if !live[record.UID] {
+				records = append(records, record)
+			}
+		}
+	}
+
+	result := trends.Build(model, namespace, records, opts)
+	for i := range result.Series {
+		for j := range result.Series[i].Points {
+			app.linkTrendPoint(&result.Series[i].Points[j], namespace)
+		}
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, ModelTrendsEnvelope{Data: result}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// linkTrendPoint points to the evaluation of a point, or to its archived snapshot once
+// the evaluation is deleted. Deleted evaluations have no link without an archive.
+func (app *App) linkTrendPoint(point *models.TrendPoint, namespace string) {
+	params := "?namespace=" + url.QueryEscape(namespace)
+	switch {
+	case !point.Deleted:
+		point.Link = EvaluationsPath + "/" + url.PathEscape(point.Evaluation) + params
+	case app.archive != nil:
+		id := archive.ID(&models.LMEvalJobKind{Metadata: models.LMEvalJobMetadata{Name: point.Evaluation, UID: point.UID}})
+		point.Link = ArchivedEvaluationsPath + "/" + url.PathEscape(id) + params
+	}
+}
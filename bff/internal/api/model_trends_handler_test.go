@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestModelTrendsHandler(t *testing.T) {
+	app, client := newTestApp()
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err = client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: "llama-eval"},
+		Spec: models.LMEvalJobSpec{
+			Model: "local-completions",
+			ModelArgs: []models.LMEvalJobModelArg{
+				{Name: "model", Value: "llama2-7b-chat"},
+				{Name: "base_url", Value: "http://llama.project-1.svc.cluster.local:8080/v1/completions"},
+			},
+			TaskList: models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+		},
+	})
+	assert.NoError(t, err)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", "llama-eval",
+		[]byte(`{"status":{"state":"Complete","completeTime":"2026-03-01T10:00:00Z","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8}}}"}}`))
+	assert.NoError(t, err)
+
+	// An evaluation of the previous deployment, deleted since
+	completed := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+	_, err = store.Record(models.EvaluationHistoryRecord{
+		UID:               "0a1b2c3d-0000-4000-8000-000000000000",
+		Name:              "llama-eval-old",
+		Namespace:         "project-1",
+		Model:             "llama2-7b-chat",
+		ModelEndpoint:     "llama-project-1",
+		Tasks:             []string{"arc_easy"},
+		State:             models.LMEvalJobStateComplete,
+		CreationTimestamp: completed.Add(-time.Hour),
+		CompleteTime:      &completed,
+		Metrics:           []models.LMEvalMetric{{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.75}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, store.MarkDeleted("0a1b2c3d-0000-4000-8000-000000000000", completed.AddDate(0, 0, 7)))
+	app.history = store
+	app.archive, err = archive.NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+
+	query := func(model, params string) (int, *models.ModelTrends) {
+		w := httptest.NewRecorder()
+		app.ModelTrendsHandler(w, newTestRequest("GET", "/api/v1/models/"+model+"/trends"+params, nil, "test-user"),
+			httprouter.Params{{Key: "id", Value: model}})
+		var response ModelTrendsEnvelope
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	status, trends := query("llama-project-1", "?namespace=project-1&task=arc_easy&metric=acc")
+	assert.Equal(t, http.StatusOK, status)
+	if !assert.Len(t, trends.Series, 1) || !assert.Len(t, trends.Series[0].Points, 2) {
+		return
+	}
+	old, latest := trends.Series[0].Points[0], trends.Series[0].Points[1]
+	assert.True(t, old.Deleted)
+	assert.Equal(t, "/api/v1/archive/evaluations/llama-eval-old-0a1b2c3d?namespace=project-1", old.Link)
+	assert.False(t, latest.Deleted)
+	assert.Equal(t, "/api/v1/evaluations/llama-eval?namespace=project-1", latest.Link)
+	assert.Equal(t, "llama-project-1", latest.ModelEndpoint)
+	assert.InDelta(t, 0.05, *latest.Change, 1e-9)
+
+	// Without the history only the live evaluations are found
+	app.history = nil
+	status, trends = query("llama2-7b-chat", "?namespace=project-1")
+	assert.Equal(t, http.StatusOK, status)
+	if assert.Len(t, trends.Series, 1) {
+		assert.Len(t, trends.Series[0].Points, 1)
+	}
+
+	status, _ = query("llama2-7b-chat", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,403 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type NotificationSubscriptionEnvelope Envelope[*models.NotificationSubscription, None]
+type NotificationSubscriptionListEnvelope Envelope[[]models.NotificationSubscription, None]
+type NotificationDeliveryListEnvelope Envelope[[]models.NotificationDelivery, None]
+
+const (
+	AuditActionCreateSubscription = "subscription.create"
+	AuditActionUpdateSubscription = "subscription.update"
+	AuditActionDeleteSubscription = "subscription.delete"
+)
+
+// ListNotificationSubscriptionsHandler handles GET /api/v1/notifications/subscriptions
+func (app *App) ListNotificationSubscriptionsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	configMaps, err := client.ListConfigMaps(ctx, namespace, notifications.LabelSelector())
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	subscriptions := []models.NotificationSubscription{}
+	for i := range configMaps {
+		subscription, err := notifications.FromConfigMap(&configMaps[i])
+		if err != nil {
+			app.logger.Warn("skipping unreadable notification subscription", "configmap", configMaps[i].Name, "error", err)
+			continue
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Name < subscriptions[j].Name
+	})
+
+	response := NotificationSubscriptionListEnvelope{
+		Data: subscriptions,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetNotificationSubscriptionHandler handles GET /api/v1/notifications/subscriptions/:name
+func (app *App) GetNotificationSubscriptionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	subscription, ok := app.getNotificationSubscription(w, r, client, namespace, ps.ByName("name"))
+	if !ok {
+		return
+	}
+
+	response := NotificationSubscriptionEnvelope{
+		Data: subscription,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// CreateNotificationSubscriptionHandler handles POST /api/v1/notifications/subscriptions
+func (app *App) CreateNotificationSubscriptionHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var subscription models.NotificationSubscription
+	err := app.ReadJSON(w, r, &subscription)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	if err := notifications.Validate(&subscription, app.webhookEgress); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	user := app.resolveUser(client, identity)
+	subscription.CreatedBy = user
+	subscription.ResourceVersion = ""
+	signingKey := ""
+	if subscription.Secret != nil {
+		signingKey = *subscription.Secret
+	}
+	subscription.Signed = signingKey != ""
+
+	configMap, err := notifications.ToConfigMap(&subscription)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	created, err := client.CreateConfigMap(ctx, namespace, configMap)
+	if err == nil && signingKey != "" {
+		if _, err = client.CreateSecret(ctx, namespace, notifications.ToSecret(subscription.Name, signingKey)); err != nil {
+			// Do not leave an unsigned subscription behind when the key could not be stored
+			if deleteErr := client.DeleteConfigMap(ctx, namespace, configMap.Name); deleteErr != nil {
+				app.logger.Error("failed to roll back notification subscription", "name", subscription.Name, "error", deleteErr)
+			}
+		}
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionCreateSubscription, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	createdSubscription, err := notifications.FromConfigMap(created)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := NotificationSubscriptionEnvelope{
+		Data: createdSubscription,
+	}
+
+	err = app.WriteJSON(w, http.StatusCreated, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// UpdateNotificationSubscriptionHandler handles PUT /api/v1/notifications/subscriptions/:name
+func (app *App) UpdateNotificationSubscriptionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	var subscription models.NotificationSubscription
+	err := app.ReadJSON(w, r, &subscription)
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if subscription.Name != "" && subscription.Name != name {
+		app.badRequestResponse(w, r, fmt.Errorf("subscription name %q does not match path %q", subscription.Name, name))
+		return
+	}
+	subscription.Name = name
+
+	if err := notifications.Validate(&subscription, app.webhookEgress); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	existing, ok := app.getNotificationSubscription(w, r, client, namespace, name)
+	if !ok {
+		return
+	}
+
+	subscription.CreatedBy = existing.CreatedBy
+	if subscription.ResourceVersion == "" {
+		subscription.ResourceVersion = existing.ResourceVersion
+	}
+	subscription.Signed = existing.Signed
+	if subscription.Secret != nil {
+		subscription.Signed = *subscription.Secret != ""
+	}
+
+	configMap, err := notifications.ToConfigMap(&subscription)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	updated, err := client.UpdateConfigMap(ctx, namespace, configMap)
+	if err == nil && subscription.Secret != nil {
+		err = updateSigningKey(ctx, client, namespace, name, *subscription.Secret)
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionUpdateSubscription, namespace, "configmaps/"+configMap.Name, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	updatedSubscription, err := notifications.FromConfigMap(updated)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	response := NotificationSubscriptionEnvelope{
+		Data: updatedSubscription,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DeleteNotificationSubscriptionHandler handles DELETE /api/v1/notifications/subscriptions/:name.
+// The signing key and delivery history are deleted with it.
+func (app *App) DeleteNotificationSubscriptionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if _, ok := app.getNotificationSubscription(w, r, client, namespace, name); !ok {
+		return
+	}
+
+	configMapName := notifications.ConfigMapName(name)
+	err = client.DeleteConfigMap(ctx, namespace, configMapName)
+	if err == nil {
+		err = ignoreNotFound(client.DeleteSecret(ctx, namespace, configMapName))
+	}
+	if err == nil {
+		err = ignoreNotFound(client.DeleteConfigMap(ctx, namespace, notifications.HistoryConfigMapName(name)))
+	}
+	app.recordAudit(r, app.auditUser(client, identity), AuditActionDeleteSubscription, namespace, "configmaps/"+configMapName, err)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListNotificationDeliveriesHandler handles GET /api/v1/notifications/subscriptions/:name/deliveries
+func (app *App) ListNotificationDeliveriesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	if _, ok := app.getNotificationSubscription(w, r, client, namespace, name); !ok {
+		return
+	}
+
+	deliveries, err := notifications.ListDeliveries(ctx, client, namespace, name)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	response := NotificationDeliveryListEnvelope{
+		Data: deliveries,
+	}
+
+	err = app.WriteJSON(w, http.StatusOK, response, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// getNotificationSubscription loads a subscription, writing a 404 when the ConfigMap is not a subscription
+func (app *App) getNotificationSubscription(w http.ResponseWriter, r *http.Request, client kubernetes.KubernetesClientInterface, namespace, name string) (*models.NotificationSubscription, bool) {
+	configMap, err := client.GetConfigMap(r.Context(), namespace, notifications.ConfigMapName(name))
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return nil, false
+	}
+	if configMap.Labels[constants.NotificationSubscriptionLabel] != "true" {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	subscription, err := notifications.FromConfigMap(configMap)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return nil, false
+	}
+	return subscription, true
+}
+
+// updateSigningKey stores a new signing key, or removes it when the key is empty
+func updateSigningKey(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, name, signingKey string) error {
+	if signingKey == "" {
+		return ignoreNotFound(client.DeleteSecret(ctx, namespace, notifications.ConfigMapName(name)))
+	}
+
+	secret := notifications.ToSecret(name, signingKey)
+	_, err := client.UpdateSecret(ctx, namespace, secret)
+	if apierrors.IsNotFound(err) {
+		_, err = client.CreateSecret(ctx, namespace, secret)
+	}
+	return err
+}
+
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/notifications"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestNotificationSubscriptionLifecycle(t *testing.T) {
+	app, client := newTestApp()
+	ctx := context.Background()
+	params := httprouter.Params{{Key: "name", Value: "ci"}}
+	secret := "s3cr3t"
+
+	subscription := models.NotificationSubscription{
+		Name:   "ci",
+		URL:    "https://hooks.example.com/eval",
+		Events: []string{models.NotificationEventFailed},
+		Secret: &secret,
+	}
+	w := httptest.NewRecorder()
+	app.CreateNotificationSubscriptionHandler(w, newTestRequest("POST", "/api/v1/notifications/subscriptions?namespace=project-1", subscription, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), secret)
+
+	var created NotificationSubscriptionEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, created.Data.Signed)
+	assert.Equal(t, models.NotificationFormatGeneric, created.Data.Format)
+	assert.Equal(t, "test-user", created.Data.CreatedBy)
+
+	key, err := notifications.SigningKey(ctx, client, "project-1", "ci")
+	assert.NoError(t, err)
+	assert.Equal(t, secret, key)
+
+	// Updating without a secret keeps the signing key
+	subscription.Secret = nil
+	subscription.Format = models.NotificationFormatSlack
+	w = httptest.NewRecorder()
+	app.UpdateNotificationSubscriptionHandler(w, newTestRequest("PUT", "/api/v1/notifications/subscriptions/ci?namespace=project-1", subscription, "test-user"), params)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated NotificationSubscriptionEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.True(t, updated.Data.Signed)
+	assert.Equal(t, models.NotificationFormatSlack, updated.Data.Format)
+
+	// An empty secret removes it
+	empty := ""
+	subscription.Secret = &empty
+	w = httptest.NewRecorder()
+	app.UpdateNotificationSubscriptionHandler(w, newTestRequest("PUT", "/api/v1/notifications/subscriptions/ci?namespace=project-1", subscription, "test-user"), params)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.False(t, updated.Data.Signed)
+	key, err = notifications.SigningKey(ctx, client, "project-1", "ci")
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	assert.NoError(t, notifications.RecordDelivery(ctx, client, "project-1", "ci", models.NotificationDelivery{
+		ID: "d1", Subscription: "ci", Event: models.NotificationEventFailed, Evaluation: "llama", Timestamp: time.Now(), Attempts: 1, Success: true,
+	}))
+	w = httptest.NewRecorder()
+	app.ListNotificationDeliveriesHandler(w, newTestRequest("GET", "/api/v1/notifications/subscriptions/ci/deliveries?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var deliveries NotificationDeliveryListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+	assert.Len(t, deliveries.Data, 1)
+	assert.Equal(t, "d1", deliveries.Data[0].ID)
+
+	// The delivery history is not listed as a subscription
+	w = httptest.NewRecorder()
+	app.ListNotificationSubscriptionsHandler(w, newTestRequest("GET", "/api/v1/notifications/subscriptions?namespace=project-1", nil, "test-user"), nil)
+	var list NotificationSubscriptionListEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+
+	w = httptest.NewRecorder()
+	app.DeleteNotificationSubscriptionHandler(w, newTestRequest("DELETE", "/api/v1/notifications/subscriptions/ci?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	_, err = client.GetConfigMap(ctx, "project-1", notifications.HistoryConfigMapName("ci"))
+	assert.True(t, apierrors.IsNotFound(err))
+
+	w = httptest.NewRecorder()
+	app.GetNotificationSubscriptionHandler(w, newTestRequest("GET", "/api/v1/notifications/subscriptions/ci?namespace=project-1", nil, "test-user"), params)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateNotificationSubscriptionValidation(t *testing.T) {
+	app, _ := newTestApp()
+
+	for _, subscription := range []models.NotificationSubscription{
+		{Name: "ci", URL: "not a url"},
+		{Name: "ci", URL: "https://hooks.example.com", Format: "teams"},
+	} {
+		w := httptest.NewRecorder()
+		app.CreateNotificationSubscriptionHandler(w, newTestRequest("POST", "/api/v1/notifications/subscriptions?namespace=project-1", subscription, "test-user"), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
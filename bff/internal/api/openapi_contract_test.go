@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/archive"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/history"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/openapi"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/trustyai"
+)
+
+var routeParamPattern = regexp.MustCompile(`:(\w+)`)
+
+func loadOpenAPISpec(t *testing.T) *openapi.Document {
+	doc, err := openapi.Load(bff.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("failed to load openapi.yaml: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPISpecDocumentsEveryRoute(t *testing.T) {
+	doc := loadOpenAPISpec(t)
+	app := &App{logger: slog.Default()}
+
+	routes := []string{http.MethodGet + " " + HealthCheckPath}
+	for _, rt := range app.apiRoutes() {
+		routes = append(routes, rt.method+" "+routeParamPattern.ReplaceAllString(rt.path, "{$1}"))
+	}
+	for _, rt := range app.muxRoutes() {
+		routes = append(routes, rt.method+" "+rt.path)
+	}
+	var documented []string
+	for _, op := range doc.Operations() {
+		documented = append(documented, op.Method+" "+op.Path)
+	}
+
+	assert.ElementsMatch(t, routes, documented, "the routes of App.Routes and the operations of openapi.yaml differ")
+}
+
+// TestOpenAPIContract sends the examples of every operation to the router backed
+// by the mock Kubernetes client and validates the responses against the spec.
+// Creations run first and deletions last so that the examples refer to resources
+// that exist.
+func TestOpenAPIContract(t *testing.T) {
+	doc := loadOpenAPISpec(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	app := &App{
+		config:                  config.EnvConfig{TemplatesNamespace: "trustyai-dashboard"},
+		logger:                  logger,
+		kubernetesClientFactory: kubernetes.NewMockClientFactory(logger),
+		trustyAIClientFactory:   trustyai.NewMockClientFactory(),
+		auditLogger:             audit.NewLogger(io.Discard),
+		archive:                 newContractArchive(t),
+		history:                 newContractHistory(t),
+		signer:                  signing.New([]byte("0123456789abcdef0123456789abcdef")),
+	}
+	server := httptest.NewServer(app.Routes())
+	defer server.Close()
+
+	phase := map[string]int{http.MethodPost: 0, http.MethodPut: 1, http.MethodPatch: 1, http.MethodGet: 2, http.MethodDelete: 3}
+	ops := doc.Operations()
+	sort.SliceStable(ops, func(i, j int) bool { return phase[ops[i].Method] < phase[ops[j].Method] })
+
+	for _, op := range ops {
+		t.Run(op.OperationID, func(t *testing.T) {
+			req, err := newContractRequest(doc, op, server.URL)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if op.Method == http.MethodPatch {
+				if err := withCurrentResourceVersion(req); !assert.NoError(t, err) {
+					return
+				}
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			status := fmt.Sprint(resp.StatusCode)
+			response, documented := op.Responses[status]
+			if !assert.True(t, documented && strings.HasPrefix(status, "2"),
+				"%s %s returned the undocumented or unexpected status %s: %s", op.Method, req.URL, status, body) {
+				return
+			}
+			assert.NoError(t, validateContractResponse(doc, response, resp.Header.Get("Content-Type"), body),
+				"%s %s returned a response that does not match openapi.yaml", op.Method, req.URL)
+		})
+	}
+}
+
+// newContractArchive returns an archive holding the evaluation of the
+// ArchivedEvaluationId example
+func newContractArchive(t *testing.T) archive.Store {
+	store, err := archive.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create the archive: %v", err)
+	}
+	job := newLMEvalJob("project-1", models.LMEvalCreateRequest{
+		EvaluationName: "Llama evaluation",
+		K8sName:        "llama-eval",
+		ModelType:      "local-completions",
+		Model:          models.LMEvalModelConfig{Name: "llama2-7b-chat", URL: "http://llama2-7b-service:8080/v1/completions"},
+		Tasks:          []string{"hellaswag"},
+	}, "mock-user")
+	job.Metadata.UID = "5f1c2a9b-3d4e-4f50-8a6b-7c8d9e0f1a2b"
+	job.Metadata.CreationTimestamp = time.Now().Add(-time.Hour)
+	job.Status = &models.LMEvalJobStatus{
+		State:   models.LMEvalJobStateComplete,
+		Results: `{"results":{"hellaswag":{"acc,none":0.85}}}`,
+	}
+	if err := store.Put(context.Background(), archive.Snapshot(job, models.ArchiveTriggerCompleted, time.Now())); err != nil {
+		t.Fatalf("failed to seed the archive: %v", err)
+	}
+	return store
+}
+
+// newContractHistory returns a history holding an evaluation matching the examples of
+// the history query
+func newContractHistory(t *testing.T) *history.Store {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("failed to open the history: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	completeTime := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	_, err = store.Record(models.EvaluationHistoryRecord{
+		UID:               "5f1c2a9b-3d4e-4f50-8a6b-7c8d9e0f1a2b",
+		Name:              "llama-eval",
+		Namespace:         "project-1",
+		Model:             "llama2-7b-chat",
+		ModelType:         "local-completions",
+		Tasks:             []string{"hellaswag"},
+		State:             models.LMEvalJobStateComplete,
+		CreationTimestamp: completeTime.Add(-time.Hour),
+		CompleteTime:      &completeTime,
+		DeletedAt:         &completeTime,
+		Metrics:           []models.LMEvalMetric{{Task: "hellaswag", Metric: "acc", Filter: "none", Value: 0.85}},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed the history: %v", err)
+	}
+	return store
+}
+
+func newContractRequest(doc *openapi.Document, op *openapi.Operation, serverURL string) (*http.Request, error) {
+	target := op.Path
+	query := url.Values{}
+	for _, param := range op.Parameters {
+		if param.Example == nil {
+			if param.Required {
+				return nil, fmt.Errorf("required parameter %s has no example", param.Name)
+			}
+			continue
+		}
+		switch param.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", url.PathEscape(fmt.Sprint(param.Example)))
+		case "query":
+			query.Set(param.Name, fmt.Sprint(param.Example))
+		}
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	if op.RequestBody != nil {
+		form := op.RequestBody.Content[openapi.ContentTypeMultipart] != nil
+		media := op.RequestBody.Content[openapi.ContentTypeJSON]
+		if form {
+			media = op.RequestBody.Content[openapi.ContentTypeMultipart]
+		}
+		if media == nil || media.Example == nil {
+			return nil, fmt.Errorf("request body has no JSON or multipart example")
+		}
+		if err := doc.Validate(media.Schema, media.Example, openapi.DirectionRequest); err != nil {
+			return nil, fmt.Errorf("request body example does not match its schema: %w", err)
+		}
+		if form {
+			var err error
+			if body, contentType, err = newContractForm(media.Example); err != nil {
+				return nil, err
+			}
+		} else {
+			data, err := json.Marshal(media.Example)
+			if err != nil {
+				return nil, err
+			}
+			body, contentType = bytes.NewReader(data), openapi.ContentTypeJSON
+		}
+	}
+
+	req, err := http.NewRequest(op.Method, serverURL+target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(constants.KubeflowUserIDHeader, "user@example.com")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// withCurrentResourceVersion bases a patch on the current version of the resource, read
+// from the same URL, since the one of the example cannot match the resources created by
+// the earlier operations
+func withCurrentResourceVersion(req *http.Request) error {
+	var patch map[string]any
+	if err := json.NewDecoder(req.Body).Decode(&patch); err != nil {
+		return err
+	}
+
+	current, err := http.NewRequest(http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		return err
+	}
+	current.Header = req.Header.Clone()
+	resp, err := http.DefaultClient.Do(current)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var envelope struct {
+		Data struct {
+			Metadata struct {
+				ResourceVersion string `json:"resourceVersion"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to read the current version of %s: %w", req.URL, err)
+	}
+
+	patch["resourceVersion"] = envelope.Data.Metadata.ResourceVersion
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	return nil
+}
+
+// newContractForm encodes the example of a multipart form, its file field last
+func newContractForm(example any) (io.Reader, string, error) {
+	fields, ok := example.(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("multipart example is not an object")
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if name != "file" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	for _, name := range names {
+		if err := form.WriteField(name, fmt.Sprint(fields[name])); err != nil {
+			return nil, "", err
+		}
+	}
+	if file, ok := fields["file"]; ok {
+		part, err := form.CreateFormFile("file", "example")
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.WriteString(part, fmt.Sprint(file)); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, form.FormDataContentType(), nil
+}
+
+func validateContractResponse(doc *openapi.Document, response *openapi.Response, contentType string, body []byte) error {
+	if len(response.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("expected an empty body, got %s", body)
+		}
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid content type %q", contentType)
+	}
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("undocumented content type %q", mediaType)
+	}
+	if mediaType != openapi.ContentTypeJSON {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return doc.Validate(media.Schema, value, openapi.DirectionResponse)
+}
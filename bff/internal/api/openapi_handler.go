@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff"
+	"sigs.k8s.io/yaml"
+)
+
+// openAPISpecJSON converts the embedded openapi.yaml once, the document cannot
+// change while the server runs
+var openAPISpecJSON = sync.OnceValues(func() ([]byte, error) {
+	return yaml.YAMLToJSON(bff.OpenAPISpec)
+})
+
+// apiDocsAssets are the Swagger UI files the /api/docs page loads, embedded at build time
+// like the spec so that the page works air-gapped and runs no third party script
+var apiDocsAssets = map[string]bool{
+	"swagger-ui-bundle.js": true,
+	"swagger-ui.css":       true,
+}
+
+var apiDocsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>TrustyAI Dashboard API</title>
+  <link rel="stylesheet" href="{{.AssetsPath}}/swagger-ui.css">
+  <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+  <div id="swagger-ui" data-spec-url="{{.SpecURL}}"></div>
+  <script src="{{.AssetsPath}}/swagger-ui-bundle.js"></script>
+  <script>
+    const root = document.getElementById("swagger-ui");
+    SwaggerUIBundle({ url: root.dataset.specUrl, domNode: root, deepLinking: true, supportedSubmitMethods: [] });
+  </script>
+</body>
+</html>
+`))
+
+// OpenAPIHandler serves the OpenAPI description of the API embedded at build time
+func (app *App) OpenAPIHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	spec, err := openAPISpecJSON()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, json.RawMessage(spec), nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// APIDocsHandler serves a Swagger UI page rendering the spec served by OpenAPIHandler.
+// The page itself holds no data, fetching the spec goes through the identity
+// middleware like every other /api/v1 request.
+func (app *App) APIDocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := apiDocsTemplate.Execute(w, struct{ SpecURL, AssetsPath string }{OpenAPIPath, APIDocsPath})
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// APIDocsAssetHandler serves the Swagger UI script and stylesheet of the /api/docs page
+func (app *App) APIDocsAssetHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("file")
+	if !apiDocsAssets[name] {
+		app.notFoundResponse(w, r)
+		return
+	}
+	http.ServeFileFS(w, r, swaggerFiles.FS, name)
+}
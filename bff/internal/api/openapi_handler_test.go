@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+)
+
+func newOpenAPITestRouter() http.Handler {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	app := &App{
+		logger:                  logger,
+		kubernetesClientFactory: kubernetes.NewMockClientFactory(logger),
+	}
+	return app.Routes()
+}
+
+func TestOpenAPIHandlerServesTheEmbeddedSpec(t *testing.T) {
+	router := newOpenAPITestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, OpenAPIPath, nil)
+	req.Header.Set(constants.KubeflowUserIDHeader, "user@example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var spec struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.1.0", spec.OpenAPI)
+	assert.Contains(t, spec.Paths, "/evaluations/{name}")
+	assert.Contains(t, spec.Paths["/openapi.json"], "get")
+}
+
+func TestOpenAPIHandlerRequiresAnIdentity(t *testing.T) {
+	w := httptest.NewRecorder()
+	newOpenAPITestRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAPIDocsHandlerRendersSwaggerUI(t *testing.T) {
+	router := newOpenAPITestRouter()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, APIDocsPath, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<script src="/api/docs/swagger-ui-bundle.js"></script>`)
+	assert.Contains(t, w.Body.String(), `data-spec-url="/api/v1/openapi.json"`)
+	assert.NotContains(t, w.Body.String(), "https://")
+
+	// The page's assets are served from the binary
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, APIDocsPath+"/swagger-ui-bundle.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+	assert.Contains(t, w.Body.String(), "SwaggerUIBundle")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, APIDocsPath+"/index.html", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/gate"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+type QualityGateEnvelope Envelope[*models.QualityGateResult, None]
+
+// QualityGateHandler handles GET /api/v1/evaluations/:name/gate. CI pipelines poll it and
+// block promotion unless the verdict is "pass".
+func (app *App) QualityGateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	lmEvalJob, err := client.GetLMEvalJob(ctx, identity, namespace, name)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	thresholds, err := gate.ThresholdsFor(lmEvalJob)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	if thresholds == nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	response := QualityGateEnvelope{
+		Data: gate.Evaluate(lmEvalJob, thresholds),
+	}
+	if err := app.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestQualityGateFromTemplate(t *testing.T) {
+	app, client := newTestApp()
+
+	template := models.EvaluationTemplate{
+		Name:       "release-gate",
+		Tasks:      []string{"arc_easy", "hellaswag"},
+		Thresholds: []string{"arc_easy.acc >= 0.7", "hellaswag.acc_norm > 0.5"},
+	}
+	w := httptest.NewRecorder()
+	app.CreateEvaluationTemplateHandler(w, newTestRequest("POST", "/api/v1/templates?namespace=project-1", template, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "llama",
+		K8sName:        "llama",
+		ModelType:      "llama",
+		Model:          models.LMEvalModelConfig{Name: "llama"},
+		TemplateRef:    &models.EvaluationTemplateRef{Name: "release-gate"},
+	}
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	getGate := func() QualityGateEnvelope {
+		w := httptest.NewRecorder()
+		app.QualityGateHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama/gate?namespace=project-1", nil, "test-user"),
+			httprouter.Params{{Key: "name", Value: "llama"}})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response QualityGateEnvelope
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	assert.Equal(t, models.QualityGateVerdictPending, getGate().Data.Verdict)
+
+	_, err := client.PatchLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "llama",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.74},\"hellaswag\":{\"acc_norm,none\":0.48}}}"}}`))
+	assert.NoError(t, err)
+
+	gateResult := getGate().Data
+	assert.Equal(t, models.QualityGateVerdictFail, gateResult.Verdict)
+	assert.True(t, gateResult.Thresholds[0].Passed)
+	assert.False(t, gateResult.Thresholds[1].Passed)
+
+	// The gate is also part of the evaluation's details
+	w = httptest.NewRecorder()
+	app.GetLMEvalHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama"}})
+	var details LMEvalJobDetailEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	assert.Equal(t, models.QualityGateVerdictFail, details.Metadata.QualityGate.Verdict)
+}
+
+func TestQualityGateValidationAndMissingGate(t *testing.T) {
+	app, _ := newTestApp()
+
+	createRequest := models.LMEvalCreateRequest{
+		EvaluationName: "llama",
+		K8sName:        "llama",
+		ModelType:      "llama",
+		Model:          models.LMEvalModelConfig{Name: "llama"},
+		Tasks:          []string{"arc_easy"},
+		Thresholds:     []string{"arc_easy.acc at least 0.7"},
+	}
+	w := httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	createRequest.Thresholds = nil
+	w = httptest.NewRecorder()
+	app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	app.QualityGateHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama/gate?namespace=project-1", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama"}})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/report"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EvaluationReportHandler handles GET /api/v1/evaluations/:name/report
+func (app *App) EvaluationReportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	format, ok := app.reportFormat(w, r)
+	if !ok {
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	name := ps.ByName("name")
+	lmEvalJob, err := client.GetLMEvalJob(ctx, identity, namespace, name)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	app.writeReport(w, r, format, name+"-report", []models.EvaluationReport{newEvaluationReport(lmEvalJob)})
+}
+
+// EvaluationsReportHandler handles GET /api/v1/reports, a report covering several evaluations
+// selected by name and/or label selector.
+func (app *App) EvaluationsReportHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("namespace parameter is required"))
+		return
+	}
+
+	format, ok := app.reportFormat(w, r)
+	if !ok {
+		return
+	}
+
+	names := map[string]bool{}
+	for _, name := range strings.Split(query.Get("names"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	selector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("invalid labelSelector: %w", err))
+		return
+	}
+	if len(names) == 0 && selector.Empty() {
+		app.badRequestResponse(w, r, fmt.Errorf("names or labelSelector parameter is required"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+		return
+	}
+
+	lmEvalJobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		app.kubernetesErrorResponse(w, r, err)
+		return
+	}
+
+	reports := []models.EvaluationReport{}
+	for i := range lmEvalJobs.Items {
+		job := &lmEvalJobs.Items[i]
+		if len(names) > 0 && !names[job.Metadata.Name] {
+			continue
+		}
+		if !selector.Matches(labels.Set(job.Metadata.Labels)) {
+			continue
+		}
+		reports = append(reports, newEvaluationReport(job))
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	app.writeReport(w, r, format, namespace+"-evaluations-report", reports)
+}
+
+// reportFormat reads the format query parameter, JSON by default
+func (app *App) reportFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = report.FormatJSON
+	}
+	if _, ok := report.ContentType(format); !ok {
+		app.badRequestResponse(w, r, fmt.Errorf("format must be one of %s, %s or %s", report.FormatCSV, report.FormatMarkdown, report.FormatJSON))
+		return "", false
+	}
+	return format, true
+}
+
+// writeReport streams the report as a download. Once streaming started the status
+// can no longer change, so write errors are only logged.
+func (app *App) writeReport(w http.ResponseWriter, r *http.Request, format, filename string, reports []models.EvaluationReport) {
+	contentType, _ := report.ContentType(format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	w.WriteHeader(http.StatusOK)
+
+	if err := report.Write(w, format, reports); err != nil {
+		app.LogError(r, fmt.Errorf("failed to write %s report: %w", format, err))
+	}
+}
+
+// newEvaluationReport flattens an LMEvalJob into a report. Unreadable results are
+// reported as an evaluation without metrics rather than failing the whole export.
+func newEvaluationReport(job *models.LMEvalJobKind) models.EvaluationReport {
+	evaluationReport := models.EvaluationReport{
+		Name:              job.Metadata.Name,
+		Namespace:         job.Metadata.Namespace,
+		DisplayName:       job.Metadata.Annotations[constants.DisplayNameAnnotation],
+		Model:             job.ModelName(),
+		ModelType:         job.Spec.Model,
+		Tasks:             job.Spec.TaskList.TaskNames,
+		NumFewShot:        job.Spec.NumFewShot,
+		Limit:             job.Spec.Limit,
+		BatchSize:         job.Spec.BatchSize,
+		CreatedBy:         job.Metadata.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp: job.Metadata.CreationTimestamp,
+		Metrics:           []models.LMEvalMetric{},
+	}
+
+	if job.Status == nil {
+		return evaluationReport
+	}
+
+	evaluationReport.State = job.Status.State
+	evaluationReport.Reason = job.Status.Reason
+	evaluationReport.StartTime = job.Status.LastScheduleTime
+	evaluationReport.CompleteTime = job.Status.CompleteTime
+	if job.Status.LastScheduleTime != nil && job.Status.CompleteTime != nil {
+		duration := job.Status.CompleteTime.Sub(*job.Status.LastScheduleTime).Seconds()
+		evaluationReport.DurationSeconds = &duration
+	}
+
+	if metrics, err := models.ParseLMEvalResults(job.Status.Results); err == nil && metrics != nil {
+		evaluationReport.Metrics = metrics
+	}
+
+	return evaluationReport
+}
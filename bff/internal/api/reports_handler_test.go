@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestEvaluationReportHandlers(t *testing.T) {
+	app, client := newTestApp()
+
+	for _, name := range []string{"llama-arc", "mistral-arc"} {
+		createRequest := models.LMEvalCreateRequest{
+			EvaluationName: name,
+			K8sName:        name,
+			ModelType:      "llama",
+			Model:          models.LMEvalModelConfig{Name: name},
+			Tasks:          []string{"arc_easy"},
+			Experiment:     "q3-release",
+		}
+		w := httptest.NewRecorder()
+		app.CreateLMEvalHandler(w, newTestRequest("POST", "/api/v1/evaluations?namespace=project-1", createRequest, "test-user"), nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	_, err := client.PatchLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "llama-arc",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.81,\"acc_norm,none\":0.79}}}"}}`))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	app.EvaluationReportHandler(w, newTestRequest("GET", "/api/v1/evaluations/llama-arc/report?namespace=project-1&format=csv", nil, "test-user"),
+		httprouter.Params{{Key: "name", Value: "llama-arc"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="llama-arc-report.csv"`, w.Header().Get("Content-Disposition"))
+	// Header plus one row per metric
+	assert.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), 3)
+
+	w = httptest.NewRecorder()
+	app.EvaluationsReportHandler(w, newTestRequest("GET", "/api/v1/reports?namespace=project-1&labelSelector=trustyai.opendatahub.io/experiment%3Dq3-release&format=md", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "## llama-arc")
+	assert.Contains(t, w.Body.String(), "## mistral-arc")
+
+	w = httptest.NewRecorder()
+	app.EvaluationsReportHandler(w, newTestRequest("GET", "/api/v1/reports?namespace=project-1&names=mistral-arc", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "llama-arc")
+
+	w = httptest.NewRecorder()
+	app.EvaluationsReportHandler(w, newTestRequest("GET", "/api/v1/reports?namespace=project-1&names=llama-arc&format=xlsx", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	app.EvaluationsReportHandler(w, newTestRequest("GET", "/api/v1/reports?namespace=project-1", nil, "test-user"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,148 @@
+// Package archive keeps snapshots of evaluations after their LMEvalJob is deleted, so
+// results stay available once a namespace is cleaned up. Snapshots are written to a
+// pluggable Store: ConfigMaps or Secrets next to the evaluation, local files or an
+// S3-compatible bucket.
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Supported backends
+const (
+	BackendConfigMap = "configmap"
+	BackendSecret    = "secret"
+	BackendFile      = "file"
+	BackendS3        = "s3"
+)
+
+// ErrNotFound is returned by Store.Get for evaluations that were never archived
+var ErrNotFound = errors.New("archived evaluation not found")
+
+// Store persists archived evaluations by namespace. Put replaces an earlier snapshot
+// with the same ID.
+type Store interface {
+	Put(ctx context.Context, evaluation *models.ArchivedEvaluation) error
+	Get(ctx context.Context, namespace, id string) (*models.ArchivedEvaluation, error)
+	List(ctx context.Context, namespace string) ([]models.ArchivedEvaluationSummary, error)
+}
+
+// Config selects and configures the backend of the archive
+type Config struct {
+	Backend string
+
+	// Dir is the directory of the file backend
+	Dir string
+
+	// S3 holds the settings of the S3 backend
+	S3 S3Config
+}
+
+// New creates the store of the configured backend, or returns nil when no backend is
+// configured. The ConfigMap and Secret backends store snapshots with client.
+func New(cfg Config, client kubernetes.KubernetesClientInterface) (Store, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendConfigMap, BackendSecret:
+		return NewKubernetesStore(client, cfg.Backend), nil
+	case BackendFile:
+		return NewFileStore(cfg.Dir)
+	case BackendS3:
+		return NewS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported archive backend %q, expected %s, %s, %s or %s",
+			cfg.Backend, BackendConfigMap, BackendSecret, BackendFile, BackendS3)
+	}
+}
+
+// ID identifies the snapshots of a job. It is a valid Kubernetes name so every backend
+// can use it as a resource name or a path segment.
+func ID(job *models.LMEvalJobKind) string {
+	suffix := strings.ReplaceAll(job.Metadata.UID, "-", "")
+	if suffix == "" {
+		suffix = fmt.Sprintf("%x", job.Metadata.CreationTimestamp.Unix())
+	}
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+	return job.Metadata.Name + "-" + suffix
+}
+
+// Snapshot captures a job, parsing its results into metrics
+func Snapshot(job *models.LMEvalJobKind, trigger string, now time.Time) *models.ArchivedEvaluation {
+	evaluation := &models.ArchivedEvaluation{
+		ArchivedEvaluationSummary: models.ArchivedEvaluationSummary{
+			ID:                ID(job),
+			Name:              job.Metadata.Name,
+			Namespace:         job.Metadata.Namespace,
+			DisplayName:       job.Metadata.Annotations[constants.DisplayNameAnnotation],
+			Model:             job.ModelName(),
+			Tasks:             job.Spec.TaskList.TaskNames,
+			State:             job.DisplayState(),
+			CreationTimestamp: job.Metadata.CreationTimestamp,
+			ArchivedAt:        now.UTC(),
+			Trigger:           trigger,
+		},
+		Job:     *job,
+		Metrics: []models.LMEvalMetric{},
+	}
+	if evaluation.Tasks == nil {
+		evaluation.Tasks = []string{}
+	}
+	if job.Status != nil {
+		evaluation.CompleteTime = job.Status.CompleteTime
+		// An unreadable result is kept as is in the job status
+		if metrics, err := models.ParseLMEvalResults(job.Status.Results); err == nil && metrics != nil {
+			evaluation.Metrics = metrics
+		}
+	}
+	return evaluation
+}
+
+// Archive snapshots a job into store
+func Archive(ctx context.Context, store Store, job *models.LMEvalJobKind, trigger string) error {
+	if err := store.Put(ctx, Snapshot(job, trigger, time.Now())); err != nil {
+		return fmt.Errorf("failed to archive evaluation %s: %w", job.Metadata.Name, err)
+	}
+	return nil
+}
+
+// validateKey rejects namespaces and IDs that are not Kubernetes names, keeping them
+// from escaping the directory or prefix of the archive
+func validateKey(namespace, id string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if errs := validation.IsDNS1123Subdomain(id); len(errs) > 0 {
+		return fmt.Errorf("invalid archived evaluation id %q", id)
+	}
+	return nil
+}
+
+func validateNamespace(namespace string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// sortSummaries orders archived evaluations newest first
+func sortSummaries(summaries []models.ArchivedEvaluationSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].CreationTimestamp.Equal(summaries[j].CreationTimestamp) {
+			return summaries[i].ID < summaries[j].ID
+		}
+		return summaries[i].CreationTimestamp.After(summaries[j].CreationTimestamp)
+	})
+}
@@ -0,0 +1,128 @@
+package archive
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func newTestJob(name, uid string, created time.Time) *models.LMEvalJobKind {
+	completed := created.Add(time.Hour)
+	return &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:              name,
+			Namespace:         "project-1",
+			UID:               uid,
+			CreationTimestamp: created,
+			Annotations:       map[string]string{constants.DisplayNameAnnotation: "Llama " + name},
+		},
+		Spec: models.LMEvalJobSpec{
+			Model:     "local-completions",
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: "llama2-7b-chat"}},
+			TaskList:  models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+		},
+		Status: &models.LMEvalJobStatus{
+			State:        models.LMEvalJobStateComplete,
+			CompleteTime: &completed,
+			Results:      `{"results":{"arc_easy":{"acc,none":0.8}}}`,
+		},
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	job := newTestJob("llama-eval", "5f1c2a9b-3d4e-4f50-8a6b-7c8d9e0f1a2b", created)
+
+	snapshot := Snapshot(job, models.ArchiveTriggerDeleted, created.Add(2*time.Hour))
+	assert.Equal(t, "llama-eval-5f1c2a9b", snapshot.ID)
+	assert.Equal(t, "Llama llama-eval", snapshot.DisplayName)
+	assert.Equal(t, models.LMEvalJobStateComplete, snapshot.State)
+	assert.Equal(t, []string{"arc_easy"}, snapshot.Tasks)
+	assert.Equal(t, job.Status.CompleteTime, snapshot.CompleteTime)
+	assert.Equal(t, models.ArchiveTriggerDeleted, snapshot.Trigger)
+	assert.Equal(t, []models.LMEvalMetric{{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.8}}, snapshot.Metrics)
+
+	// Jobs without a UID, such as those of the mock client, fall back to their creation time
+	job.Metadata.UID = ""
+	assert.Equal(t, "llama-eval-69a42a40", ID(job))
+
+	// Unreadable results are kept in the job status only
+	job.Status.Results = "not json"
+	assert.Empty(t, Snapshot(job, models.ArchiveTriggerCompleted, time.Now()).Metrics)
+}
+
+func TestStores(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	fileStore, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stores := map[string]Store{
+		BackendConfigMap: NewKubernetesStore(client, BackendConfigMap),
+		BackendSecret:    NewKubernetesStore(client, BackendSecret),
+		BackendFile:      fileStore,
+	}
+	for backend, store := range stores {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			created := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+			older := Snapshot(newTestJob("zeta-"+backend, "0a1b2c3d", created), models.ArchiveTriggerCompleted, time.Now())
+			newer := Snapshot(newTestJob("newer-"+backend, "1a2b3c4d", created.Add(time.Hour)), models.ArchiveTriggerCompleted, time.Now())
+			assert.NoError(t, store.Put(ctx, older))
+			assert.NoError(t, store.Put(ctx, newer))
+
+			// A second snapshot of an evaluation replaces the first one
+			newer.Trigger = models.ArchiveTriggerDeleted
+			assert.NoError(t, store.Put(ctx, newer))
+
+			summaries, err := store.List(ctx, "project-1")
+			assert.NoError(t, err)
+			var ids []string
+			for _, summary := range summaries {
+				ids = append(ids, summary.ID)
+			}
+			assert.Equal(t, []string{newer.ID, older.ID}, ids)
+			assert.Equal(t, models.ArchiveTriggerDeleted, summaries[0].Trigger)
+
+			evaluation, err := store.Get(ctx, "project-1", newer.ID)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, newer.Metrics, evaluation.Metrics)
+			assert.Equal(t, newer.Job.Spec, evaluation.Job.Spec)
+			assert.True(t, newer.ArchivedAt.Equal(evaluation.ArchivedAt))
+
+			_, err = store.Get(ctx, "project-1", "unknown-1")
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = store.Get(ctx, "project-1", "../../etc/passwd")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			summaries, err = store.List(ctx, "project-2")
+			assert.NoError(t, err)
+			assert.Empty(t, summaries)
+		})
+	}
+}
+
+func TestNewRequiresTheBackendSettings(t *testing.T) {
+	store, err := New(Config{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	for _, cfg := range []Config{
+		{Backend: "sqlite"},
+		{Backend: BackendFile},
+		{Backend: BackendS3, S3: S3Config{Endpoint: "https://s3.example.com"}},
+		{Backend: BackendS3, S3: S3Config{Endpoint: "https://s3.example.com", Bucket: "evaluations"}},
+	} {
+		_, err := New(cfg, nil)
+		assert.Error(t, err, "backend %q", cfg.Backend)
+	}
+}
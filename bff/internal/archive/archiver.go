@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// DefaultInterval is how often finished evaluations are checked for archiving
+const DefaultInterval = time.Minute
+
+// Archiver snapshots evaluations as they finish, so their results survive deletions
+// made outside of the dashboard. Archived evaluations are annotated with the time of
+// their snapshot and skipped afterwards.
+type Archiver struct {
+	client   kubernetes.KubernetesClientInterface
+	store    Store
+	logger   *slog.Logger
+	interval time.Duration
+	identity *kubernetes.RequestIdentity
+}
+
+func NewArchiver(client kubernetes.KubernetesClientInterface, store Store, logger *slog.Logger, interval time.Duration) *Archiver {
+	return &Archiver{
+		client:   client,
+		store:    store,
+		logger:   logger.With(slog.String("component", "archiver")),
+		interval: interval,
+		identity: kubernetes.BackgroundIdentity("archiver"),
+	}
+}
+
+// Run archives finished evaluations every interval until ctx is cancelled
+func (a *Archiver) Run(ctx context.Context) {
+	a.logger.Info("starting archiver", slog.Duration("interval", a.interval))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.Reconcile(ctx); err != nil {
+			a.logger.Error("failed to archive finished evaluations", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			a.logger.Info("stopping archiver")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile archives the finished evaluations of every namespace that were not archived yet
+func (a *Archiver) Reconcile(ctx context.Context) error {
+	jobs, err := a.client.ListLMEvalJobs(ctx, a.identity, "")
+	if err != nil {
+		return err
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !job.Status.IsFinished() || job.Metadata.Annotations[constants.ArchivedAtAnnotation] != "" {
+			continue
+		}
+
+		snapshot := Snapshot(job, models.ArchiveTriggerCompleted, time.Now())
+		if err := a.store.Put(ctx, snapshot); err != nil {
+			a.logger.Error("failed to archive evaluation",
+				slog.String("evaluation", job.Metadata.Name),
+				slog.String("namespace", job.Metadata.Namespace),
+				slog.Any("error", err))
+			continue
+		}
+
+		patch, _ := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]string{constants.ArchivedAtAnnotation: snapshot.ArchivedAt.Format(time.RFC3339)},
+			},
+		})
+		if _, err := a.client.PatchLMEvalJob(ctx, a.identity, job.Metadata.Namespace, job.Metadata.Name, patch); err != nil {
+			a.logger.Error("failed to mark evaluation as archived", slog.String("evaluation", job.Metadata.Name), slog.Any("error", err))
+		}
+	}
+	return nil
+}
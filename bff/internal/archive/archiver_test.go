@@ -0,0 +1,63 @@
+package archive
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// countingStore counts the snapshots written to the store it wraps
+type countingStore struct {
+	Store
+	puts int
+}
+
+func (s *countingStore) Put(ctx context.Context, evaluation *models.ArchivedEvaluation) error {
+	s.puts++
+	return s.Store.Put(ctx, evaluation)
+}
+
+func TestArchiverArchivesFinishedEvaluationsOnce(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	identity := kubernetes.BackgroundIdentity("test")
+	for name, status := range map[string]string{
+		"complete-eval": `{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8}}}"}`,
+		"running-eval":  `{"state":"Running"}`,
+	} {
+		_, err := client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+			Metadata: models.LMEvalJobMetadata{Name: name},
+		})
+		assert.NoError(t, err)
+		_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", name, []byte(`{"status":`+status+`}`))
+		assert.NoError(t, err)
+	}
+
+	fileStore, err := NewFileStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	store := &countingStore{Store: fileStore}
+	archiver := NewArchiver(client, store, slog.Default(), DefaultInterval)
+
+	assert.NoError(t, archiver.Reconcile(context.Background()))
+	summaries, err := store.List(context.Background(), "project-1")
+	assert.NoError(t, err)
+	if !assert.Len(t, summaries, 1) {
+		return
+	}
+	assert.Equal(t, "complete-eval", summaries[0].Name)
+	assert.Equal(t, models.ArchiveTriggerCompleted, summaries[0].Trigger)
+
+	job, err := client.GetLMEvalJob(context.Background(), identity, "project-1", "complete-eval")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, job.Metadata.Annotations[constants.ArchivedAtAnnotation])
+
+	// Archived evaluations are skipped afterwards
+	assert.NoError(t, archiver.Reconcile(context.Background()))
+	assert.Equal(t, 1, store.puts)
+}
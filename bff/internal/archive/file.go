@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// FileStore keeps every snapshot as a JSON file named <dir>/<namespace>/<id>.json,
+// typically on a persistent volume mounted into the BFF
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("the file archive backend requires a directory")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(_ context.Context, evaluation *models.ArchivedEvaluation) error {
+	if err := validateKey(evaluation.Namespace, evaluation.ID); err != nil {
+		return err
+	}
+	data, err := json.Marshal(evaluation)
+	if err != nil {
+		return fmt.Errorf("failed to encode archived evaluation: %w", err)
+	}
+
+	dir := filepath.Join(s.dir, evaluation.Namespace)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	// Write then rename, so readers never see a partial snapshot
+	tmp, err := os.CreateTemp(dir, ".tmp-"+evaluation.ID+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, evaluation.ID+".json"))
+}
+
+func (s *FileStore) Get(_ context.Context, namespace, id string) (*models.ArchivedEvaluation, error) {
+	if validateKey(namespace, id) != nil {
+		return nil, ErrNotFound
+	}
+	raw, err := os.ReadFile(filepath.Join(s.dir, namespace, id+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decode(raw)
+}
+
+func (s *FileStore) List(_ context.Context, namespace string) ([]models.ArchivedEvaluationSummary, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, namespace))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	summaries := []models.ArchivedEvaluationSummary{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(s.dir, namespace, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		evaluation, err := decode(raw)
+		if err != nil {
+			// A damaged snapshot does not hide the others
+			continue
+		}
+		summaries = append(summaries, evaluation.ArchivedEvaluationSummary)
+	}
+	sortSummaries(summaries)
+	return summaries, nil
+}
@@ -0,0 +1,175 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// resourcePrefix is prepended to the ID to name the ConfigMap or Secret of a snapshot
+	resourcePrefix = "archived-"
+
+	// dataKey holds the gzipped JSON snapshot. Results can be large and ConfigMaps and
+	// Secrets are limited to 1 MiB, so snapshots are compressed.
+	dataKey = "evaluation.json.gz"
+)
+
+// KubernetesStore keeps every snapshot in a ConfigMap or a Secret in the namespace of
+// the evaluation, so access to the archive follows access to the namespace
+type KubernetesStore struct {
+	client kubernetes.KubernetesClientInterface
+	kind   string
+}
+
+// NewKubernetesStore creates a store writing ConfigMaps, or Secrets when kind is BackendSecret
+func NewKubernetesStore(client kubernetes.KubernetesClientInterface, kind string) *KubernetesStore {
+	return &KubernetesStore{client: client, kind: kind}
+}
+
+func (s *KubernetesStore) Put(ctx context.Context, evaluation *models.ArchivedEvaluation) error {
+	if err := validateKey(evaluation.Namespace, evaluation.ID); err != nil {
+		return err
+	}
+	data, err := compress(evaluation)
+	if err != nil {
+		return err
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:        resourcePrefix + evaluation.ID,
+		Labels:      map[string]string{constants.ArchivedEvaluationLabel: "true"},
+		Annotations: map[string]string{constants.ArchivedAtAnnotation: evaluation.ArchivedAt.Format(time.RFC3339)},
+	}
+	namespace := evaluation.Namespace
+
+	if s.kind == BackendSecret {
+		secret := &corev1.Secret{ObjectMeta: meta, Data: map[string][]byte{dataKey: data}}
+		_, err := s.client.CreateSecret(ctx, namespace, secret)
+		if apierrors.IsAlreadyExists(err) {
+			_, err = s.client.UpdateSecret(ctx, namespace, secret)
+		}
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: meta, BinaryData: map[string][]byte{dataKey: data}}
+	_, err = s.client.CreateConfigMap(ctx, namespace, configMap)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = s.client.UpdateConfigMap(ctx, namespace, configMap)
+	}
+	return err
+}
+
+func (s *KubernetesStore) Get(ctx context.Context, namespace, id string) (*models.ArchivedEvaluation, error) {
+	if validateKey(namespace, id) != nil {
+		return nil, ErrNotFound
+	}
+
+	var labels map[string]string
+	var data []byte
+	if s.kind == BackendSecret {
+		secret, err := s.client.GetSecret(ctx, namespace, resourcePrefix+id)
+		if err != nil {
+			return nil, notFoundAs(err)
+		}
+		labels, data = secret.Labels, secret.Data[dataKey]
+	} else {
+		configMap, err := s.client.GetConfigMap(ctx, namespace, resourcePrefix+id)
+		if err != nil {
+			return nil, notFoundAs(err)
+		}
+		labels, data = configMap.Labels, configMap.BinaryData[dataKey]
+	}
+
+	if labels[constants.ArchivedEvaluationLabel] != "true" {
+		return nil, ErrNotFound
+	}
+	return decompress(data)
+}
+
+func (s *KubernetesStore) List(ctx context.Context, namespace string) ([]models.ArchivedEvaluationSummary, error) {
+	selector := constants.ArchivedEvaluationLabel + "=true"
+
+	var blobs [][]byte
+	if s.kind == BackendSecret {
+		secrets, err := s.client.ListSecrets(ctx, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets {
+			blobs = append(blobs, secret.Data[dataKey])
+		}
+	} else {
+		configMaps, err := s.client.ListConfigMaps(ctx, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, configMap := range configMaps {
+			blobs = append(blobs, configMap.BinaryData[dataKey])
+		}
+	}
+
+	summaries := []models.ArchivedEvaluationSummary{}
+	for _, blob := range blobs {
+		evaluation, err := decompress(blob)
+		if err != nil {
+			// A damaged snapshot does not hide the others
+			continue
+		}
+		summaries = append(summaries, evaluation.ArchivedEvaluationSummary)
+	}
+	sortSummaries(summaries)
+	return summaries, nil
+}
+
+func notFoundAs(err error) error {
+	if apierrors.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func compress(evaluation *models.ArchivedEvaluation) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(evaluation); err != nil {
+		return nil, fmt.Errorf("failed to encode archived evaluation: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archived evaluation: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) (*models.ArchivedEvaluation, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived evaluation: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived evaluation: %w", err)
+	}
+	return decode(raw)
+}
+
+func decode(raw []byte) (*models.ArchivedEvaluation, error) {
+	var evaluation models.ArchivedEvaluation
+	if err := json.Unmarshal(raw, &evaluation); err != nil {
+		return nil, fmt.Errorf("failed to decode archived evaluation: %w", err)
+	}
+	return &evaluation, nil
+}
@@ -0,0 +1,231 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	s3Timeout       = 30 * time.Second
+)
+
+// S3Config configures an S3-compatible bucket, such as AWS S3, MinIO or Ceph RGW
+type S3Config struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.us-east-1.amazonaws.com
+	Endpoint string
+	Bucket   string
+	// Region is used to sign requests, us-east-1 when empty
+	Region string
+	// Prefix is prepended to the object keys, e.g. trustyai/
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Store keeps every snapshot as a JSON object named <prefix><namespace>/<id>.json.
+// Requests use path-style URLs, which every S3-compatible service supports, and are
+// signed with Signature Version 4.
+type S3Store struct {
+	endpoint   *url.URL
+	bucket     string
+	prefix     string
+	signer     *signer
+	httpClient *http.Client
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("the s3 archive backend requires an endpoint and a bucket")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("the s3 archive backend requires an access key ID and a secret access key")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	return &S3Store{
+		endpoint: endpoint,
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+		signer: &signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			region:          region,
+			service:         "s3",
+		},
+		httpClient: &http.Client{Timeout: s3Timeout},
+		now:        time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, evaluation *models.ArchivedEvaluation) error {
+	if err := validateKey(evaluation.Namespace, evaluation.ID); err != nil {
+		return err
+	}
+	data, err := json.Marshal(evaluation)
+	if err != nil {
+		return fmt.Errorf("failed to encode archived evaluation: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, s.key(evaluation.Namespace, evaluation.ID), nil, data)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, namespace, id string) (*models.ArchivedEvaluation, error) {
+	if validateKey(namespace, id) != nil {
+		return nil, ErrNotFound
+	}
+	return s.get(ctx, s.key(namespace, id))
+}
+
+func (s *S3Store) List(ctx context.Context, namespace string) ([]models.ArchivedEvaluationSummary, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.listKeys(ctx, s.prefix+namespace+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	// Listings only return keys, the summaries are read from the snapshots themselves
+	summaries := []models.ArchivedEvaluationSummary{}
+	for _, key := range keys {
+		evaluation, err := s.get(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, evaluation.ArchivedEvaluationSummary)
+	}
+	sortSummaries(summaries)
+	return summaries, nil
+}
+
+func (s *S3Store) key(namespace, id string) string {
+	return s.prefix + namespace + "/" + id + ".json"
+}
+
+func (s *S3Store) get(ctx context.Context, key string) (*models.ArchivedEvaluation, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived evaluation: %w", err)
+	}
+	return decode(raw)
+}
+
+// listBucketResult is the subset of the ListObjectsV2 response the store reads
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) listKeys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the s3 object listing: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			if strings.HasSuffix(object.Key, ".json") {
+				keys = append(keys, object.Key)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do sends a signed request for an object of the bucket, or for the bucket itself when
+// key is empty. Responses other than 2xx are turned into errors, 404 into ErrNotFound.
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(target.Path, "/") + "/" + s.bucket
+	if key != "" {
+		target.Path += "/" + key
+	}
+	target.RawPath = uriEncode(target.Path, false)
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	s.signer.sign(req, hashHex(body), s.now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request failed: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && key != "" {
+		return nil, ErrNotFound
+	}
+	var s3Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if xml.Unmarshal(raw, &s3Error) == nil && s3Error.Code != "" {
+		return nil, fmt.Errorf("s3 %s %s failed with HTTP %d: %s: %s", method, target.Path, resp.StatusCode, s3Error.Code, s3Error.Message)
+	}
+	return nil, fmt.Errorf("s3 %s %s failed with HTTP %d", method, target.Path, resp.StatusCode)
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Actions performed on evaluations, by users or by the BFF's background workers
+const (
+	ActionCreateEvaluation = "evaluation.create"
+	ActionUpdateEvaluation = "evaluation.update"
+	ActionDeleteEvaluation = "evaluation.delete"
+)
+
+// Event describes a single mutating action performed through the BFF.
+type Event struct {
+	User      string
+	Groups    []string
+	Action    string
+	Namespace string
+	Resource  string
+	Outcome   string
+	TraceID   string
+	Error     string
+}
+
+// NewEvent returns the event of an action performed by user. A nil err marks the action
+// as successful.
+func NewEvent(user string, groups []string, action, namespace, resource string, err error) Event {
+	event := Event{
+		User:      user,
+		Groups:    groups,
+		Action:    action,
+		Namespace: namespace,
+		Resource:  resource,
+		Outcome:   OutcomeSuccess,
+	}
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		event.Error = err.Error()
+	}
+	return event
+}
+
+// Logger writes audit events through a dedicated slog handler so they can be
+// shipped separately from the regular application logs.
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates an audit logger that writes JSON events to w.
+func NewLogger(w io.Writer) *Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo})
+	return &Logger{logger: slog.New(handler).With(slog.String("log_type", "audit"))}
+}
+
+// NewFileLogger creates an audit logger appending to the file at path.
+// The returned closer must be closed on shutdown.
+func NewFileLogger(path string) (*Logger, io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log file %q: %w", path, err)
+	}
+	return NewLogger(f), f, nil
+}
+
+// Log records an audit event. It is safe to call on a nil Logger.
+func (l *Logger) Log(ctx context.Context, event Event) {
+	if l == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("user", event.User),
+		slog.Any("groups", event.Groups),
+		slog.String("action", event.Action),
+		slog.String("namespace", event.Namespace),
+		slog.String("resource", event.Resource),
+		slog.String("outcome", event.Outcome),
+		slog.String("trace_id", event.TraceID),
+	}
+	if event.Error != "" {
+		attrs = append(attrs, slog.String("error", event.Error))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "audit event", attrs...)
+}
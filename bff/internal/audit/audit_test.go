@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerWritesStructuredEvent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+
+	logger.Log(context.Background(), Event{
+		User:      "user@example.com",
+		Groups:    []string{"team-a"},
+		Action:    "evaluation.create",
+		Namespace: "project-1",
+		Resource:  "lmevaljobs/my-eval",
+		Outcome:   OutcomeSuccess,
+		TraceID:   "trace-1",
+	})
+
+	var entry map[string]any
+	err := json.Unmarshal(buf.Bytes(), &entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "audit", entry["log_type"])
+	assert.Equal(t, "user@example.com", entry["user"])
+	assert.Equal(t, []any{"team-a"}, entry["groups"])
+	assert.Equal(t, "evaluation.create", entry["action"])
+	assert.Equal(t, "project-1", entry["namespace"])
+	assert.Equal(t, "lmevaljobs/my-eval", entry["resource"])
+	assert.Equal(t, OutcomeSuccess, entry["outcome"])
+	assert.Equal(t, "trace-1", entry["trace_id"])
+	assert.NotContains(t, entry, "error")
+}
+
+func TestNilLoggerIsNoop(t *testing.T) {
+	var logger *Logger
+	assert.NotPanics(t, func() {
+		logger.Log(context.Background(), Event{Action: "evaluation.delete"})
+	})
+}
@@ -0,0 +1,135 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DefaultInterval is how often batches are checked for evaluations to release
+const DefaultInterval = 15 * time.Second
+
+var resumePatch = []byte(`{"spec":{"suspend":false}}`)
+
+// Controller starts suspended evaluations of a batch as running ones finish,
+// keeping each batch within its concurrency cap.
+type Controller struct {
+	client   kubernetes.KubernetesClientInterface
+	logger   *slog.Logger
+	interval time.Duration
+	identity *kubernetes.RequestIdentity
+}
+
+func NewController(client kubernetes.KubernetesClientInterface, logger *slog.Logger, interval time.Duration) *Controller {
+	return &Controller{
+		client:   client,
+		logger:   logger.With(slog.String("component", "batch-controller")),
+		interval: interval,
+		identity: kubernetes.BackgroundIdentity("batch-controller"),
+	}
+}
+
+// Run reconciles batches every interval until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) {
+	c.logger.Info("starting batch controller", slog.Duration("interval", c.interval))
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Reconcile(ctx); err != nil {
+			c.logger.Error("failed to reconcile evaluation batches", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Info("stopping batch controller")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type batchKey struct {
+	namespace, name string
+}
+
+// Reconcile releases suspended evaluations of every capped batch across all namespaces
+func (c *Controller) Reconcile(ctx context.Context) error {
+	jobs, err := c.client.ListLMEvalJobs(ctx, c.identity, "")
+	if err != nil {
+		return err
+	}
+
+	batches := map[batchKey][]models.LMEvalJobKind{}
+	for _, job := range jobs.Items {
+		name := job.Metadata.Labels[constants.BatchLabel]
+		if name == "" || job.Metadata.Annotations[constants.BatchMaxConcurrentAnnotation] == "" {
+			continue
+		}
+		key := batchKey{namespace: job.Metadata.Namespace, name: name}
+		batches[key] = append(batches[key], job)
+	}
+
+	for key, batchJobs := range batches {
+		if err := c.reconcileBatch(ctx, batchJobs); err != nil {
+			c.logger.Error("failed to reconcile batch",
+				slog.String("batch", key.name),
+				slog.String("namespace", key.namespace),
+				slog.Any("error", err))
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) reconcileBatch(ctx context.Context, jobs []models.LMEvalJobKind) error {
+	// Jobs are released in the order they were created
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Metadata.CreationTimestamp.Equal(jobs[j].Metadata.CreationTimestamp) {
+			return jobs[i].Metadata.Name < jobs[j].Metadata.Name
+		}
+		return jobs[i].Metadata.CreationTimestamp.Before(jobs[j].Metadata.CreationTimestamp)
+	})
+
+	maxConcurrent := 0
+	active := 0
+	var suspended []models.LMEvalJobKind
+	for _, job := range jobs {
+		if limit, err := strconv.Atoi(job.Metadata.Annotations[constants.BatchMaxConcurrentAnnotation]); err == nil && limit > maxConcurrent {
+			maxConcurrent = limit
+		}
+		switch {
+		case job.Spec.Suspend:
+			suspended = append(suspended, job)
+		case !job.Status.IsFinished():
+			active++
+		}
+	}
+
+	for _, job := range suspended {
+		if active >= maxConcurrent {
+			break
+		}
+		_, err := c.client.PatchLMEvalJob(ctx, c.identity, job.Metadata.Namespace, job.Metadata.Name, resumePatch)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to start %q: %w", job.Metadata.Name, err)
+		}
+		active++
+
+		c.logger.Info("started queued batch evaluation",
+			slog.String("batch", job.Metadata.Labels[constants.BatchLabel]),
+			slog.String("namespace", job.Metadata.Namespace),
+			slog.String("evaluation", job.Metadata.Name))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package batch
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func createBatchJob(t *testing.T, client kubernetes.KubernetesClientInterface, name string, suspend bool) {
+	_, err := client.CreateLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:        name,
+			Labels:      map[string]string{constants.BatchLabel: "llama-sweep"},
+			Annotations: map[string]string{constants.BatchMaxConcurrentAnnotation: "2"},
+		},
+		Spec: models.LMEvalJobSpec{Suspend: suspend},
+	})
+	assert.NoError(t, err)
+}
+
+func suspendedJobs(t *testing.T, client kubernetes.KubernetesClientInterface) []string {
+	jobs, err := client.ListLMEvalJobs(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1")
+	assert.NoError(t, err)
+
+	var names []string
+	for _, job := range jobs.Items {
+		if job.Spec.Suspend {
+			names = append(names, job.Metadata.Name)
+		}
+	}
+	return names
+}
+
+func TestReconcileRespectsConcurrencyCap(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createBatchJob(t, client, "sweep-a", false)
+	createBatchJob(t, client, "sweep-b", true)
+	createBatchJob(t, client, "sweep-c", true)
+
+	controller := NewController(client, slog.Default(), time.Minute)
+	assert.NoError(t, controller.Reconcile(context.Background()))
+
+	// One job was running, so exactly one more is released
+	assert.Len(t, suspendedJobs(t, client), 1)
+
+	// Releasing is idempotent while both slots are busy
+	assert.NoError(t, controller.Reconcile(context.Background()))
+	assert.Len(t, suspendedJobs(t, client), 1)
+
+	// A finished job frees a slot for the remaining one
+	_, err := client.PatchLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "sweep-a",
+		[]byte(`{"status":{"state":"Complete"}}`))
+	assert.NoError(t, err)
+	assert.NoError(t, controller.Reconcile(context.Background()))
+	assert.Empty(t, suspendedJobs(t, client))
+}
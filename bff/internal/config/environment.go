@@ -30,6 +30,9 @@ const (
 
 	// DefaultOAuthProxyTokenHeader is the header used by OAuth proxy to inject access tokens.
 	DefaultOAuthProxyTokenHeader = "X-forward-access-token"
+
+	// DefaultDatasetLoaderImage runs the Job copying large evaluation datasets to their PVC.
+	DefaultDatasetLoaderImage = "registry.access.redhat.com/ubi9/ubi-minimal:latest"
 )
 
 type EnvConfig struct {
@@ -54,4 +57,67 @@ type EnvConfig struct {
 	// OAuth Proxy specific configuration
 	// Header used to extract the access token from OAuth proxy sidecar
 	OAuthProxyTokenHeader string
+
+	// ─── TEMPLATES ──────────────────────────────────────────────
+	// Namespace holding cluster-wide evaluation templates managed by admins.
+	// When empty, only namespace scoped templates are available.
+	TemplatesNamespace string
+
+	// ─── AUDIT ──────────────────────────────────────────────────
+	// File that audit events for mutating requests are appended to.
+	// When empty, audit events are written as JSON to stdout.
+	AuditLogFile string
+
+	// ─── NOTIFICATIONS ──────────────────────────────────────────
+	// URL that evaluation lifecycle CloudEvents are sent to, such as a Knative broker.
+	// When empty, no CloudEvents are published.
+	CloudEventsSinkURL string
+
+	// Comma separated CIDRs webhooks may be sent to although they are not public, such as
+	// the Service network of in-cluster receivers. Other loopback, link-local and private
+	// addresses are refused.
+	WebhookAllowedCIDRs string
+
+	// ─── ARCHIVE ────────────────────────────────────────────────
+	// Backend keeping snapshots of evaluations: "configmap", "secret", "file" or "s3".
+	// When empty, evaluations are not archived.
+	ArchiveBackend string
+
+	// Directory of the file backend, typically a mounted persistent volume.
+	ArchiveDir string
+
+	// Bucket of the s3 backend. Credentials are read from AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY, keeping them out of the logged configuration.
+	ArchiveS3Endpoint string
+	ArchiveS3Bucket   string
+	ArchiveS3Region   string
+	ArchiveS3Prefix   string
+
+	// ─── HISTORY ────────────────────────────────────────────────
+	// File of the evaluation history, fed by watching LMEvalJobs. It keeps the evaluations
+	// of past months after their deletion. When empty, no history is recorded.
+	HistoryFile string
+
+	// ─── TRUSTYAI ───────────────────────────────────────────────
+	// URL of the Prometheus scraping the TrustyAI services, queried with the user's token
+	// for metric time series, typically the tenancy port of the OpenShift Thanos querier.
+	// When empty, metric time series are not available.
+	PrometheusURL string
+
+	// ─── DATASETS ───────────────────────────────────────────────
+	// Image of the Job copying custom evaluation datasets too large for a ConfigMap to
+	// their PVC. It only needs a shell; air-gapped installs can point it to a mirror.
+	DatasetLoaderImage string
+
+	// ─── BACKGROUND WORKERS ─────────────────────────────────────
+	// Runs the evaluation scheduler in this process.
+	EnableScheduler bool
+
+	// Runs the retention controller, which deletes finished evaluations older than the
+	// retention period set on their namespace.
+	EnableRetention bool
+
+	// Namespace holding the Lease used to elect the replica that runs background workers.
+	// When empty, workers run without leader election, which is only safe with a single replica.
+	LeaderElectionNamespace string
 }
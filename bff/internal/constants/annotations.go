@@ -0,0 +1,71 @@
+package constants
+
+// Annotations and labels the BFF stamps on the resources it manages.
+const (
+	DisplayNameAnnotation = "opendatahub.io/display-name"
+	DescriptionAnnotation = "opendatahub.io/description"
+	CreatedByAnnotation   = "trustyai.opendatahub.io/created-by"
+	TemplateAnnotation    = "trustyai.opendatahub.io/template"
+
+	EvaluationTemplateLabel = "trustyai.opendatahub.io/evaluation-template"
+
+	// TagsAnnotation holds the comma separated free-form tags of an evaluation
+	TagsAnnotation = "trustyai.opendatahub.io/tags"
+
+	// ManagedLabelPrefix is the prefix of the labels the BFF sets itself, users cannot change them
+	ManagedLabelPrefix = "trustyai.opendatahub.io/"
+
+	// Schedules are stored as labelled ConfigMaps; the evaluations they create carry
+	// the schedule name as a label and the time they were scheduled for as an annotation.
+	EvaluationScheduleLabel    = "trustyai.opendatahub.io/evaluation-schedule"
+	ScheduleLabel              = "trustyai.opendatahub.io/schedule"
+	LastScheduleTimeAnnotation = "trustyai.opendatahub.io/last-schedule-time"
+	ScheduledTimeAnnotation    = "trustyai.opendatahub.io/scheduled-time"
+
+	// SignatureAnnotation carries the BFF's signature over records its service account acts on
+	SignatureAnnotation = "trustyai.opendatahub.io/signature"
+
+	// Evaluations created together by a batch request share the batch label. The concurrency
+	// cap is stored on every job so the batch controller can release suspended ones.
+	BatchLabel                   = "trustyai.opendatahub.io/batch"
+	BatchMaxConcurrentAnnotation = "trustyai.opendatahub.io/batch-max-concurrent"
+
+	// RetentionDaysAnnotation is set on a namespace to have the retention controller delete
+	// evaluations that finished more than the given number of days ago
+	RetentionDaysAnnotation = "trustyai.opendatahub.io/evaluation-retention-days"
+
+	// Archived evaluations stored as ConfigMaps or Secrets carry the archive label. Finished
+	// evaluations are annotated with the time the archive took their snapshot.
+	ArchivedEvaluationLabel = "trustyai.opendatahub.io/archived-evaluation"
+	ArchivedAtAnnotation    = "trustyai.opendatahub.io/archived-at"
+
+	// ExperimentLabel groups related evaluations, such as the runs of a model release review
+	ExperimentLabel = "trustyai.opendatahub.io/experiment"
+
+	// RegressionPolicyAnnotation holds the JSON encoded regression policy of an evaluation
+	RegressionPolicyAnnotation = "trustyai.opendatahub.io/regression-policy"
+
+	// QualityGateAnnotation holds the JSON encoded metric thresholds of an evaluation
+	QualityGateAnnotation = "trustyai.opendatahub.io/quality-gate"
+
+	// Webhook subscriptions are stored as labelled ConfigMaps, their delivery history in a
+	// second ConfigMap labelled with the subscription name. Evaluations are annotated with
+	// the event sent for them so every state transition is notified once; while deliveries
+	// are retried, with the subscriptions that already received it.
+	NotificationSubscriptionLabel   = "trustyai.opendatahub.io/notification-subscription"
+	NotificationDeliveriesLabel     = "trustyai.opendatahub.io/notification-deliveries"
+	NotifiedEventAnnotation         = "trustyai.opendatahub.io/notified-event"
+	NotifiedSubscriptionsAnnotation = "trustyai.opendatahub.io/notified-subscriptions"
+
+	// GuardrailsEvaluationLabel marks the ConfigMaps storing guardrails evaluations, with
+	// their prompts and results
+	GuardrailsEvaluationLabel = "trustyai.opendatahub.io/guardrails-evaluation"
+
+	// DatasetLabel marks the ConfigMap describing a custom evaluation dataset, and holding it
+	// when it is small, with the dataset name; so are the PVC of larger datasets and the Job
+	// loading them, whose chunk ConfigMaps carry DatasetChunkLabel. Evaluations of a dataset
+	// are annotated with its name.
+	DatasetLabel      = "trustyai.opendatahub.io/dataset"
+	DatasetChunkLabel = "trustyai.opendatahub.io/dataset-chunk"
+	DatasetAnnotation = "trustyai.opendatahub.io/dataset"
+)
@@ -0,0 +1,201 @@
+package datasets
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// chunkBytes is the size of the chunk ConfigMaps the loader Job copies to the PVC
+	chunkBytes = MaxConfigMapBytes
+	chunkKey   = "chunk"
+
+	// minPVCBytes is the smallest PVC requested, storage classes round small sizes up anyway
+	minPVCBytes = 64 << 20
+
+	// loaderTTL keeps the finished loader Job, and so its chunks, for a day, for the state
+	// of the dataset to be reported
+	loaderTTL = int32(24 * 60 * 60)
+
+	chunksPath  = "/chunks"
+	datasetPath = "/dataset"
+)
+
+// PVCName returns the name of the PVC storing the named dataset
+func PVCName(name string) string {
+	return configMapPrefix + name
+}
+
+// LoaderJobName returns the name of the Job copying the named dataset to its PVC
+func LoaderJobName(name string) string {
+	return configMapPrefix + name + "-loader"
+}
+
+// chunkName returns the name of a chunk ConfigMap of the named dataset
+func chunkName(name string, index int) string {
+	return fmt.Sprintf("%s%s-chunk-%03d", configMapPrefix, name, index)
+}
+
+// Chunks returns the number of chunk ConfigMaps of a dataset of the given size
+func Chunks(size int64) int {
+	return int((size + chunkBytes - 1) / chunkBytes)
+}
+
+// PersistentVolumeClaim is the PVC storing a dataset, with room for the Hugging Face files
+// offline evaluations may need besides it
+func PersistentVolumeClaim(dataset *models.Dataset) *corev1.PersistentVolumeClaim {
+	size := max(2*dataset.Size, minPVCBytes)
+	// Whole mebibytes read better in the cluster
+	size = (size + 1<<20 - 1) &^ (1<<20 - 1)
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   PVCName(dataset.Name),
+			Labels: map[string]string{constants.DatasetLabel: dataset.Name},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *resource.NewQuantity(size, resource.BinarySI),
+				},
+			},
+		},
+	}
+}
+
+// LoaderJob is the Job concatenating the chunk ConfigMaps of a dataset into its file on the
+// PVC. Its pod waits for the chunks, which are created once the Job exists to be owned by it
+// and deleted with it.
+func LoaderJob(dataset *models.Dataset, image string) *batchv1.Job {
+	chunks := Chunks(dataset.Size)
+	sources := make([]corev1.VolumeProjection, chunks)
+	for i := range sources {
+		sources[i] = corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: chunkName(dataset.Name, i)},
+				Items:                []corev1.KeyToPath{{Key: chunkKey, Path: fmt.Sprintf("%03d", i)}},
+			},
+		}
+	}
+
+	// The file is written under a temporary name so that it only appears once complete
+	file := path.Join(datasetPath, FileName(dataset.Format))
+	script := fmt.Sprintf("cat %s/* > %s.part && mv %s.part %s", chunksPath, file, file, file)
+
+	labels := map[string]string{constants.DatasetLabel: dataset.Name}
+	backoffLimit := int32(2)
+	ttl := loaderTTL
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   LoaderJobName(dataset.Name),
+			Labels: labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "loader",
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", script},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "chunks", MountPath: chunksPath, ReadOnly: true},
+							{Name: "dataset", MountPath: datasetPath},
+						},
+						SecurityContext: restrictedSecurityContext(),
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "chunks", VolumeSource: corev1.VolumeSource{
+							Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+						}},
+						{Name: "dataset", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: PVCName(dataset.Name)},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// restrictedSecurityContext complies with the restricted pod security standard
+func restrictedSecurityContext() *corev1.SecurityContext {
+	yes, no := true, false
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &no,
+		RunAsNonRoot:             &yes,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+// ChunkConfigMaps reads a dataset into the chunk ConfigMaps of its loader Job, which owns
+// them, calling create with each of them in order
+func ChunkConfigMaps(dataset *models.Dataset, file io.Reader, job *batchv1.Job, create func(*corev1.ConfigMap) error) error {
+	owner := metav1.OwnerReference{
+		APIVersion: "batch/v1",
+		Kind:       "Job",
+		Name:       job.Name,
+		UID:        job.UID,
+	}
+	buffer := make([]byte, chunkBytes)
+	for i := 0; ; i++ {
+		n, err := io.ReadFull(file, buffer)
+		if n > 0 {
+			chunk := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            chunkName(dataset.Name, i),
+					Labels:          map[string]string{constants.DatasetChunkLabel: dataset.Name},
+					OwnerReferences: []metav1.OwnerReference{owner},
+				},
+				// Chunks may split characters, so they are binary
+				BinaryData: map[string][]byte{chunkKey: buffer[:n]},
+			}
+			if createErr := create(chunk); createErr != nil {
+				return createErr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// LoaderState sets the state of a dataset stored on a PVC from its loader Job, which is
+// deleted a day after finishing; the dataset is then taken to be Ready
+func LoaderState(dataset *models.Dataset, job *batchv1.Job) {
+	if dataset.Storage != models.DatasetStoragePVC {
+		return
+	}
+	switch {
+	case job == nil || job.Status.Succeeded > 0:
+		dataset.State = models.DatasetStateReady
+	case jobFailed(job):
+		dataset.State = models.DatasetStateFailed
+	default:
+		dataset.State = models.DatasetStateLoading
+	}
+}
+
+func jobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package datasets
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const (
+	// unitxt task and template of the generated cards: the input column is asked as an
+	// open question, the output column is its expected answer
+	qaTask     = "tasks.qa.open"
+	qaTemplate = "templates.qa.open.simple"
+	qaQuestion = "question"
+	qaAnswers  = "answers"
+
+	// offlineStoragePath is where the operator mounts the PVC of offline jobs, as HF_HOME
+	offlineStoragePath = "/opt/app-root/src/hf_home"
+	// configMapPath is where the ConfigMap of a small dataset is mounted
+	configMapPath   = "/opt/app-root/src/dataset"
+	configMapVolume = "dataset"
+)
+
+// offlineEnv keeps lm-evaluation-harness, Hugging Face and unitxt from going online, as the
+// operator does for jobs with offline storage
+var offlineEnv = []models.LMEvalJobEnvVar{
+	{Name: "HF_HUB_OFFLINE", Value: "1"},
+	{Name: "HF_DATASETS_OFFLINE", Value: "1"},
+	{Name: "TRANSFORMERS_OFFLINE", Value: "1"},
+	{Name: "UNITXT_USE_ONLY_LOCAL_CATALOGS", Value: "True"},
+}
+
+// Apply adds a recipe evaluating the referenced dataset to a job, which then runs offline,
+// mounting the dataset's ConfigMap or PVC
+func Apply(job *models.LMEvalJobKind, dataset *models.Dataset, ref models.DatasetRef) error {
+	input, output := ref.InputColumn, ref.OutputColumn
+	if input == "" {
+		input = dataset.Ref.InputColumn
+	}
+	if output == "" {
+		output = dataset.Ref.OutputColumn
+	}
+	switch {
+	case input == "" || output == "":
+		return fmt.Errorf("dataset.inputColumn and dataset.outputColumn are required, the columns of dataset %q are %s", dataset.Name, strings.Join(dataset.Columns, ", "))
+	case input == output:
+		return fmt.Errorf("the input and output columns of the dataset must differ")
+	}
+	for _, column := range []string{input, output} {
+		if !slices.Contains(dataset.Columns, column) {
+			return fmt.Errorf("%q is not a text column of dataset %q, its columns are %s", column, dataset.Name, strings.Join(dataset.Columns, ", "))
+		}
+	}
+
+	file := path.Join(configMapPath, FileName(dataset.Format))
+	if dataset.Storage == models.DatasetStoragePVC {
+		file = path.Join(offlineStoragePath, FileName(dataset.Format))
+	}
+	card, err := Card(dataset.Format, file, input, output)
+	if err != nil {
+		return err
+	}
+
+	spec := &job.Spec
+	spec.TaskList.TaskRecipes = append(spec.TaskList.TaskRecipes, models.LMEvalJobTaskRecipe{
+		Card:     models.LMEvalJobCard{Custom: card},
+		Template: &models.LMEvalJobTemplate{Name: qaTemplate},
+	})
+	spec.AllowOnline = false
+	if dataset.Storage == models.DatasetStoragePVC {
+		spec.Offline = &models.LMEvalJobOffline{
+			Storage: models.LMEvalJobOfflineStorage{PVCName: PVCName(dataset.Name)},
+		}
+	} else {
+		// Offline storage needs a PVC, so the ConfigMap is mounted and the job taken offline
+		// with the environment the operator would set
+		if spec.Pod == nil {
+			spec.Pod = &models.LMEvalJobPod{}
+		}
+		if spec.Pod.Container == nil {
+			spec.Pod.Container = &models.LMEvalJobContainer{}
+		}
+		spec.Pod.Volumes = append(spec.Pod.Volumes, models.LMEvalJobVolume{
+			Name:      configMapVolume,
+			ConfigMap: &models.LMEvalJobConfigMapSource{Name: ConfigMapName(dataset.Name)},
+		})
+		spec.Pod.Container.VolumeMounts = append(spec.Pod.Container.VolumeMounts, models.LMEvalJobVolumeMount{
+			Name:      configMapVolume,
+			MountPath: configMapPath,
+			ReadOnly:  true,
+		})
+		spec.Pod.Container.Env = append(spec.Pod.Container.Env, offlineEnv...)
+	}
+
+	if job.Metadata.Annotations == nil {
+		job.Metadata.Annotations = map[string]string{}
+	}
+	job.Metadata.Annotations[constants.DatasetAnnotation] = dataset.Name
+	return nil
+}
+
+// Card is the JSON of a unitxt card loading the dataset file as its test split and renaming
+// its columns to the fields of an open question answering task
+func Card(format, file, input, output string) (string, error) {
+	loader := map[string]any{"__type__": "load_csv", "files": map[string]string{"test": file}}
+	if format == models.DatasetFormatJSONL {
+		loader = map[string]any{"__type__": "load_hf", "path": "json", "data_files": map[string]string{"test": file}}
+	}
+
+	renames := map[string]string{}
+	if input != qaQuestion {
+		renames[input] = qaQuestion
+	}
+	if output != qaAnswers {
+		renames[output] = qaAnswers
+	}
+	steps := []map[string]any{}
+	if len(renames) > 0 {
+		steps = append(steps, map[string]any{"__type__": "rename", "field_to_field": renames})
+	}
+	// The task expects a list of acceptable answers
+	steps = append(steps, map[string]any{"__type__": "wrap", "field": qaAnswers, "inside": "list"})
+
+	card, err := json.Marshal(map[string]any{
+		"__type__":         "task_card",
+		"loader":           loader,
+		"preprocess_steps": steps,
+		"task":             qaTask,
+		"templates":        []string{qaTemplate},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the unitxt card: %w", err)
+	}
+	return string(card), nil
+}
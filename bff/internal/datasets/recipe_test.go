@@ -0,0 +1,121 @@
+package datasets
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyConfigMapDataset(t *testing.T) {
+	dataset := &models.Dataset{
+		Name:    "support-qa",
+		Format:  models.DatasetFormatCSV,
+		Storage: models.DatasetStorageConfigMap,
+		Columns: []string{"ticket", "reply"},
+	}
+	job := &models.LMEvalJobKind{Spec: models.LMEvalJobSpec{AllowOnline: true}}
+
+	err := Apply(job, dataset, models.DatasetRef{Name: "support-qa"})
+	assert.Error(t, err, "the columns cannot be guessed")
+
+	err = Apply(job, dataset, models.DatasetRef{Name: "support-qa", InputColumn: "ticket", OutputColumn: "reply"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, job.Spec.AllowOnline)
+	assert.Nil(t, job.Spec.Offline)
+	assert.Equal(t, "support-qa", job.Metadata.Annotations[constants.DatasetAnnotation])
+	if assert.NotNil(t, job.Spec.Pod) && assert.NotNil(t, job.Spec.Pod.Container) {
+		assert.Equal(t, "eval-dataset-support-qa", job.Spec.Pod.Volumes[0].ConfigMap.Name)
+		assert.Equal(t, models.LMEvalJobVolumeMount{Name: "dataset", MountPath: configMapPath, ReadOnly: true}, job.Spec.Pod.Container.VolumeMounts[0])
+		assert.Contains(t, job.Spec.Pod.Container.Env, models.LMEvalJobEnvVar{Name: "HF_DATASETS_OFFLINE", Value: "1"})
+	}
+
+	if !assert.Len(t, job.Spec.TaskList.TaskRecipes, 1) {
+		return
+	}
+	recipe := job.Spec.TaskList.TaskRecipes[0]
+	assert.Equal(t, qaTemplate, recipe.Template.Name)
+	var card map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(recipe.Card.Custom), &card))
+	assert.Equal(t, "load_csv", card["loader"].(map[string]any)["__type__"])
+	assert.Equal(t, "/opt/app-root/src/dataset/dataset.csv", card["loader"].(map[string]any)["files"].(map[string]any)["test"])
+	assert.Equal(t, qaTask, card["task"])
+	steps := card["preprocess_steps"].([]any)
+	assert.Equal(t, map[string]any{"ticket": "question", "reply": "answers"}, steps[0].(map[string]any)["field_to_field"])
+	assert.Equal(t, "wrap", steps[1].(map[string]any)["__type__"])
+}
+
+func TestApplyPVCDataset(t *testing.T) {
+	dataset := &models.Dataset{
+		Name:    "tickets",
+		Format:  models.DatasetFormatJSONL,
+		Storage: models.DatasetStoragePVC,
+		Columns: []string{"question", "response"},
+		Ref:     models.DatasetRef{Name: "tickets", InputColumn: "question", OutputColumn: "response"},
+	}
+	job := &models.LMEvalJobKind{}
+
+	assert.Error(t, Apply(job, dataset, models.DatasetRef{Name: "tickets", OutputColumn: "answer"}))
+	assert.Error(t, Apply(job, dataset, models.DatasetRef{Name: "tickets", OutputColumn: "question"}))
+
+	if !assert.NoError(t, Apply(job, dataset, models.DatasetRef{Name: "tickets"})) {
+		return
+	}
+	assert.Nil(t, job.Spec.Pod)
+	if assert.NotNil(t, job.Spec.Offline) {
+		assert.Equal(t, "eval-dataset-tickets", job.Spec.Offline.Storage.PVCName)
+	}
+	card := job.Spec.TaskList.TaskRecipes[0].Card.Custom
+	assert.Contains(t, card, `"path":"json"`)
+	assert.Contains(t, card, `"/opt/app-root/src/hf_home/dataset.jsonl"`)
+	// Only the output column needs renaming
+	assert.Contains(t, card, `"field_to_field":{"response":"answers"}`)
+}
+
+func TestLoader(t *testing.T) {
+	dataset := &models.Dataset{Name: "tickets", Format: models.DatasetFormatJSONL, Storage: models.DatasetStoragePVC, Size: chunkBytes + 1}
+	assert.Equal(t, 2, Chunks(dataset.Size))
+	assert.Equal(t, 1, Chunks(chunkBytes))
+
+	pvc := PersistentVolumeClaim(dataset)
+	assert.Equal(t, "64Mi", pvc.Spec.Resources.Requests.Storage().String())
+
+	job := LoaderJob(dataset, "loader:latest")
+	assert.Equal(t, "eval-dataset-tickets-loader", job.Name)
+	assert.Len(t, job.Spec.Template.Spec.Volumes[0].Projected.Sources, 2)
+	assert.Equal(t, "eval-dataset-tickets", job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.Contains(t, job.Spec.Template.Spec.Containers[0].Command[2], "/dataset/dataset.jsonl")
+
+	job.UID = "job-uid"
+	file := strings.Repeat("x", int(dataset.Size))
+	var chunks []*corev1.ConfigMap
+	err := ChunkConfigMaps(dataset, strings.NewReader(file), job, func(chunk *corev1.ConfigMap) error {
+		chunks = append(chunks, chunk.DeepCopy())
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, chunks, 2) {
+		assert.Equal(t, "eval-dataset-tickets-chunk-001", chunks[1].Name)
+		assert.Equal(t, job.UID, chunks[1].OwnerReferences[0].UID)
+		assert.Equal(t, file, string(bytes.Join([][]byte{chunks[0].BinaryData[chunkKey], chunks[1].BinaryData[chunkKey]}, nil)))
+	}
+
+	LoaderState(dataset, job)
+	assert.Equal(t, models.DatasetStateLoading, dataset.State)
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	LoaderState(dataset, job)
+	assert.Equal(t, models.DatasetStateFailed, dataset.State)
+	job.Status = batchv1.JobStatus{Succeeded: 1}
+	LoaderState(dataset, job)
+	assert.Equal(t, models.DatasetStateReady, dataset.State)
+	LoaderState(dataset, nil)
+	assert.Equal(t, models.DatasetStateReady, dataset.State)
+}
@@ -0,0 +1,286 @@
+// Package datasets stores the custom datasets teams evaluate models on and wires them into
+// LMEvalJobs as unitxt cards. Datasets small enough are kept in a ConfigMap mounted into the
+// job, larger ones on a PVC filled by a loader Job and mounted by the job's offline mode.
+package datasets
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	configMapPrefix = "eval-dataset-"
+	specKey         = "dataset.json"
+
+	// MaxConfigMapBytes bounds the datasets stored in their ConfigMap, which holds at most
+	// 1 MiB with its metadata. Larger datasets are stored on a PVC.
+	MaxConfigMapBytes = 900 << 10
+
+	// MaxBytes bounds datasets, which are staged in chunk ConfigMaps on their way to a PVC
+	MaxBytes = 64 << 20
+
+	// maxNameLength leaves room for the prefix and the suffix of the chunk ConfigMaps
+	maxNameLength = 40
+)
+
+// ErrInvalidDataset is wrapped by the errors of uploaded files that are not datasets
+var ErrInvalidDataset = errors.New("invalid dataset")
+
+// columnPairs are the input and output columns guessed for datasets with common names
+var columnPairs = [][2]string{
+	{"question", "answer"},
+	{"question", "answers"},
+	{"prompt", "response"},
+	{"prompt", "completion"},
+	{"instruction", "output"},
+	{"input", "output"},
+}
+
+// datasetSpec is the description of a dataset stored as ConfigMap data, the rest is carried
+// by the ConfigMap's own metadata
+type datasetSpec struct {
+	Format       string   `json:"format"`
+	Storage      string   `json:"storage"`
+	Size         int64    `json:"size"`
+	Rows         int      `json:"rows"`
+	Columns      []string `json:"columns"`
+	InputColumn  string   `json:"inputColumn,omitempty"`
+	OutputColumn string   `json:"outputColumn,omitempty"`
+}
+
+// ConfigMapName returns the name of the ConfigMap describing the named dataset
+func ConfigMapName(name string) string {
+	return configMapPrefix + name
+}
+
+// LabelSelector selects the ConfigMaps describing datasets
+func LabelSelector() string {
+	return constants.DatasetLabel
+}
+
+// FileName is the name of the dataset file, in the ConfigMap or on the PVC
+func FileName(format string) string {
+	return "dataset." + format
+}
+
+// ValidateName checks the name of a dataset before it is uploaded
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid dataset name %q: %s", name, strings.Join(errs, ", "))
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("dataset name must be no more than %d characters", maxNameLength)
+	}
+	return nil
+}
+
+// Inspect reads a dataset, returning its text columns and number of rows. JSON Lines files
+// hold an object per line, their columns are the fields with a string value in the first
+// one and every other line must have them. CSV files have a header.
+func Inspect(file io.Reader, format string) ([]string, int, error) {
+	switch format {
+	case models.DatasetFormatJSONL:
+		return inspectJSONL(file)
+	case models.DatasetFormatCSV:
+		return inspectCSV(file)
+	}
+	return nil, 0, fmt.Errorf("format must be %s or %s", models.DatasetFormatJSONL, models.DatasetFormatCSV)
+}
+
+func inspectJSONL(file io.Reader) ([]string, int, error) {
+	reader := bufio.NewReader(file)
+	var columns []string
+	rows := 0
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 {
+			var row map[string]any
+			if jsonErr := json.Unmarshal(trimmed, &row); jsonErr != nil {
+				return nil, 0, fmt.Errorf("%w: line %d is not a JSON object: %w", ErrInvalidDataset, line, jsonErr)
+			}
+			if columns == nil {
+				for field, value := range row {
+					if _, ok := value.(string); ok {
+						columns = append(columns, field)
+					}
+				}
+				if len(columns) == 0 {
+					return nil, 0, fmt.Errorf("%w: line %d has no text field", ErrInvalidDataset, line)
+				}
+				sort.Strings(columns)
+			}
+			for _, column := range columns {
+				if _, ok := row[column].(string); !ok {
+					return nil, 0, fmt.Errorf("%w: line %d has no text field %q", ErrInvalidDataset, line, column)
+				}
+			}
+			rows++
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if rows == 0 {
+		return nil, 0, fmt.Errorf("%w: the dataset has no rows", ErrInvalidDataset)
+	}
+	return columns, rows, nil
+}
+
+func inspectCSV(file io.Reader) ([]string, int, error) {
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, 0, fmt.Errorf("%w: the CSV file is empty", ErrInvalidDataset)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+	}
+	for _, column := range header {
+		if column == "" || !utf8.ValidString(column) {
+			return nil, 0, fmt.Errorf("%w: the CSV header has a column without a name", ErrInvalidDataset)
+		}
+	}
+	reader.ReuseRecord = true
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+		}
+		for _, value := range record {
+			if !utf8.ValidString(value) {
+				return nil, 0, fmt.Errorf("%w: row %d is not UTF-8 text", ErrInvalidDataset, rows+1)
+			}
+		}
+		rows++
+	}
+	if rows == 0 {
+		return nil, 0, fmt.Errorf("%w: the dataset has no rows", ErrInvalidDataset)
+	}
+	return header, rows, nil
+}
+
+// SetColumns sets the input and output columns evaluations use by default, guessing the
+// ones left empty from the common names of columns
+func SetColumns(dataset *models.Dataset, input, output string) error {
+	for _, column := range []string{input, output} {
+		if column != "" && !slices.Contains(dataset.Columns, column) {
+			return fmt.Errorf("%q is not a text column of the dataset, its columns are %s", column, strings.Join(dataset.Columns, ", "))
+		}
+	}
+	if input == "" || output == "" {
+		for _, pair := range columnPairs {
+			if (input == "" || input == pair[0]) && (output == "" || output == pair[1]) &&
+				slices.Contains(dataset.Columns, pair[0]) && slices.Contains(dataset.Columns, pair[1]) {
+				input, output = pair[0], pair[1]
+				break
+			}
+		}
+	}
+	if input != "" && input == output {
+		return fmt.Errorf("the input and output columns must differ")
+	}
+	dataset.Ref = models.DatasetRef{Name: dataset.Name, InputColumn: input, OutputColumn: output}
+	return nil
+}
+
+// ToConfigMap serialises the description of a dataset into the ConfigMap that stores it,
+// together with the dataset itself when data is not nil
+func ToConfigMap(dataset *models.Dataset, data []byte) (*corev1.ConfigMap, error) {
+	spec, err := json.Marshal(datasetSpec{
+		Format:       dataset.Format,
+		Storage:      dataset.Storage,
+		Size:         dataset.Size,
+		Rows:         dataset.Rows,
+		Columns:      dataset.Columns,
+		InputColumn:  dataset.Ref.InputColumn,
+		OutputColumn: dataset.Ref.OutputColumn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dataset: %w", err)
+	}
+
+	annotations := map[string]string{
+		constants.CreatedByAnnotation: dataset.CreatedBy,
+	}
+	if dataset.DisplayName != "" {
+		annotations[constants.DisplayNameAnnotation] = dataset.DisplayName
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ConfigMapName(dataset.Name),
+			Labels:      map[string]string{constants.DatasetLabel: dataset.Name},
+			Annotations: annotations,
+		},
+		Data: map[string]string{
+			specKey: string(spec),
+		},
+	}
+	if data != nil {
+		configMap.Data[FileName(dataset.Format)] = string(data)
+	}
+	return configMap, nil
+}
+
+// FromConfigMap reads the description of a dataset back from its ConfigMap. Datasets
+// stored on a PVC are Loading, their state is set from the loader Job by LoaderState.
+func FromConfigMap(configMap *corev1.ConfigMap) (*models.Dataset, error) {
+	name := configMap.Labels[constants.DatasetLabel]
+	if name == "" || ConfigMapName(name) != configMap.Name {
+		return nil, fmt.Errorf("configmap %q does not describe a dataset", configMap.Name)
+	}
+
+	var spec datasetSpec
+	if err := json.Unmarshal([]byte(configMap.Data[specKey]), &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode dataset %q: %w", configMap.Name, err)
+	}
+
+	dataset := &models.Dataset{
+		Name:        name,
+		Namespace:   configMap.Namespace,
+		DisplayName: configMap.Annotations[constants.DisplayNameAnnotation],
+		Format:      spec.Format,
+		Storage:     spec.Storage,
+		Size:        spec.Size,
+		Rows:        spec.Rows,
+		Columns:     spec.Columns,
+		Ref: models.DatasetRef{
+			Name:         name,
+			InputColumn:  spec.InputColumn,
+			OutputColumn: spec.OutputColumn,
+		},
+		State:             models.DatasetStateReady,
+		UID:               string(configMap.UID),
+		CreatedBy:         configMap.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp: configMap.CreationTimestamp.Time,
+	}
+	if dataset.Storage == models.DatasetStoragePVC {
+		dataset.State = models.DatasetStateLoading
+	}
+	return dataset, nil
+}
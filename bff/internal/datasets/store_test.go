@@ -0,0 +1,102 @@
+package datasets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestInspect(t *testing.T) {
+	columns, rows, err := Inspect(strings.NewReader("{\"prompt\": \"hi\", \"response\": \"hello\", \"score\": 1}\n\n{\"prompt\": \"bye\", \"response\": \"goodbye\"}"), models.DatasetFormatJSONL)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prompt", "response"}, columns)
+	assert.Equal(t, 2, rows)
+
+	columns, rows, err = Inspect(strings.NewReader("question,answer\n\"Is it, really?\",yes\nno,\"multi\nline\"\n"), models.DatasetFormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"question", "answer"}, columns)
+	assert.Equal(t, 2, rows)
+
+	for name, test := range map[string]struct{ file, format string }{
+		"missing field":  {"{\"prompt\": \"hi\"}\n{\"response\": \"hello\"}\n", models.DatasetFormatJSONL},
+		"no text field":  {"{\"score\": 1}\n", models.DatasetFormatJSONL},
+		"not json":       {"prompt,response\n", models.DatasetFormatJSONL},
+		"empty jsonl":    {"\n", models.DatasetFormatJSONL},
+		"empty csv":      {"", models.DatasetFormatCSV},
+		"header only":    {"question,answer\n", models.DatasetFormatCSV},
+		"unnamed column": {"question,\nq,a\n", models.DatasetFormatCSV},
+		"ragged row":     {"question,answer\nq\n", models.DatasetFormatCSV},
+	} {
+		_, _, err := Inspect(strings.NewReader(test.file), test.format)
+		assert.True(t, errors.Is(err, ErrInvalidDataset), name)
+	}
+
+	_, _, err = Inspect(strings.NewReader("a,b\n"), "parquet")
+	assert.Error(t, err)
+}
+
+func TestSetColumns(t *testing.T) {
+	dataset := &models.Dataset{Name: "support-qa", Columns: []string{"answer", "question", "topic"}}
+	assert.NoError(t, SetColumns(dataset, "", ""))
+	assert.Equal(t, models.DatasetRef{Name: "support-qa", InputColumn: "question", OutputColumn: "answer"}, dataset.Ref)
+
+	assert.NoError(t, SetColumns(dataset, "topic", ""))
+	assert.Equal(t, models.DatasetRef{Name: "support-qa", InputColumn: "topic"}, dataset.Ref)
+
+	assert.NoError(t, SetColumns(dataset, "", "topic"))
+	assert.Equal(t, models.DatasetRef{Name: "support-qa", OutputColumn: "topic"}, dataset.Ref)
+
+	assert.Error(t, SetColumns(dataset, "prompt", ""))
+	assert.Error(t, SetColumns(dataset, "topic", "topic"))
+}
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("support-qa"))
+	assert.Error(t, ValidateName(""))
+	assert.Error(t, ValidateName("Support_QA"))
+	assert.Error(t, ValidateName(strings.Repeat("a", maxNameLength+1)))
+}
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	dataset := &models.Dataset{
+		Name:        "support-qa",
+		DisplayName: "Support questions",
+		Format:      models.DatasetFormatCSV,
+		Storage:     models.DatasetStorageConfigMap,
+		Size:        12,
+		Rows:        1,
+		Columns:     []string{"question", "answer"},
+		Ref:         models.DatasetRef{Name: "support-qa", InputColumn: "question", OutputColumn: "answer"},
+		CreatedBy:   "test-user",
+	}
+
+	configMap, err := ToConfigMap(dataset, []byte("question,answer\nq,a\n"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "eval-dataset-support-qa", configMap.Name)
+	assert.Equal(t, "question,answer\nq,a\n", configMap.Data["dataset.csv"])
+
+	read, err := FromConfigMap(configMap)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, dataset.Ref, read.Ref)
+	assert.Equal(t, dataset.Columns, read.Columns)
+	assert.Equal(t, "Support questions", read.DisplayName)
+	assert.Equal(t, "test-user", read.CreatedBy)
+	assert.Equal(t, models.DatasetStateReady, read.State)
+
+	dataset.Storage = models.DatasetStoragePVC
+	configMap, _ = ToConfigMap(dataset, nil)
+	assert.NotContains(t, configMap.Data, "dataset.csv")
+	read, _ = FromConfigMap(configMap)
+	assert.Equal(t, models.DatasetStateLoading, read.State)
+
+	configMap.Labels = nil
+	_, err = FromConfigMap(configMap)
+	assert.Error(t, err)
+}
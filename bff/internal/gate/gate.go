@@ -0,0 +1,168 @@
+// Package gate evaluates metric thresholds, the quality gate of an evaluation.
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// maxThresholds bounds the size of the quality gate annotation
+const maxThresholds = 50
+
+var thresholdPattern = regexp.MustCompile(`^\s*(\S+?)\s*(>=|<=|==|>|<)\s*(\S+)\s*$`)
+
+// Threshold is a parsed expression of the form "<task>.<metric>[,<filter>] <op> <value>"
+type Threshold struct {
+	Expression string
+	Key        string
+	Operator   string
+	Value      float64
+}
+
+// Parse parses a single threshold expression such as "arc_easy.acc >= 0.7"
+func Parse(expression string) (Threshold, error) {
+	match := thresholdPattern.FindStringSubmatch(expression)
+	if match == nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q, expected \"<task>.<metric> <op> <value>\"", expression)
+	}
+	key, operator := match[1], match[2]
+	task, metric, found := strings.Cut(key, ".")
+	if !found || task == "" || metric == "" || strings.HasPrefix(metric, ",") {
+		return Threshold{}, fmt.Errorf("invalid threshold %q, the metric must be given as <task>.<metric>", expression)
+	}
+	value, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q, %q is not a number", expression, match[3])
+	}
+	return Threshold{
+		Expression: strings.TrimSpace(expression),
+		Key:        key,
+		Operator:   operator,
+		Value:      value,
+	}, nil
+}
+
+// Validate checks the threshold expressions of a create request or template
+func Validate(expressions []string) error {
+	if len(expressions) > maxThresholds {
+		return fmt.Errorf("at most %d thresholds are allowed", maxThresholds)
+	}
+	for _, expression := range expressions {
+		if _, err := Parse(expression); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Encode serialises threshold expressions for the quality gate annotation
+func Encode(expressions []string) (string, error) {
+	data, err := json.Marshal(expressions)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode quality gate: %w", err)
+	}
+	return string(data), nil
+}
+
+// ThresholdsFor reads the quality gate of an evaluation, nil when it has none
+func ThresholdsFor(job *models.LMEvalJobKind) ([]Threshold, error) {
+	value := job.Metadata.Annotations[constants.QualityGateAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+	var expressions []string
+	if err := json.Unmarshal([]byte(value), &expressions); err != nil {
+		return nil, fmt.Errorf("invalid quality gate on %q: %w", job.Metadata.Name, err)
+	}
+	thresholds := make([]Threshold, 0, len(expressions))
+	for _, expression := range expressions {
+		threshold, err := Parse(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quality gate on %q: %w", job.Metadata.Name, err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// Evaluate checks the thresholds against an evaluation's results. A metric the
+// evaluation did not report fails its threshold.
+func Evaluate(job *models.LMEvalJobKind, thresholds []Threshold) *models.QualityGateResult {
+	result := &models.QualityGateResult{
+		Evaluation: job.Metadata.Name,
+		Namespace:  job.Metadata.Namespace,
+		Thresholds: make([]models.QualityGateOutcome, 0, len(thresholds)),
+	}
+	for _, threshold := range thresholds {
+		result.Thresholds = append(result.Thresholds, models.QualityGateOutcome{
+			Expression: threshold.Expression,
+			Key:        threshold.Key,
+			Operator:   threshold.Operator,
+			Threshold:  threshold.Value,
+		})
+	}
+
+	switch {
+	case !job.Status.IsFinished():
+		result.Verdict = models.QualityGateVerdictPending
+		result.Reason = "evaluation has not completed"
+		return result
+	case job.Status.IsFailed():
+		result.Verdict = models.QualityGateVerdictFail
+		result.Reason = "evaluation failed"
+		return result
+	}
+
+	metrics, err := models.ParseLMEvalResults(job.Status.Results)
+	if err != nil {
+		result.Verdict = models.QualityGateVerdictFail
+		result.Reason = err.Error()
+		return result
+	}
+	values := make(map[string]float64, len(metrics))
+	for _, metric := range metrics {
+		values[metric.Key()] = metric.Value
+	}
+
+	failed := 0
+	for i := range result.Thresholds {
+		outcome := &result.Thresholds[i]
+		if value, ok := values[outcome.Key]; ok {
+			outcome.Value = &value
+			outcome.Passed = compare(value, outcome.Operator, outcome.Threshold)
+		}
+		if !outcome.Passed {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		result.Verdict = models.QualityGateVerdictFail
+		result.Reason = fmt.Sprintf("%d of %d thresholds not met", failed, len(result.Thresholds))
+	} else {
+		result.Verdict = models.QualityGateVerdictPass
+	}
+	return result
+}
+
+func compare(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">=":
+		return value >= threshold
+	case ">":
+		return value > threshold
+	case "<=":
+		return value <= threshold
+	case "<":
+		return value < threshold
+	case "==":
+		return value == threshold
+	}
+	return false
+}
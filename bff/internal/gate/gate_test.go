@@ -0,0 +1,76 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const gateResults = `{"results":{"arc_easy":{"acc,none":0.72,"acc_norm,none":0.68},"gsm8k":{"exact_match,strict-match":0.41}}}`
+
+func evaluation(thresholds string, status *models.LMEvalJobStatus) *models.LMEvalJobKind {
+	return &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:        "llama",
+			Namespace:   "project-1",
+			Annotations: map[string]string{constants.QualityGateAnnotation: thresholds},
+		},
+		Status: status,
+	}
+}
+
+func TestParse(t *testing.T) {
+	threshold, err := Parse("arc_easy.acc >= 0.7")
+	assert.NoError(t, err)
+	assert.Equal(t, Threshold{Expression: "arc_easy.acc >= 0.7", Key: "arc_easy.acc", Operator: ">=", Value: 0.7}, threshold)
+
+	threshold, err = Parse("gsm8k.exact_match,strict-match<0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "gsm8k.exact_match,strict-match", threshold.Key)
+	assert.Equal(t, "<", threshold.Operator)
+
+	for _, expression := range []string{"", "arc_easy >= 0.7", "arc_easy.acc => 0.7", "arc_easy.acc >= high", ".acc > 1"} {
+		_, err := Parse(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	complete := &models.LMEvalJobStatus{State: models.LMEvalJobStateComplete, Results: gateResults}
+	job := evaluation(`["arc_easy.acc >= 0.7","gsm8k.exact_match,strict-match > 0.4"]`, complete)
+	thresholds, err := ThresholdsFor(job)
+	assert.NoError(t, err)
+
+	result := Evaluate(job, thresholds)
+	assert.Equal(t, models.QualityGateVerdictPass, result.Verdict)
+	assert.Len(t, result.Thresholds, 2)
+	assert.InDelta(t, 0.72, *result.Thresholds[0].Value, 1e-9)
+	assert.True(t, result.Thresholds[1].Passed)
+
+	// A threshold on a metric the evaluation did not report fails
+	job = evaluation(`["arc_easy.acc_norm >= 0.7","hellaswag.acc >= 0.5"]`, complete)
+	thresholds, _ = ThresholdsFor(job)
+	result = Evaluate(job, thresholds)
+	assert.Equal(t, models.QualityGateVerdictFail, result.Verdict)
+	assert.Equal(t, "2 of 2 thresholds not met", result.Reason)
+	assert.Nil(t, result.Thresholds[1].Value)
+
+	job = evaluation(`["arc_easy.acc >= 0.7"]`, &models.LMEvalJobStatus{State: "Running"})
+	thresholds, _ = ThresholdsFor(job)
+	assert.Equal(t, models.QualityGateVerdictPending, Evaluate(job, thresholds).Verdict)
+
+	job = evaluation(`["arc_easy.acc >= 0.7"]`, &models.LMEvalJobStatus{State: models.LMEvalJobStateComplete, Reason: models.LMEvalJobReasonFailed})
+	thresholds, _ = ThresholdsFor(job)
+	assert.Equal(t, models.QualityGateVerdictFail, Evaluate(job, thresholds).Verdict)
+}
+
+func TestThresholdsForWithoutGate(t *testing.T) {
+	thresholds, err := ThresholdsFor(&models.LMEvalJobKind{})
+	assert.NoError(t, err)
+	assert.Nil(t, thresholds)
+
+	_, err = ThresholdsFor(evaluation(`not json`, nil))
+	assert.Error(t, err)
+}
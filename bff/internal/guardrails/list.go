@@ -0,0 +1,76 @@
+package guardrails
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// ModelType is the model type shown for guardrails evaluations in the evaluation list
+const ModelType = "guardrails"
+
+// AsLMEvalJob presents an evaluation in the shape of the LMEvalJobs it is listed with, so
+// clients of the evaluation list can show both; Kind tells them apart. The orchestrator
+// takes the place of the model and the detectors the place of the tasks, and the detection
+// rates are reported as lm-evaluation-harness results with a detection_rate metric per
+// detector.
+func AsLMEvalJob(evaluation *models.GuardrailsEvaluation) models.LMEvalJobKind {
+	annotations := map[string]string{
+		constants.CreatedByAnnotation: evaluation.CreatedBy,
+	}
+	if evaluation.DisplayName != "" {
+		annotations[constants.DisplayNameAnnotation] = evaluation.DisplayName
+	}
+	var labels map[string]string
+	if evaluation.Experiment != "" {
+		labels = map[string]string{constants.ExperimentLabel: evaluation.Experiment}
+	}
+
+	job := models.LMEvalJobKind{
+		Kind: models.EvaluationKindGuardrails,
+		Metadata: models.LMEvalJobMetadata{
+			Name:              evaluation.Name,
+			Namespace:         evaluation.Namespace,
+			Labels:            labels,
+			Annotations:       annotations,
+			ResourceVersion:   evaluation.ResourceVersion,
+			UID:               evaluation.UID,
+			CreationTimestamp: evaluation.CreationTimestamp,
+		},
+		Spec: models.LMEvalJobSpec{
+			Model:     ModelType,
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "orchestrator", Value: evaluation.Orchestrator}},
+			TaskList:  models.LMEvalJobTaskList{TaskNames: evaluation.Detectors},
+		},
+		Status: &models.LMEvalJobStatus{
+			State:        evaluation.Status.State,
+			Message:      evaluation.Status.Message,
+			CompleteTime: evaluation.Status.CompleteTime,
+		},
+	}
+
+	// Failed evaluations are complete with a Failed reason, as the operator reports them
+	if evaluation.Status.State == models.GuardrailsStateFailed {
+		job.Status.State = models.LMEvalJobStateComplete
+		job.Status.Reason = models.LMEvalJobReasonFailed
+	}
+	if progress := evaluation.Status.Progress; progress != nil && progress.Total > 0 {
+		job.Status.ProgressBars = []models.LMEvalJobProgressBar{{
+			Count:   fmt.Sprintf("%d/%d", progress.Completed, progress.Total),
+			Message: "Prompts checked",
+			Percent: fmt.Sprintf("%d%%", progress.Completed*100/progress.Total),
+		}}
+	}
+	if results := evaluation.Status.Results; results != nil {
+		payload := map[string]map[string]map[string]float64{"results": {}}
+		for _, detector := range results.Detectors {
+			payload["results"][detector.Detector] = map[string]float64{"detection_rate,none": detector.DetectionRate}
+		}
+		if raw, err := json.Marshal(payload); err == nil {
+			job.Status.Results = string(raw)
+		}
+	}
+	return job
+}
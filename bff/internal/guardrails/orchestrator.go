@@ -0,0 +1,102 @@
+package guardrails
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+)
+
+const (
+	detectionPath       = "/api/v2/text/detection/content"
+	orchestratorTimeout = 60 * time.Second
+)
+
+// Detection is a span of content flagged by a detector
+type Detection struct {
+	Start         int     `json:"start"`
+	End           int     `json:"end"`
+	Text          string  `json:"text"`
+	Detection     string  `json:"detection"`
+	DetectionType string  `json:"detection_type"`
+	DetectorID    string  `json:"detector_id"`
+	Score         float64 `json:"score"`
+}
+
+// Detector checks content with the detectors of a guardrails orchestrator
+type Detector interface {
+	Detect(ctx context.Context, content string, detectors []string) ([]Detection, error)
+}
+
+// OrchestratorClient calls the detection API of a guardrails orchestrator
+type OrchestratorClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOrchestratorClient returns a client of the orchestrator at baseURL. No credentials are
+// sent: the BFF's service account token must not reach a service of a user namespace.
+func NewOrchestratorClient(baseURL string) *OrchestratorClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if pool := helper.ServiceCertPool(); pool != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &OrchestratorClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: orchestratorTimeout, Transport: transport},
+	}
+}
+
+// Detect runs content through the given detectors with their default parameters
+func (c *OrchestratorClient) Detect(ctx context.Context, content string, detectors []string) ([]Detection, error) {
+	request := struct {
+		Detectors map[string]map[string]any `json:"detectors"`
+		Content   string                    `json:"content"`
+	}{Detectors: map[string]map[string]any{}, Content: content}
+	for _, detector := range detectors {
+		request.Detectors[detector] = map[string]any{}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode detection request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+detectionPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var orchestratorError struct {
+			Code    int    `json:"code"`
+			Details string `json:"details"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(raw, &orchestratorError) == nil && orchestratorError.Details != "" {
+			return nil, fmt.Errorf("orchestrator detection failed with HTTP %d: %s", resp.StatusCode, orchestratorError.Details)
+		}
+		return nil, fmt.Errorf("orchestrator detection failed with HTTP %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Detections []Detection `json:"detections"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode detection response: %w", err)
+	}
+	return response.Detections, nil
+}
@@ -0,0 +1,43 @@
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrchestratorClientDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, detectionPath, r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var request struct {
+			Detectors map[string]map[string]any `json:"detectors"`
+			Content   string                    `json:"content"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Detectors, "hap")
+
+		if request.Content == "fail" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"code":422,"details":"unknown detector"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"detections":[{"start":0,"end":5,"text":"awful","detection":"has_HAP","detection_type":"hap","detector_id":"hap","score":0.97}]}`))
+	}))
+	defer server.Close()
+
+	client := NewOrchestratorClient(server.URL + "/")
+	detections, err := client.Detect(context.Background(), "awful words", []string{"hap"})
+	if assert.NoError(t, err) && assert.Len(t, detections, 1) {
+		assert.Equal(t, "hap", detections[0].DetectorID)
+		assert.InDelta(t, 0.97, detections[0].Score, 1e-9)
+	}
+
+	_, err = client.Detect(context.Background(), "fail", []string{"hap"})
+	assert.ErrorContains(t, err, "HTTP 422: unknown detector")
+}
@@ -0,0 +1,377 @@
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	authv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// DefaultInterval is how often evaluations are checked for ones to run
+	DefaultInterval = 15 * time.Second
+
+	// DefaultTimeout bounds the time an evaluation may run, so that a slow orchestrator
+	// does not hold up the evaluations queued behind it
+	DefaultTimeout = 30 * time.Minute
+
+	// progressInterval is how many prompts are checked between two progress updates
+	progressInterval = 25
+
+	// maxConsecutiveErrors is how many prompts in a row may fail before an evaluation is
+	// failed, the orchestrator is then most likely unreachable
+	maxConsecutiveErrors = 5
+)
+
+// Runner runs pending guardrails evaluations one at a time. Evaluations interrupted by a
+// restart are run again from the start. An evaluation fails when it runs longer than its
+// timeout, or when several prompts in a row cannot be checked. Only one replica should run it at a time, see
+// kubernetes.RunWithLeaderElection.
+//
+// Evaluations are stored in ConfigMaps of user namespaces and run with the BFF's service
+// account, so only evaluations signed by the API run, and only while the user who created
+// them may read their orchestrator and dataset.
+type Runner struct {
+	client   kubernetes.KubernetesClientInterface
+	signer   *signing.Signer
+	logger   *slog.Logger
+	interval time.Duration
+	// timeout bounds the run of each evaluation, see DefaultTimeout
+	timeout time.Duration
+
+	// connect returns the detector of an orchestrator, replaced in tests
+	connect func(orchestrator models.GuardrailsOrchestrator) (Detector, error)
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewRunner(client kubernetes.KubernetesClientInterface, signer *signing.Signer, logger *slog.Logger, interval time.Duration) *Runner {
+	r := &Runner{
+		client:   client,
+		signer:   signer,
+		logger:   logger.With(slog.String("component", "guardrails-runner")),
+		interval: interval,
+		timeout:  DefaultTimeout,
+		now:      time.Now,
+	}
+	r.connect = r.connectOrchestrator
+	return r
+}
+
+// Run runs pending evaluations every interval until ctx is cancelled
+func (r *Runner) Run(ctx context.Context) {
+	r.logger.Info("starting guardrails evaluation runner", slog.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			r.logger.Error("failed to run guardrails evaluations", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stopping guardrails evaluation runner")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile runs every unfinished evaluation across all namespaces, oldest first
+func (r *Runner) Reconcile(ctx context.Context) error {
+	configMaps, err := r.client.ListConfigMaps(ctx, "", LabelSelector())
+	if err != nil {
+		return err
+	}
+
+	var evaluations []*models.GuardrailsEvaluation
+	for i := range configMaps {
+		evaluation, err := FromConfigMap(&configMaps[i])
+		if err != nil {
+			r.logger.Warn("skipping unreadable guardrails evaluation", slog.String("configmap", configMaps[i].Name), slog.Any("error", err))
+			continue
+		}
+		if evaluation.Status.State == models.GuardrailsStatePending || evaluation.Status.State == models.GuardrailsStateRunning {
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].CreationTimestamp.Before(evaluations[j].CreationTimestamp)
+	})
+
+	for _, evaluation := range evaluations {
+		if err := r.run(ctx, evaluation); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			r.logger.Error("failed to run guardrails evaluation",
+				slog.String("evaluation", evaluation.Name),
+				slog.String("namespace", evaluation.Namespace),
+				slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context, evaluation *models.GuardrailsEvaluation) error {
+	startTime := r.now().UTC()
+	status := &models.GuardrailsEvaluationStatus{State: models.GuardrailsStateRunning, StartTime: &startTime}
+
+	refused, err := r.authorize(ctx, evaluation)
+	if err != nil {
+		return err
+	}
+	if refused != "" {
+		return r.finish(ctx, evaluation, status, refused, nil)
+	}
+
+	prompts, detector, err := r.prepare(ctx, evaluation)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return r.finish(ctx, evaluation, status, err.Error(), nil)
+	}
+
+	status.Progress = &models.GuardrailsProgress{Total: len(prompts)}
+	if err := r.writeStatus(ctx, evaluation, status); err != nil {
+		return err
+	}
+	r.logger.Info("running guardrails evaluation",
+		slog.String("evaluation", evaluation.Name),
+		slog.String("namespace", evaluation.Namespace),
+		slog.Int("prompts", len(prompts)))
+
+	runCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tally := newTally(evaluation.Detectors, len(prompts))
+	var lastErr error
+	consecutiveErrors := 0
+	for i, prompt := range prompts {
+		detections, err := detector.Detect(runCtx, prompt, evaluation.Detectors)
+		if ctx.Err() != nil {
+			// Left running, the next leader runs it again
+			return ctx.Err()
+		}
+		if runCtx.Err() != nil {
+			status.Progress.Completed = i
+			return r.finish(ctx, evaluation, status, fmt.Sprintf("the evaluation did not finish within %s, %d of %d prompts were checked", r.timeout, i, len(prompts)), nil)
+		}
+		if err != nil {
+			tally.errors++
+			lastErr = err
+			if consecutiveErrors++; consecutiveErrors == maxConsecutiveErrors {
+				status.Progress.Completed = i + 1
+				return r.finish(ctx, evaluation, status, fmt.Sprintf("stopped after %d prompts in a row could not be checked: %v", maxConsecutiveErrors, lastErr), nil)
+			}
+		} else {
+			consecutiveErrors = 0
+			tally.add(detections)
+		}
+
+		if completed := i + 1; completed%progressInterval == 0 && completed < len(prompts) {
+			status.Progress.Completed = completed
+			if err := r.writeStatus(ctx, evaluation, status); err != nil {
+				return err
+			}
+		}
+	}
+	status.Progress.Completed = len(prompts)
+
+	results := tally.results()
+	switch {
+	case results.Evaluated == 0:
+		return r.finish(ctx, evaluation, status, fmt.Sprintf("no prompt could be checked: %v", lastErr), nil)
+	case results.Errors > 0:
+		return r.finish(ctx, evaluation, status, fmt.Sprintf("%d of %d prompts could not be checked: %v", results.Errors, results.Prompts, lastErr), results)
+	default:
+		return r.finish(ctx, evaluation, status, "", results)
+	}
+}
+
+// authorize checks that an evaluation was created through the API and that the user it
+// runs as may read its orchestrator and dataset. It returns why an evaluation may not run;
+// errors of the access reviews leave it pending until the next pass.
+func (r *Runner) authorize(ctx context.Context, evaluation *models.GuardrailsEvaluation) (string, error) {
+	if err := Verify(r.signer, evaluation); err != nil {
+		return err.Error(), nil
+	}
+
+	runAs := &kubernetes.RequestIdentity{UserID: evaluation.RunAs.User, Groups: evaluation.RunAs.Groups}
+	checks := []authv1.ResourceAttributes{{
+		Verb:      "get",
+		Group:     "trustyai.opendatahub.io",
+		Resource:  "guardrailsorchestrators",
+		Namespace: evaluation.Namespace,
+		Name:      evaluation.Orchestrator,
+	}}
+	if dataset := evaluation.Dataset; dataset != nil {
+		checks = append(checks, authv1.ResourceAttributes{
+			Verb:      "get",
+			Resource:  "configmaps",
+			Namespace: evaluation.Namespace,
+			Name:      dataset.ConfigMap,
+		})
+	}
+	for _, check := range checks {
+		allowed, err := r.client.CanSubjectAccess(ctx, runAs, check)
+		if err != nil {
+			return "", fmt.Errorf("failed to authorize guardrails evaluation %q: %w", evaluation.Name, err)
+		}
+		if !allowed {
+			return fmt.Sprintf("%s may not %s %s %q", evaluation.RunAs.User, check.Verb, check.Resource, check.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// prepare resolves the orchestrator and reads the prompts of an evaluation
+func (r *Runner) prepare(ctx context.Context, evaluation *models.GuardrailsEvaluation) ([]string, Detector, error) {
+	orchestrators, err := r.client.GetGuardrailsOrchestrators(ctx, evaluation.Namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover guardrails orchestrators: %w", err)
+	}
+	var detector Detector
+	for _, orchestrator := range orchestrators {
+		if orchestrator.Name == evaluation.Orchestrator {
+			if detector, err = r.connect(orchestrator); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if detector == nil {
+		return nil, nil, fmt.Errorf("guardrails orchestrator %q not found", evaluation.Orchestrator)
+	}
+
+	prompts := evaluation.Prompts
+	if dataset := evaluation.Dataset; dataset != nil {
+		configMap, err := r.client.GetConfigMap(ctx, evaluation.Namespace, dataset.ConfigMap)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read dataset %q: %w", dataset.ConfigMap, err)
+		}
+		data, ok := configMap.Data[dataset.Key]
+		if !ok {
+			return nil, nil, fmt.Errorf("dataset %q has no key %q", dataset.ConfigMap, dataset.Key)
+		}
+		prompts = ParsePrompts(data)
+	}
+	if len(prompts) == 0 {
+		return nil, nil, fmt.Errorf("the dataset has no prompts")
+	}
+	return prompts, detector, nil
+}
+
+func (r *Runner) connectOrchestrator(orchestrator models.GuardrailsOrchestrator) (Detector, error) {
+	return NewOrchestratorClient(orchestrator.URL), nil
+}
+
+// finish records the outcome of an evaluation, failed when it has no results
+func (r *Runner) finish(ctx context.Context, evaluation *models.GuardrailsEvaluation, status *models.GuardrailsEvaluationStatus, message string, results *models.GuardrailsResults) error {
+	completeTime := r.now().UTC()
+	status.CompleteTime = &completeTime
+	status.Message = message
+	status.Results = results
+	status.State = models.GuardrailsStateComplete
+	if results == nil {
+		status.State = models.GuardrailsStateFailed
+	}
+
+	r.logger.Info("guardrails evaluation finished",
+		slog.String("evaluation", evaluation.Name),
+		slog.String("namespace", evaluation.Namespace),
+		slog.String("state", status.State))
+	return r.writeStatus(ctx, evaluation, status)
+}
+
+// writeStatus stores the status of an evaluation. Evaluations deleted while running are
+// reported as an error, which stops them.
+func (r *Runner) writeStatus(ctx context.Context, evaluation *models.GuardrailsEvaluation, status *models.GuardrailsEvaluationStatus) error {
+	configMap, err := r.client.GetConfigMap(ctx, evaluation.Namespace, ConfigMapName(evaluation.Name))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("evaluation was deleted while running")
+		}
+		return err
+	}
+	if err := SetStatus(configMap, status); err != nil {
+		return err
+	}
+	_, err = r.client.UpdateConfigMap(ctx, evaluation.Namespace, configMap)
+	return err
+}
+
+// tally counts the detections of an evaluation
+type tally struct {
+	detectors []string
+	prompts   int
+	errors    int
+	flagged   int
+
+	detections        map[string]int
+	flaggedByDetector map[string]int
+}
+
+func newTally(detectors []string, prompts int) *tally {
+	return &tally{
+		detectors:         detectors,
+		prompts:           prompts,
+		detections:        map[string]int{},
+		flaggedByDetector: map[string]int{},
+	}
+}
+
+// add counts the detections of one prompt. Detections that do not name their detector
+// are attributed to the only detector requested, if there is one.
+func (t *tally) add(detections []Detection) {
+	flaggedBy := map[string]bool{}
+	for _, detection := range detections {
+		detector := detection.DetectorID
+		if detector == "" && len(t.detectors) == 1 {
+			detector = t.detectors[0]
+		}
+		t.detections[detector]++
+		flaggedBy[detector] = true
+	}
+	for detector := range flaggedBy {
+		t.flaggedByDetector[detector]++
+	}
+	if len(detections) > 0 {
+		t.flagged++
+	}
+}
+
+func (t *tally) results() *models.GuardrailsResults {
+	results := &models.GuardrailsResults{
+		Prompts:        t.prompts,
+		Evaluated:      t.prompts - t.errors,
+		Errors:         t.errors,
+		FlaggedPrompts: t.flagged,
+		Detectors:      []models.DetectorResult{},
+	}
+	rate := func(count int) float64 {
+		if results.Evaluated == 0 {
+			return 0
+		}
+		return float64(count) / float64(results.Evaluated)
+	}
+	results.DetectionRate = rate(t.flagged)
+	for _, detector := range t.detectors {
+		results.Detectors = append(results.Detectors, models.DetectorResult{
+			Detector:       detector,
+			Detections:     t.detections[detector],
+			FlaggedPrompts: t.flaggedByDetector[detector],
+			DetectionRate:  rate(t.flaggedByDetector[detector]),
+		})
+	}
+	return results
+}
@@ -0,0 +1,244 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// keywordDetector flags prompts containing the name of a detector and fails on "error"
+type keywordDetector struct{}
+
+func (keywordDetector) Detect(_ context.Context, content string, detectors []string) ([]Detection, error) {
+	if strings.Contains(content, "error") {
+		return nil, errors.New("detector unavailable")
+	}
+	var detections []Detection
+	for _, detector := range detectors {
+		if strings.Contains(content, detector) {
+			detections = append(detections, Detection{DetectorID: detector}, Detection{DetectorID: detector})
+		}
+	}
+	return detections, nil
+}
+
+// hangingDetector never answers before its context ends
+type hangingDetector struct{}
+
+func (hangingDetector) Detect(ctx context.Context, _ string, _ []string) ([]Detection, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// denyingClient reports that no user may read configmaps
+type denyingClient struct {
+	kubernetes.KubernetesClientInterface
+}
+
+func (c denyingClient) CanSubjectAccess(ctx context.Context, subject *kubernetes.RequestIdentity, attributes authv1.ResourceAttributes) (bool, error) {
+	return attributes.Resource != "configmaps", nil
+}
+
+var testSigner = signing.New([]byte("0123456789abcdef0123456789abcdef"))
+
+// createTestEvaluation stores an evaluation as created by the API, run as test-user
+func createTestEvaluation(t *testing.T, client kubernetes.KubernetesClientInterface, evaluation *models.GuardrailsEvaluation) {
+	evaluation.Namespace = "project-1"
+	evaluation.Status.State = models.GuardrailsStatePending
+	evaluation.RunAs = models.RunAs{User: "test-user", Groups: []string{"system:authenticated"}}
+	assert.NoError(t, Sign(testSigner, evaluation))
+	configMap, err := ToConfigMap(evaluation)
+	assert.NoError(t, err)
+	_, err = client.CreateConfigMap(context.Background(), "project-1", configMap)
+	assert.NoError(t, err)
+}
+
+func getTestEvaluation(t *testing.T, client kubernetes.KubernetesClientInterface, name string) *models.GuardrailsEvaluation {
+	configMap, err := client.GetConfigMap(context.Background(), "project-1", ConfigMapName(name))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	evaluation, err := FromConfigMap(configMap)
+	assert.NoError(t, err)
+	return evaluation
+}
+
+func newTestRunner(client kubernetes.KubernetesClientInterface) *Runner {
+	r := NewRunner(client, testSigner, slog.Default(), time.Minute)
+	r.connect = func(models.GuardrailsOrchestrator) (Detector, error) { return keywordDetector{}, nil }
+	r.now = func() time.Time { return time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC) }
+	return r
+}
+
+func TestReconcileRunsPendingEvaluations(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "inline",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap", "injection"},
+		Prompts:      []string{"hap and injection", "hap only", "harmless", "error"},
+	})
+	_, err := client.CreateConfigMap(context.Background(), "project-1", &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prompts"},
+		Data:       map[string]string{"prompts.jsonl": `{"prompt": "injection"}` + "\nharmless\n"},
+	})
+	assert.NoError(t, err)
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "dataset",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"injection"},
+		Dataset:      &models.GuardrailsDataset{ConfigMap: "prompts", Key: "prompts.jsonl"},
+	})
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "unknown-orchestrator",
+		Orchestrator: "missing",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"hap"},
+	})
+
+	r := newTestRunner(client)
+	assert.NoError(t, r.Reconcile(context.Background()))
+
+	inline := getTestEvaluation(t, client, "inline")
+	assert.Equal(t, models.GuardrailsStateComplete, inline.Status.State)
+	assert.Contains(t, inline.Status.Message, "1 of 4 prompts could not be checked")
+	assert.Equal(t, &models.GuardrailsProgress{Completed: 4, Total: 4}, inline.Status.Progress)
+	assert.Equal(t, &models.GuardrailsResults{
+		Prompts:        4,
+		Evaluated:      3,
+		Errors:         1,
+		FlaggedPrompts: 2,
+		DetectionRate:  2.0 / 3,
+		Detectors: []models.DetectorResult{
+			{Detector: "hap", Detections: 4, FlaggedPrompts: 2, DetectionRate: 2.0 / 3},
+			{Detector: "injection", Detections: 2, FlaggedPrompts: 1, DetectionRate: 1.0 / 3},
+		},
+	}, inline.Status.Results)
+
+	dataset := getTestEvaluation(t, client, "dataset")
+	assert.Equal(t, models.GuardrailsStateComplete, dataset.Status.State)
+	assert.Empty(t, dataset.Status.Message)
+	assert.Equal(t, 0.5, dataset.Status.Results.DetectionRate)
+
+	unknown := getTestEvaluation(t, client, "unknown-orchestrator")
+	assert.Equal(t, models.GuardrailsStateFailed, unknown.Status.State)
+	assert.Equal(t, `guardrails orchestrator "missing" not found`, unknown.Status.Message)
+	assert.Nil(t, unknown.Status.Results)
+
+	// Finished evaluations are not run again
+	completeTime := inline.Status.CompleteTime
+	r.now = func() time.Time { return time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC) }
+	assert.NoError(t, r.Reconcile(context.Background()))
+	assert.Equal(t, completeTime, getTestEvaluation(t, client, "inline").Status.CompleteTime)
+}
+
+func TestRunFailsWhenNoPromptIsChecked(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "down",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"error", "another error"},
+	})
+
+	assert.NoError(t, newTestRunner(client).Reconcile(context.Background()))
+
+	evaluation := getTestEvaluation(t, client, "down")
+	assert.Equal(t, models.GuardrailsStateFailed, evaluation.Status.State)
+	assert.Equal(t, "no prompt could be checked: detector unavailable", evaluation.Status.Message)
+}
+
+func TestRunStopsAfterConsecutiveErrors(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "unreachable",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"hap", "error", "error", "error", "error", "error", "hap", "hap"},
+	})
+
+	assert.NoError(t, newTestRunner(client).Reconcile(context.Background()))
+
+	evaluation := getTestEvaluation(t, client, "unreachable")
+	assert.Equal(t, models.GuardrailsStateFailed, evaluation.Status.State)
+	assert.Equal(t, "stopped after 5 prompts in a row could not be checked: detector unavailable", evaluation.Status.Message)
+	assert.Equal(t, &models.GuardrailsProgress{Completed: 6, Total: 8}, evaluation.Status.Progress)
+}
+
+func TestRunFailsEvaluationsThatExceedTheTimeout(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "slow",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"hap", "hap"},
+	})
+
+	r := newTestRunner(client)
+	r.connect = func(models.GuardrailsOrchestrator) (Detector, error) { return hangingDetector{}, nil }
+	r.timeout = 50 * time.Millisecond
+	assert.NoError(t, r.Reconcile(context.Background()))
+
+	evaluation := getTestEvaluation(t, client, "slow")
+	assert.Equal(t, models.GuardrailsStateFailed, evaluation.Status.State)
+	assert.Equal(t, "the evaluation did not finish within 50ms, 0 of 2 prompts were checked", evaluation.Status.Message)
+	assert.NotNil(t, evaluation.Status.CompleteTime)
+}
+
+func TestRunRefusesUnsignedEvaluations(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	evaluation := &models.GuardrailsEvaluation{
+		Name:         "edited",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"hap"},
+	}
+	createTestEvaluation(t, client, evaluation)
+
+	// The ConfigMap is edited by hand to read another dataset
+	evaluation.Prompts = nil
+	evaluation.Dataset = &models.GuardrailsDataset{ConfigMap: "secret-prompts", Key: "prompts.txt"}
+	configMap, err := ToConfigMap(evaluation)
+	assert.NoError(t, err)
+	_, err = client.UpdateConfigMap(context.Background(), "project-1", configMap)
+	assert.NoError(t, err)
+
+	assert.NoError(t, newTestRunner(client).Reconcile(context.Background()))
+
+	edited := getTestEvaluation(t, client, "edited")
+	assert.Equal(t, models.GuardrailsStateFailed, edited.Status.State)
+	assert.Contains(t, edited.Status.Message, "was not created through the dashboard API")
+}
+
+func TestRunRefusesDatasetsTheUserMayNotRead(t *testing.T) {
+	client := denyingClient{kubernetes.NewMockKubernetesClient(slog.Default())}
+	_, err := client.CreateConfigMap(context.Background(), "project-1", &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prompts"},
+		Data:       map[string]string{"prompts.txt": "hap\n"},
+	})
+	assert.NoError(t, err)
+	createTestEvaluation(t, client, &models.GuardrailsEvaluation{
+		Name:         "dataset",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Dataset:      &models.GuardrailsDataset{ConfigMap: "prompts", Key: "prompts.txt"},
+	})
+
+	assert.NoError(t, newTestRunner(client).Reconcile(context.Background()))
+
+	evaluation := getTestEvaluation(t, client, "dataset")
+	assert.Equal(t, models.GuardrailsStateFailed, evaluation.Status.State)
+	assert.Equal(t, `test-user may not get configmaps "prompts"`, evaluation.Status.Message)
+	assert.Nil(t, evaluation.Status.Results)
+}
@@ -0,0 +1,251 @@
+// Package guardrails evaluates the detectors of TrustyAI guardrails orchestrators: it runs
+// a prompt dataset through an orchestrator and records how often each detector flags a
+// prompt. Evaluations are stored as ConfigMaps and run by the BFF itself.
+package guardrails
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	configMapPrefix = "guardrails-eval-"
+	specKey         = "evaluation.json"
+	statusKey       = "status.json"
+
+	// MaxPrompts bounds the prompts of an evaluation, which are stored with it
+	MaxPrompts = 1000
+)
+
+// evaluationSpec is the part of an evaluation stored as ConfigMap data, apart from its
+// status which the runner updates; the rest is carried by the ConfigMap's own metadata.
+type evaluationSpec struct {
+	Orchestrator string                    `json:"orchestrator"`
+	Detectors    []string                  `json:"detectors"`
+	Prompts      []string                  `json:"prompts,omitempty"`
+	Dataset      *models.GuardrailsDataset `json:"dataset,omitempty"`
+	RunAs        models.RunAs              `json:"runAs"`
+}
+
+// signedEvaluation is the part of an evaluation its signature covers: what runs, where and
+// as whom
+type signedEvaluation struct {
+	Namespace    string                    `json:"namespace"`
+	Name         string                    `json:"name"`
+	RunAs        models.RunAs              `json:"runAs"`
+	Orchestrator string                    `json:"orchestrator"`
+	Detectors    []string                  `json:"detectors"`
+	Prompts      []string                  `json:"prompts,omitempty"`
+	Dataset      *models.GuardrailsDataset `json:"dataset,omitempty"`
+}
+
+func signedFields(evaluation *models.GuardrailsEvaluation) signedEvaluation {
+	return signedEvaluation{
+		Namespace:    evaluation.Namespace,
+		Name:         evaluation.Name,
+		RunAs:        evaluation.RunAs,
+		Orchestrator: evaluation.Orchestrator,
+		Detectors:    evaluation.Detectors,
+		Prompts:      evaluation.Prompts,
+		Dataset:      evaluation.Dataset,
+	}
+}
+
+// Sign sets the signature of an evaluation created through the API
+func Sign(signer *signing.Signer, evaluation *models.GuardrailsEvaluation) error {
+	signature, err := signer.Sign(signedFields(evaluation))
+	if err != nil {
+		return err
+	}
+	evaluation.Signature = signature
+	return nil
+}
+
+// Verify checks that an evaluation read back from its ConfigMap was created through the API
+// and not written by hand, so that its RunAs can be trusted
+func Verify(signer *signing.Signer, evaluation *models.GuardrailsEvaluation) error {
+	if err := signer.Verify(signedFields(evaluation), evaluation.Signature); err != nil {
+		return fmt.Errorf("guardrails evaluation %s/%s was not created through the dashboard API: %w", evaluation.Namespace, evaluation.Name, err)
+	}
+	return nil
+}
+
+// ConfigMapName returns the name of the ConfigMap storing the named evaluation
+func ConfigMapName(name string) string {
+	return configMapPrefix + name
+}
+
+// LabelSelector selects the ConfigMaps storing guardrails evaluations
+func LabelSelector() string {
+	return constants.GuardrailsEvaluationLabel + "=true"
+}
+
+// Validate checks an evaluation before it is stored
+func Validate(evaluation *models.GuardrailsEvaluation) error {
+	if evaluation.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if errs := validation.IsDNS1123Label(ConfigMapName(evaluation.Name)); len(errs) > 0 {
+		return fmt.Errorf("invalid evaluation name %q: %s", evaluation.Name, strings.Join(errs, ", "))
+	}
+	if len(evaluation.Detectors) == 0 {
+		return fmt.Errorf("at least one detector is required")
+	}
+	for _, detector := range evaluation.Detectors {
+		if strings.TrimSpace(detector) == "" {
+			return fmt.Errorf("detector names must not be empty")
+		}
+	}
+	switch {
+	case len(evaluation.Prompts) == 0 && evaluation.Dataset == nil:
+		return fmt.Errorf("either prompts or a dataset is required")
+	case len(evaluation.Prompts) > 0 && evaluation.Dataset != nil:
+		return fmt.Errorf("prompts and dataset are mutually exclusive")
+	case len(evaluation.Prompts) > MaxPrompts:
+		return fmt.Errorf("an evaluation can have no more than %d prompts", MaxPrompts)
+	case evaluation.Dataset != nil && (evaluation.Dataset.ConfigMap == "" || evaluation.Dataset.Key == ""):
+		return fmt.Errorf("dataset.configMap and dataset.key are required")
+	}
+	if evaluation.Experiment != "" {
+		if errs := validation.IsValidLabelValue(evaluation.Experiment); len(errs) > 0 {
+			return fmt.Errorf("invalid experiment %q: %s", evaluation.Experiment, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// ParsePrompts reads the prompts of a dataset, one per line. Lines holding a JSON object
+// use its "prompt" or "text" field, so JSON Lines datasets work as well. Blank lines are
+// skipped.
+func ParsePrompts(data string) []string {
+	prompts := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			var record struct {
+				Prompt string `json:"prompt"`
+				Text   string `json:"text"`
+			}
+			if err := json.Unmarshal([]byte(line), &record); err == nil {
+				if record.Prompt != "" {
+					line = record.Prompt
+				} else if record.Text != "" {
+					line = record.Text
+				}
+			}
+		}
+		prompts = append(prompts, line)
+	}
+	return prompts
+}
+
+// ToConfigMap serialises an evaluation into the ConfigMap that stores it
+func ToConfigMap(evaluation *models.GuardrailsEvaluation) (*corev1.ConfigMap, error) {
+	spec, err := json.Marshal(evaluationSpec{
+		Orchestrator: evaluation.Orchestrator,
+		Detectors:    evaluation.Detectors,
+		Prompts:      evaluation.Prompts,
+		Dataset:      evaluation.Dataset,
+		RunAs:        evaluation.RunAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode guardrails evaluation: %w", err)
+	}
+	status, err := json.Marshal(evaluation.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode guardrails evaluation status: %w", err)
+	}
+
+	labels := map[string]string{
+		constants.GuardrailsEvaluationLabel: "true",
+	}
+	if evaluation.Experiment != "" {
+		labels[constants.ExperimentLabel] = evaluation.Experiment
+	}
+	annotations := map[string]string{
+		constants.CreatedByAnnotation: evaluation.CreatedBy,
+		constants.SignatureAnnotation: evaluation.Signature,
+	}
+	if evaluation.DisplayName != "" {
+		annotations[constants.DisplayNameAnnotation] = evaluation.DisplayName
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ConfigMapName(evaluation.Name),
+			ResourceVersion: evaluation.ResourceVersion,
+			Labels:          labels,
+			Annotations:     annotations,
+		},
+		Data: map[string]string{
+			specKey:   string(spec),
+			statusKey: string(status),
+		},
+	}, nil
+}
+
+// FromConfigMap reads an evaluation back from its ConfigMap
+func FromConfigMap(configMap *corev1.ConfigMap) (*models.GuardrailsEvaluation, error) {
+	if configMap.Labels[constants.GuardrailsEvaluationLabel] != "true" {
+		return nil, fmt.Errorf("configmap %q does not hold a guardrails evaluation", configMap.Name)
+	}
+
+	var spec evaluationSpec
+	if err := json.Unmarshal([]byte(configMap.Data[specKey]), &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode guardrails evaluation %q: %w", configMap.Name, err)
+	}
+
+	evaluation := &models.GuardrailsEvaluation{
+		Kind:              models.EvaluationKindGuardrails,
+		Name:              strings.TrimPrefix(configMap.Name, configMapPrefix),
+		Namespace:         configMap.Namespace,
+		DisplayName:       configMap.Annotations[constants.DisplayNameAnnotation],
+		Experiment:        configMap.Labels[constants.ExperimentLabel],
+		Orchestrator:      spec.Orchestrator,
+		Detectors:         spec.Detectors,
+		Prompts:           spec.Prompts,
+		Dataset:           spec.Dataset,
+		UID:               string(configMap.UID),
+		ResourceVersion:   configMap.ResourceVersion,
+		CreatedBy:         configMap.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp: configMap.CreationTimestamp.Time,
+		RunAs:             spec.RunAs,
+		Signature:         configMap.Annotations[constants.SignatureAnnotation],
+	}
+	if data := configMap.Data[statusKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &evaluation.Status); err != nil {
+			return nil, fmt.Errorf("failed to decode the status of guardrails evaluation %q: %w", configMap.Name, err)
+		}
+	}
+	if evaluation.Status.State == "" {
+		evaluation.Status.State = models.GuardrailsStatePending
+	}
+	return evaluation, nil
+}
+
+// SetStatus replaces the status stored in the ConfigMap of an evaluation
+func SetStatus(configMap *corev1.ConfigMap, status *models.GuardrailsEvaluationStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to encode guardrails evaluation status: %w", err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[statusKey] = string(data)
+	return nil
+}
@@ -0,0 +1,116 @@
+package guardrails
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	evaluation := &models.GuardrailsEvaluation{
+		Name:         "safety-check",
+		DisplayName:  "Safety check",
+		Experiment:   "q3-release",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap"},
+		Prompts:      []string{"hello"},
+		CreatedBy:    "test-user",
+		Status:       models.GuardrailsEvaluationStatus{State: models.GuardrailsStateRunning},
+	}
+
+	configMap, err := ToConfigMap(evaluation)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "guardrails-eval-safety-check", configMap.Name)
+
+	read, err := FromConfigMap(configMap)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, models.EvaluationKindGuardrails, read.Kind)
+	assert.Equal(t, "safety-check", read.Name)
+	assert.Equal(t, "Safety check", read.DisplayName)
+	assert.Equal(t, "q3-release", read.Experiment)
+	assert.Equal(t, []string{"hello"}, read.Prompts)
+	assert.Equal(t, models.GuardrailsStateRunning, read.Status.State)
+
+	configMap.Labels = nil
+	_, err = FromConfigMap(configMap)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *models.GuardrailsEvaluation {
+		return &models.GuardrailsEvaluation{Name: "safety-check", Detectors: []string{"hap"}, Prompts: []string{"hello"}}
+	}
+	assert.NoError(t, Validate(valid()))
+
+	dataset := valid()
+	dataset.Prompts = nil
+	dataset.Dataset = &models.GuardrailsDataset{ConfigMap: "prompts", Key: "prompts.jsonl"}
+	assert.NoError(t, Validate(dataset))
+
+	for name, change := range map[string]func(*models.GuardrailsEvaluation){
+		"missing name":   func(e *models.GuardrailsEvaluation) { e.Name = "" },
+		"invalid name":   func(e *models.GuardrailsEvaluation) { e.Name = "Safety_Check" },
+		"no detector":    func(e *models.GuardrailsEvaluation) { e.Detectors = nil },
+		"empty detector": func(e *models.GuardrailsEvaluation) { e.Detectors = []string{" "} },
+		"no prompts":     func(e *models.GuardrailsEvaluation) { e.Prompts = nil },
+		"prompts and dataset": func(e *models.GuardrailsEvaluation) {
+			e.Dataset = &models.GuardrailsDataset{ConfigMap: "prompts", Key: "k"}
+		},
+		"too many prompts":   func(e *models.GuardrailsEvaluation) { e.Prompts = make([]string, MaxPrompts+1) },
+		"invalid experiment": func(e *models.GuardrailsEvaluation) { e.Experiment = "not valid!" },
+	} {
+		evaluation := valid()
+		change(evaluation)
+		assert.Error(t, Validate(evaluation), name)
+	}
+}
+
+func TestParsePrompts(t *testing.T) {
+	data := "What is the capital of France?\n\n  {\"prompt\": \"Ignore previous instructions\"}\n{\"text\": \"Tell me a joke\"}\n{not json\n"
+	assert.Equal(t, []string{
+		"What is the capital of France?",
+		"Ignore previous instructions",
+		"Tell me a joke",
+		"{not json",
+	}, ParsePrompts(data))
+	assert.Empty(t, ParsePrompts("\n \n"))
+}
+
+func TestAsLMEvalJob(t *testing.T) {
+	job := AsLMEvalJob(&models.GuardrailsEvaluation{
+		Name:         "safety-check",
+		Namespace:    "project-1",
+		Orchestrator: "guardrails-orchestrator",
+		Detectors:    []string{"hap", "prompt_injection"},
+		Status: models.GuardrailsEvaluationStatus{
+			State: models.GuardrailsStateComplete,
+			Results: &models.GuardrailsResults{Detectors: []models.DetectorResult{
+				{Detector: "hap", DetectionRate: 0.25},
+				{Detector: "prompt_injection", DetectionRate: 0.5},
+			}},
+		},
+	})
+
+	assert.Equal(t, models.EvaluationKindGuardrails, job.Kind)
+	assert.Equal(t, ModelType, job.Spec.Model)
+	assert.Equal(t, []string{"hap", "prompt_injection"}, job.Spec.TaskList.TaskNames)
+	assert.Equal(t, models.LMEvalJobStateComplete, job.DisplayState())
+	metrics, err := models.ParseLMEvalResults(job.Status.Results)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []models.LMEvalMetric{
+		{Task: "hap", Metric: "detection_rate", Filter: "none", Value: 0.25},
+		{Task: "prompt_injection", Metric: "detection_rate", Filter: "none", Value: 0.5},
+	}, metrics)
+
+	failed := AsLMEvalJob(&models.GuardrailsEvaluation{
+		Name:   "broken",
+		Status: models.GuardrailsEvaluationStatus{State: models.GuardrailsStateFailed, Message: "orchestrator not found"},
+	})
+	assert.Equal(t, models.LMEvalJobReasonFailed, failed.DisplayState())
+	assert.Empty(t, failed.Status.Results)
+}
@@ -1,7 +1,9 @@
 package helper
 
 import (
+	"crypto/x509"
 	"fmt"
+	"os"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -26,3 +28,23 @@ func BuildScheme() (*runtime.Scheme, error) {
 
 	return scheme, nil
 }
+
+// ServiceCAFile is the CA that signs the serving certificates of OpenShift services
+var ServiceCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+// ServiceCertPool trusts the system roots and the service CA, or returns nil outside
+// a cluster that provides one
+func ServiceCertPool() *x509.CertPool {
+	ca, err := os.ReadFile(ServiceCAFile)
+	if err != nil {
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil
+	}
+	return pool
+}
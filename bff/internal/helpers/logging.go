@@ -78,6 +78,10 @@ func CloneBody(r *http.Request) ([]byte, error) {
 	return cloneBody, err
 }
 
+// maxLoggedBodyLength bounds the request bodies read for logging. Longer bodies and bodies
+// of unknown length, such as streamed uploads, are not logged.
+const maxLoggedBodyLength = 64 << 10
+
 type RequestLogValuer struct {
 	Request *http.Request
 }
@@ -85,7 +89,12 @@ type RequestLogValuer struct {
 func (r RequestLogValuer) LogValue() slog.Value {
 	body := ""
 
-	if r.Request.Body != nil {
+	if r.Request.Body != nil && (r.Request.ContentLength < 0 || r.Request.ContentLength > maxLoggedBodyLength) {
+		body = fmt.Sprintf("(%d bytes not logged)", r.Request.ContentLength)
+		if r.Request.ContentLength < 0 {
+			body = "(streamed body not logged)"
+		}
+	} else if r.Request.Body != nil {
 		cloneBody, err := CloneBody(r.Request)
 		if err != nil {
 			body = fmt.Sprintf("error: %v", err)
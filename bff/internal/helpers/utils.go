@@ -26,6 +26,16 @@ func GetEnvAsString(name string, defaultVal string) string {
 	return defaultVal
 }
 
+// GetEnvAsBool gets an environment variable as a boolean with a default value
+func GetEnvAsBool(name string, defaultVal bool) bool {
+	if value, exists := os.LookupEnv(name); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultVal
+}
+
 // ParseLevel parses log level from string
 func ParseLevel(s string) slog.Level {
 	var level slog.Level
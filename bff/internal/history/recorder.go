@@ -0,0 +1,159 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// DefaultResyncInterval is how often the watch is restarted from a full listing, which
+	// catches up with changes missed while the BFF was down
+	DefaultResyncInterval = 10 * time.Minute
+
+	// retryDelay is the pause before watching again after a watch ended or failed
+	retryDelay = 5 * time.Second
+)
+
+// Recorder feeds the history from a watch on the LMEvalJobs of every namespace
+type Recorder struct {
+	client   kubernetes.KubernetesClientInterface
+	store    *Store
+	logger   *slog.Logger
+	resync   time.Duration
+	identity *kubernetes.RequestIdentity
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewRecorder(client kubernetes.KubernetesClientInterface, store *Store, logger *slog.Logger, resync time.Duration) *Recorder {
+	return &Recorder{
+		client:   client,
+		store:    store,
+		logger:   logger.With(slog.String("component", "history-recorder")),
+		resync:   resync,
+		identity: kubernetes.BackgroundIdentity("history-recorder"),
+		now:      time.Now,
+	}
+}
+
+// Run records the changes of evaluations until ctx is cancelled
+func (r *Recorder) Run(ctx context.Context) {
+	r.logger.Info("starting history recorder", slog.Duration("resync", r.resync))
+
+	for {
+		if err := r.watch(ctx); err != nil {
+			r.logger.Error("failed to watch evaluations", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stopping history recorder")
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// watch records a full listing, then the changes reported by a watch until it ends or
+// the resync interval elapses. The watch starts first so no change is missed in between.
+func (r *Recorder) watch(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.resync)
+	defer cancel()
+
+	events, err := r.client.WatchLMEvalJobs(ctx, r.identity, "")
+	if err != nil {
+		return err
+	}
+	if err := r.Sync(ctx); err != nil {
+		return err
+	}
+	for event := range events {
+		r.apply(event)
+	}
+	return nil
+}
+
+// Sync records every evaluation in the cluster and marks the ones that are gone as deleted
+func (r *Recorder) Sync(ctx context.Context) error {
+	jobs, err := r.client.ListLMEvalJobs(ctx, r.identity, "")
+	if err != nil {
+		return err
+	}
+
+	live := map[string]bool{}
+	for i := range jobs.Items {
+		live[jobs.Items[i].Metadata.UID] = true
+		r.apply(kubernetes.LMEvalJobEvent{Type: watch.Modified, Job: jobs.Items[i]})
+	}
+	records, err := r.store.Query(Query{Live: true})
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if !live[record.UID] {
+			r.apply(kubernetes.LMEvalJobEvent{Type: watch.Deleted, Job: models.LMEvalJobKind{
+				Metadata: models.LMEvalJobMetadata{UID: record.UID, Name: record.Name, Namespace: record.Namespace},
+			}})
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) apply(event kubernetes.LMEvalJobEvent) {
+	job := &event.Job
+	if job.Metadata.UID == "" {
+		return
+	}
+
+	var err error
+	if event.Type == watch.Deleted {
+		err = r.store.MarkDeleted(job.Metadata.UID, r.now())
+	} else {
+		_, err = r.store.Record(NewRecord(job))
+	}
+	if err != nil {
+		r.logger.Error("failed to record evaluation",
+			slog.String("evaluation", job.Metadata.Name),
+			slog.String("namespace", job.Metadata.Namespace),
+			slog.Any("error", err))
+	}
+}
+
+// NewRecord captures the state of a job, parsing its results into metrics
+func NewRecord(job *models.LMEvalJobKind) models.EvaluationHistoryRecord {
+	record := models.EvaluationHistoryRecord{
+		UID:               job.Metadata.UID,
+		Name:              job.Metadata.Name,
+		Namespace:         job.Metadata.Namespace,
+		DisplayName:       job.Metadata.Annotations[constants.DisplayNameAnnotation],
+		Experiment:        job.Metadata.Labels[constants.ExperimentLabel],
+		Model:             job.ModelName(),
+		ModelType:         job.Spec.Model,
+		ModelEndpoint:     job.ModelEndpoint(),
+		Tasks:             job.Spec.TaskList.TaskNames,
+		State:             job.DisplayState(),
+		CreationTimestamp: job.Metadata.CreationTimestamp.UTC(),
+		Metrics:           []models.LMEvalMetric{},
+	}
+	if record.Tasks == nil {
+		record.Tasks = []string{}
+	}
+	if job.Status != nil {
+		if job.Status.CompleteTime != nil {
+			completeTime := job.Status.CompleteTime.UTC()
+			record.CompleteTime = &completeTime
+		}
+		// Unreadable results are left out, the evaluation is still recorded
+		if metrics, err := models.ParseLMEvalResults(job.Status.Results); err == nil && metrics != nil {
+			record.Metrics = metrics
+		}
+	}
+	return record
+}
@@ -0,0 +1,88 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func createJob(t *testing.T, client kubernetes.KubernetesClientInterface, name string) {
+	_, err := client.CreateLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: name},
+		Spec: models.LMEvalJobSpec{
+			Model:     "local-completions",
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: "llama2-7b-chat"}},
+			TaskList:  models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestRecorderFollowsEvaluations(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	identity := kubernetes.BackgroundIdentity("test")
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	// Evaluations created while the BFF was down are picked up by the first listing
+	createJob(t, client, "existing")
+	// A record of an evaluation deleted meanwhile
+	_, err = store.Record(newTestRecord("gone", "llama2-7b-chat", time.Now().Add(-time.Hour)))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder := NewRecorder(client, store, slog.Default(), time.Minute)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		recorder.Run(ctx)
+	}()
+
+	recordOf := func(name string) *models.EvaluationHistoryRecord {
+		records, err := store.Query(Query{})
+		assert.NoError(t, err)
+		for _, record := range records {
+			if record.Name == name {
+				return &record
+			}
+		}
+		return nil
+	}
+	assert.Eventually(t, func() bool {
+		gone := recordOf("eval-gone")
+		return recordOf("existing") != nil && gone != nil && gone.DeletedAt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Changes are followed through the watch
+	createJob(t, client, "watched")
+	_, err = client.PatchLMEvalJob(ctx, identity, "project-1", "watched",
+		[]byte(`{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8}}}"}}`))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		record := recordOf("watched")
+		return record != nil && record.State == models.LMEvalJobStateComplete && len(record.Metrics) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, client.DeleteLMEvalJob(ctx, identity, "project-1", "watched"))
+	assert.Eventually(t, func() bool {
+		record := recordOf("watched")
+		return record != nil && record.DeletedAt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	record := recordOf("watched")
+	assert.Equal(t, "llama2-7b-chat", record.Model)
+	assert.Equal(t, "local-completions", record.ModelType)
+
+	cancel()
+	<-done
+}
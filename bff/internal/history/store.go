@@ -0,0 +1,307 @@
+// Package history records the evaluations seen in the cluster into a file owned by the
+// BFF, so their results can be searched and charted after their LMEvalJob is deleted or
+// the operator is reinstalled.
+//
+// The store is a bbolt database: records are kept by UID, and indexed by namespace and
+// finish time, and by namespace, model and finish time, so queries of a namespace only
+// read its evaluations. Every change is committed and synced to disk before it returns.
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTimeout bounds the wait for the file lock, which another BFF may hold when replicas
+// share a volume. It is shortened in tests.
+var openTimeout = 10 * time.Second
+
+var (
+	recordsBucket = []byte("records")
+	// timeIndex keys are namespace, 0, finish time, UID; modelIndex keys are namespace, 0,
+	// lowercased model, 0, finish time, UID. Finish times sort the most recent first.
+	timeIndex  = []byte("by-namespace-time")
+	modelIndex = []byte("by-namespace-model-time")
+)
+
+// timeKeySize is the size of an encoded finish time
+const timeKeySize = 12
+
+// Store keeps the history of evaluations by UID
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the history in path, creating it when it does not exist
+func Open(path string) (*Store, error) {
+	if path == "" {
+		return nil, errors.New("the history requires a file")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the history directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o640, &bolt.Options{Timeout: openTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("failed to open the history: %s is used by another process, every replica needs its own file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the history: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{recordsBucket, timeIndex, modelIndex} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open the history: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores the state of an evaluation. It reports whether it differs from the
+// recorded one, unchanged records are not written again.
+func (s *Store) Record(record models.EvaluationHistoryRecord) (bool, error) {
+	if record.UID == "" {
+		return false, errors.New("history records require a UID")
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode history record: %w", err)
+	}
+
+	changed := false
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		existing := tx.Bucket(recordsBucket).Get([]byte(record.UID))
+		if bytes.Equal(existing, encoded) {
+			return nil
+		}
+		changed = true
+		if existing != nil {
+			var previous models.EvaluationHistoryRecord
+			if err := json.Unmarshal(existing, &previous); err == nil {
+				if err := deleteIndexes(tx, &previous); err != nil {
+					return err
+				}
+			}
+		}
+		return put(tx, &record, encoded)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to write history record: %w", err)
+	}
+	return changed, nil
+}
+
+// MarkDeleted records that the LMEvalJob of an evaluation is gone
+func (s *Store) MarkDeleted(uid string, at time.Time) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		existing := tx.Bucket(recordsBucket).Get([]byte(uid))
+		if existing == nil {
+			return nil
+		}
+		var record models.EvaluationHistoryRecord
+		if err := json.Unmarshal(existing, &record); err != nil {
+			return err
+		}
+		if record.DeletedAt != nil {
+			return nil
+		}
+
+		at = at.UTC()
+		record.DeletedAt = &at
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		// The indexed fields are unchanged, only the record is rewritten
+		return tx.Bucket(recordsBucket).Put([]byte(uid), encoded)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark history record deleted: %w", err)
+	}
+	return nil
+}
+
+func put(tx *bolt.Tx, record *models.EvaluationHistoryRecord, encoded []byte) error {
+	if err := tx.Bucket(recordsBucket).Put([]byte(record.UID), encoded); err != nil {
+		return err
+	}
+	if err := tx.Bucket(timeIndex).Put(timeIndexKey(record), nil); err != nil {
+		return err
+	}
+	return tx.Bucket(modelIndex).Put(modelIndexKey(record), nil)
+}
+
+func deleteIndexes(tx *bolt.Tx, record *models.EvaluationHistoryRecord) error {
+	if err := tx.Bucket(timeIndex).Delete(timeIndexKey(record)); err != nil {
+		return err
+	}
+	return tx.Bucket(modelIndex).Delete(modelIndexKey(record))
+}
+
+func namespacePrefix(namespace string) []byte {
+	return append([]byte(namespace), 0)
+}
+
+func modelPrefix(namespace, model string) []byte {
+	return append(append(namespacePrefix(namespace), strings.ToLower(model)...), 0)
+}
+
+func timeIndexKey(record *models.EvaluationHistoryRecord) []byte {
+	key := append(namespacePrefix(record.Namespace), encodeTime(record.FinishedAt())...)
+	return append(key, record.UID...)
+}
+
+func modelIndexKey(record *models.EvaluationHistoryRecord) []byte {
+	key := append(modelPrefix(record.Namespace, record.Model), encodeTime(record.FinishedAt())...)
+	return append(key, record.UID...)
+}
+
+// encodeTime encodes a time so that later times sort first
+func encodeTime(t time.Time) []byte {
+	key := make([]byte, timeKeySize)
+	binary.BigEndian.PutUint64(key, ^(uint64(t.Unix()) ^ 1<<63))
+	binary.BigEndian.PutUint32(key[8:], ^uint32(t.Nanosecond()))
+	return key
+}
+
+func decodeTime(key []byte) time.Time {
+	seconds := int64(^binary.BigEndian.Uint64(key) ^ 1<<63)
+	return time.Unix(seconds, int64(^binary.BigEndian.Uint32(key[8:]))).UTC()
+}
+
+// Query selects recorded evaluations. Empty fields match every evaluation.
+type Query struct {
+	Namespace string
+	Model     string
+	Task      string
+	// Metric matches a metric name, such as acc, or a task and metric, such as arc_easy.acc
+	Metric string
+	// Since and Until bound the time evaluations finished at, see EvaluationHistoryRecord.FinishedAt
+	Since time.Time
+	Until time.Time
+	// Live excludes the evaluations whose LMEvalJob was deleted
+	Live bool
+}
+
+func (q *Query) matches(record *models.EvaluationHistoryRecord) bool {
+	finishedAt := record.FinishedAt()
+	switch {
+	case q.Namespace != "" && record.Namespace != q.Namespace,
+		q.Model != "" && !strings.EqualFold(record.Model, q.Model),
+		q.Task != "" && !containsTask(record, q.Task),
+		q.Metric != "" && !containsMetric(record, q.Metric),
+		!q.Since.IsZero() && finishedAt.Before(q.Since),
+		!q.Until.IsZero() && !finishedAt.Before(q.Until),
+		q.Live && record.DeletedAt != nil:
+		return false
+	}
+	return true
+}
+
+func containsTask(record *models.EvaluationHistoryRecord, task string) bool {
+	for _, name := range record.Tasks {
+		if name == task {
+			return true
+		}
+	}
+	for _, metric := range record.Metrics {
+		if metric.Task == task {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMetric(record *models.EvaluationHistoryRecord, metric string) bool {
+	for _, m := range record.Metrics {
+		if m.Metric == metric || m.Key() == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns the matching evaluations, most recently finished first. Queries of a
+// namespace walk its index from Until back to Since, others read every record.
+func (s *Store) Query(q Query) ([]models.EvaluationHistoryRecord, error) {
+	records := []models.EvaluationHistoryRecord{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if q.Namespace == "" {
+			return tx.Bucket(recordsBucket).ForEach(func(_, value []byte) error {
+				var record models.EvaluationHistoryRecord
+				if err := json.Unmarshal(value, &record); err != nil {
+					return err
+				}
+				if q.matches(&record) {
+					records = append(records, record)
+				}
+				return nil
+			})
+		}
+
+		index, prefix := tx.Bucket(timeIndex), namespacePrefix(q.Namespace)
+		if q.Model != "" {
+			index, prefix = tx.Bucket(modelIndex), modelPrefix(q.Namespace, q.Model)
+		}
+		start := prefix
+		if !q.Until.IsZero() {
+			start = append(bytes.Clone(prefix), encodeTime(q.Until)...)
+		}
+
+		cursor := index.Cursor()
+		for key, _ := cursor.Seek(start); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+			rest := key[len(prefix):]
+			if len(rest) < timeKeySize {
+				continue
+			}
+			if !q.Since.IsZero() && decodeTime(rest).Before(q.Since) {
+				break
+			}
+			value := tx.Bucket(recordsBucket).Get(rest[timeKeySize:])
+			if value == nil {
+				continue
+			}
+			var record models.EvaluationHistoryRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if q.matches(&record) {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the history: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i].FinishedAt(), records[j].FinishedAt()
+		if a.Equal(b) {
+			return records[i].UID < records[j].UID
+		}
+		return a.After(b)
+	})
+	return records, nil
+}
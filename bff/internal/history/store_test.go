@@ -0,0 +1,164 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func newTestRecord(uid, model string, completed time.Time, metrics ...models.LMEvalMetric) models.EvaluationHistoryRecord {
+	return models.EvaluationHistoryRecord{
+		UID:               uid,
+		Name:              "eval-" + uid,
+		Namespace:         "project-1",
+		Model:             model,
+		ModelType:         "local-completions",
+		Tasks:             []string{"arc_easy"},
+		State:             models.LMEvalJobStateComplete,
+		CreationTimestamp: completed.Add(-time.Hour),
+		CompleteTime:      &completed,
+		Metrics:           metrics,
+	}
+}
+
+func TestStoreSurvivesReopening(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "history.db")
+	store, err := Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	completed := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := newTestRecord("a", "llama", completed)
+	changed, err := store.Record(record)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	// Unchanged records are not written again
+	changed, err = store.Record(record)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.NoError(t, store.MarkDeleted("a", completed.Add(24*time.Hour)))
+	assert.NoError(t, store.MarkDeleted("unknown", completed))
+	assert.NoError(t, store.Close())
+
+	store, err = Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+	records, err := store.Query(Query{})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "llama", records[0].Model)
+		assert.Equal(t, completed.Add(24*time.Hour), *records[0].DeletedAt)
+	}
+}
+
+func TestRecordReindexesChangedEvaluations(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	// A running evaluation is indexed by its creation time, then by its completion time
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := newTestRecord("a", "llama", created)
+	record.State = models.LMEvalJobStateRunning
+	record.CreationTimestamp = created
+	record.CompleteTime = nil
+	_, err = store.Record(record)
+	assert.NoError(t, err)
+
+	completed := created.AddDate(0, 1, 0)
+	record.State = models.LMEvalJobStateComplete
+	record.CompleteTime = &completed
+	_, err = store.Record(record)
+	assert.NoError(t, err)
+
+	for _, q := range []Query{
+		{Namespace: "project-1"},
+		{Namespace: "project-1", Model: "llama"},
+		{Namespace: "project-1", Since: completed},
+	} {
+		records, err := store.Query(q)
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1, "query %+v", q) {
+			assert.Equal(t, models.LMEvalJobStateComplete, records[0].State)
+		}
+	}
+	records, err := store.Query(Query{Namespace: "project-1", Until: completed})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestOpenFailsWhileTheFileIsInUse(t *testing.T) {
+	defer func(timeout time.Duration) { openTimeout = timeout }(openTimeout)
+	openTimeout = 100 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	_, err = Open(path)
+	assert.ErrorContains(t, err, "used by another process")
+}
+
+func TestQuery(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	january := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	acc := models.LMEvalMetric{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.7}
+	perplexity := models.LMEvalMetric{Task: "wikitext", Metric: "word_perplexity", Filter: "none", Value: 12}
+	for _, record := range []models.EvaluationHistoryRecord{
+		newTestRecord("jan", "llama", january, acc),
+		newTestRecord("feb", "Mistral", january.AddDate(0, 1, 0), acc, perplexity),
+		newTestRecord("mar", "llama", january.AddDate(0, 2, 0), perplexity),
+	} {
+		_, err := store.Record(record)
+		assert.NoError(t, err)
+	}
+	other := newTestRecord("other", "llama", january, acc)
+	other.Namespace = "project-2"
+	_, err = store.Record(other)
+	assert.NoError(t, err)
+	assert.NoError(t, store.MarkDeleted("jan", january.AddDate(0, 3, 0)))
+
+	uids := func(q Query) []string {
+		q.Namespace = "project-1"
+		records, err := store.Query(q)
+		assert.NoError(t, err)
+		var uids []string
+		for _, record := range records {
+			uids = append(uids, record.UID)
+		}
+		return uids
+	}
+
+	assert.Equal(t, []string{"mar", "feb", "jan"}, uids(Query{}))
+	assert.Equal(t, []string{"mar", "jan"}, uids(Query{Model: "llama"}))
+	assert.Equal(t, []string{"feb"}, uids(Query{Model: "mistral"}))
+	assert.Equal(t, []string{"mar", "feb"}, uids(Query{Task: "wikitext"}))
+	assert.Equal(t, []string{"feb", "jan"}, uids(Query{Metric: "acc"}))
+	assert.Equal(t, []string{"feb", "jan"}, uids(Query{Metric: "arc_easy.acc"}))
+	assert.Equal(t, []string{"feb"}, uids(Query{Since: january.AddDate(0, 0, 1), Until: january.AddDate(0, 2, 0)}))
+	assert.Equal(t, []string{"mar", "feb"}, uids(Query{Live: true}))
+	assert.Equal(t, []string{"jan"}, uids(Query{Model: "llama", Until: january.AddDate(0, 2, 0)}))
+
+	// Queries of every namespace read every record
+	all, err := store.Query(Query{Model: "llama", Until: january.AddDate(0, 0, 1)})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
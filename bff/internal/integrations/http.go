@@ -1,6 +1,7 @@
 package integrations
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -8,15 +9,22 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
 )
 
+// maxErrorMessageLength bounds the upstream error bodies kept as error messages
+const maxErrorMessageLength = 512
+
+// HTTPClientInterface calls an upstream REST API. Requests are cancelled with their ctx.
 type HTTPClientInterface interface {
-	GET(url string) ([]byte, error)
-	POST(url string, body io.Reader) ([]byte, error)
-	PATCH(url string, body io.Reader) ([]byte, error)
+	GET(ctx context.Context, url string) ([]byte, error)
+	POST(ctx context.Context, url string, body io.Reader) ([]byte, error)
+	PATCH(ctx context.Context, url string, body io.Reader) ([]byte, error)
+	DELETE(ctx context.Context, url string, body io.Reader) ([]byte, error)
 }
 
 type HTTPClient struct {
@@ -50,11 +58,22 @@ func NewHTTPClient(logger *slog.Logger, baseURL string) (HTTPClientInterface, er
 	}, nil
 }
 
-func (c *HTTPClient) GET(url string) ([]byte, error) {
+// NewHTTPClientWithTimeout returns a client whose requests fail once timeout elapsed, for
+// upstream APIs that may take long to answer
+func NewHTTPClientWithTimeout(logger *slog.Logger, baseURL string, timeout time.Duration) (HTTPClientInterface, error) {
+	client, err := NewHTTPClient(logger, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	client.(*HTTPClient).client.Timeout = timeout
+	return client, nil
+}
+
+func (c *HTTPClient) GET(ctx context.Context, url string) ([]byte, error) {
 	requestId := uuid.NewString()
 
 	fullURL := c.baseURL + url
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -72,15 +91,18 @@ func (c *HTTPClient) GET(url string) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newHTTPError(response.StatusCode, body)
+	}
 	return body, nil
 }
 
-func (c *HTTPClient) POST(url string, body io.Reader) ([]byte, error) {
+func (c *HTTPClient) POST(ctx context.Context, url string, body io.Reader) ([]byte, error) {
 	requestId := uuid.NewString()
 
 	fullURL := c.baseURL + url
-	fmt.Println(fullURL)
-	req, err := http.NewRequest("POST", fullURL, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -101,30 +123,17 @@ func (c *HTTPClient) POST(url string, body io.Reader) ([]byte, error) {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	if response.StatusCode != http.StatusCreated {
-		var errorResponse ErrorResponse
-		if err := json.Unmarshal(responseBody, &errorResponse); err != nil {
-			return nil, fmt.Errorf("error unmarshalling error response: %w", err)
-		}
-		httpError := &HTTPError{
-			StatusCode:    response.StatusCode,
-			ErrorResponse: errorResponse,
-		}
-		//Sometimes the code comes empty from model registry API
-		//also not all error codes are correctly implemented
-		//see https://github.com/kubeflow/model-registry/issues/95
-		if httpError.ErrorResponse.Code == "" {
-			httpError.ErrorResponse.Code = strconv.Itoa(response.StatusCode)
-		}
-		return nil, httpError
+	// Model registry answers 201, the TrustyAI service 200
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return nil, newHTTPError(response.StatusCode, responseBody)
 	}
 
 	return responseBody, nil
 }
 
-func (c *HTTPClient) PATCH(url string, body io.Reader) ([]byte, error) {
+func (c *HTTPClient) PATCH(ctx context.Context, url string, body io.Reader) ([]byte, error) {
 	fullURL := c.baseURL + url
-	req, err := http.NewRequest(http.MethodPatch, fullURL, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -148,25 +157,67 @@ func (c *HTTPClient) PATCH(url string, body io.Reader) ([]byte, error) {
 	}
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		if err := json.Unmarshal(responseBody, &errorResponse); err != nil {
-			return nil, fmt.Errorf("error unmarshalling error response: %w", err)
-		}
-		httpError := &HTTPError{
-			StatusCode:    response.StatusCode,
-			ErrorResponse: errorResponse,
-		}
-		//Sometimes the code comes empty from model registry API
-		//also not all error codes are correctly implemented
-		//see https://github.com/kubeflow/model-registry/issues/95
-		if httpError.ErrorResponse.Code == "" {
-			httpError.ErrorResponse.Code = strconv.Itoa(response.StatusCode)
-		}
-		return nil, httpError
+		return nil, newHTTPError(response.StatusCode, responseBody)
+	}
+	return responseBody, nil
+}
+
+func (c *HTTPClient) DELETE(ctx context.Context, url string, body io.Reader) ([]byte, error) {
+	fullURL := c.baseURL + url
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fullURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	requestId := uuid.NewString()
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logUpstreamReq(c.logger, requestId, req)
+
+	response, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	logUpstreamResp(c.logger, requestId, response, responseBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return nil, newHTTPError(response.StatusCode, responseBody)
 	}
 	return responseBody, nil
 }
 
+// newHTTPError reads the error response of an upstream API. Bodies that are not an
+// ErrorResponse, such as the plain text errors of the TrustyAI service, become the message.
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	var errorResponse ErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err != nil || errorResponse.Message == "" {
+		errorResponse.Message = strings.TrimSpace(string(body))
+		if len(errorResponse.Message) > maxErrorMessageLength {
+			errorResponse.Message = errorResponse.Message[:maxErrorMessageLength] + "..."
+		}
+	}
+	httpError := &HTTPError{
+		StatusCode:    statusCode,
+		ErrorResponse: errorResponse,
+	}
+	//Sometimes the code comes empty from model registry API
+	//also not all error codes are correctly implemented
+	//see https://github.com/kubeflow/model-registry/issues/95
+	if httpError.ErrorResponse.Code == "" {
+		httpError.ErrorResponse.Code = strconv.Itoa(statusCode)
+	}
+	return httpError
+}
+
 func logUpstreamReq(logger *slog.Logger, reqId string, req *http.Request) {
 	logger.Debug("Making upstream HTTP request", slog.String("request_id", reqId), slog.Any("request", helper.RequestLogValuer{Request: req}))
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	authv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -17,22 +19,69 @@ type KubernetesClientInterface interface {
 
 	// Model serving service discovery
 	GetModelServingServices(ctx context.Context, namespace string) ([]ServiceDetails, error)
+	GetGuardrailsOrchestrators(ctx context.Context, namespace string) ([]models.GuardrailsOrchestrator, error)
 
 	// Namespace access
 	GetNamespaces(ctx context.Context, identity *RequestIdentity) ([]corev1.Namespace, error)
+	// GetNamespace reads a namespace as the backend, e.g. for settings stored in its annotations
+	GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error)
 
 	// Permission checks (abstracted SAR/SelfSAR)
 	CanListServicesInNamespace(ctx context.Context, identity *RequestIdentity, namespace string) (bool, error)
 	CanAccessServiceInNamespace(ctx context.Context, identity *RequestIdentity, namespace, serviceName string) (bool, error)
+	// CanSubjectCreateLMEvalJobs checks with a SubjectAccessReview whether subject, who need not
+	// be the caller, may create LMEvalJobs in namespace. It authorizes work the backend does on
+	// a user's behalf outside of their request, such as scheduled runs.
+	CanSubjectCreateLMEvalJobs(ctx context.Context, subject *RequestIdentity, namespace string) (bool, error)
+	// CanSubjectAccess checks with a SubjectAccessReview whether subject may act on the
+	// resources described by attributes, for other work done on a user's behalf
+	CanSubjectAccess(ctx context.Context, subject *RequestIdentity, attributes authv1.ResourceAttributes) (bool, error)
 
 	// LMEvalJob CRUD operations
 	CreateLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace string, lmEvalJob *models.LMEvalJobKind) (*models.LMEvalJobKind, error)
 	GetLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string) (*models.LMEvalJobKind, error)
 	ListLMEvalJobs(ctx context.Context, identity *RequestIdentity, namespace string) (*models.LMEvalJobList, error)
 	DeleteLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string) error
+	// PatchLMEvalJob applies a JSON merge patch (RFC 7386) to an LMEvalJob
+	PatchLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string, patch []byte) (*models.LMEvalJobKind, error)
+	// WatchLMEvalJobs streams the changes of the LMEvalJobs of a namespace, of every namespace
+	// when it is empty. The channel is closed when ctx is cancelled or the watch ends.
+	WatchLMEvalJobs(ctx context.Context, identity *RequestIdentity, namespace string) (<-chan LMEvalJobEvent, error)
+
+	// ConfigMap storage used for BFF owned state such as evaluation templates
+	ListConfigMaps(ctx context.Context, namespace, labelSelector string) ([]corev1.ConfigMap, error)
+	GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error)
+	CreateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error)
+	UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error)
+	DeleteConfigMap(ctx context.Context, namespace, name string) error
+
+	// Secret storage for credentials the BFF owns, such as webhook signing keys
+	ListSecrets(ctx context.Context, namespace, labelSelector string) ([]corev1.Secret, error)
+	GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+	CreateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error)
+	UpdateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error)
+	DeleteSecret(ctx context.Context, namespace, name string) error
+
+	// Storage of custom evaluation datasets too large for a ConfigMap, on a PVC filled by a Job
+	CreatePersistentVolumeClaim(ctx context.Context, namespace string, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error)
+	DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error
+	CreateJob(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error)
+	GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error)
+	// DeleteJob deletes a Job together with its pods and the objects it owns
+	DeleteJob(ctx context.Context, namespace, name string) error
+
+	// Kubernetes Events recorded on the objects the BFF acts on
+	CreateEvent(ctx context.Context, namespace string, event *corev1.Event) error
+	ListEvents(ctx context.Context, namespace, involvedObjectName string) ([]corev1.Event, error)
+
+	// Logs of the pods running evaluations. tailLines limits the output to the last lines, 0 returns all of it.
+	GetPodLogs(ctx context.Context, namespace, podName string, tailLines int64) (string, error)
 
 	// Meta
 	IsClusterAdmin(identity *RequestIdentity) (bool, error)
 	BearerToken() (string, error)
 	GetUser(identity *RequestIdentity) (string, error)
+	// GetUserInfo returns the full user name and the groups behind identity, for the
+	// SubjectAccessReviews of work done later on the user's behalf
+	GetUserInfo(identity *RequestIdentity) (*RequestIdentity, error)
 }
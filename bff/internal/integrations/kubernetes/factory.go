@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
@@ -45,6 +46,9 @@ func NewKubernetesClientFactory(cfg config.EnvConfig, logger *slog.Logger) (Kube
 // If running locally (e.g. for development), it uses the current user's kubeconfig context.
 type KubernetesClientFactory interface {
 	GetClient(ctx context.Context) (KubernetesClientInterface, error)
+	// GetServiceAccountClient returns a client acting as the backend itself.
+	// It is used for state shared across users and for background work that has no request.
+	GetServiceAccountClient() (KubernetesClientInterface, error)
 	ExtractRequestIdentity(httpHeader http.Header) (*RequestIdentity, error)
 	ValidateRequestIdentity(identity *RequestIdentity) error
 }
@@ -69,6 +73,10 @@ func (f *StaticClientFactory) GetClient(_ context.Context) (KubernetesClientInte
 	return f.Client, nil
 }
 
+func (f *StaticClientFactory) GetServiceAccountClient() (KubernetesClientInterface, error) {
+	return f.Client, nil
+}
+
 func (f *StaticClientFactory) ExtractRequestIdentity(httpHeader http.Header) (*RequestIdentity, error) {
 
 	userID := httpHeader.Get(constants.KubeflowUserIDHeader)
@@ -114,6 +122,8 @@ type TokenClientFactory struct {
 	Logger *slog.Logger
 	Header string
 	Prefix string
+
+	serviceAccountClient lazyServiceAccountClient
 }
 
 func NewTokenClientFactory(logger *slog.Logger, cfg config.EnvConfig) KubernetesClientFactory {
@@ -170,6 +180,25 @@ func (f *TokenClientFactory) GetClient(ctx context.Context) (KubernetesClientInt
 	return newTokenKubernetesClient(identity.Token, f.Logger)
 }
 
+func (f *TokenClientFactory) GetServiceAccountClient() (KubernetesClientInterface, error) {
+	return f.serviceAccountClient.get(f.Logger)
+}
+
+// lazyServiceAccountClient creates the backend's own client on first use, so token based
+// deployments that never need it do not require service account credentials.
+type lazyServiceAccountClient struct {
+	once   sync.Once
+	client KubernetesClientInterface
+	err    error
+}
+
+func (l *lazyServiceAccountClient) get(logger *slog.Logger) (KubernetesClientInterface, error) {
+	l.once.Do(func() {
+		l.client, l.err = newInternalKubernetesClient(logger)
+	})
+	return l.client, l.err
+}
+
 //
 // ─── MOCK FACTORY (MOCK) ────────────────────────────────────────────────
 // uses a mock client for local development without requiring a Kubernetes cluster
@@ -192,6 +221,10 @@ func (f *MockClientFactory) GetClient(_ context.Context) (KubernetesClientInterf
 	return f.Client, nil
 }
 
+func (f *MockClientFactory) GetServiceAccountClient() (KubernetesClientInterface, error) {
+	return f.Client, nil
+}
+
 func (f *MockClientFactory) ExtractRequestIdentity(httpHeader http.Header) (*RequestIdentity, error) {
 	// In mock mode, we still require the kubeflow-userid header for consistency
 	userID := httpHeader.Get(constants.KubeflowUserIDHeader)
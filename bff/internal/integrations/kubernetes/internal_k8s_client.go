@@ -192,3 +192,7 @@ func (kc *InternalKubernetesClient) GetUser(identity *RequestIdentity) (string,
 	// On internal client, we can use the identity from request directly
 	return identity.UserID, nil
 }
+
+func (kc *InternalKubernetesClient) GetUserInfo(identity *RequestIdentity) (*RequestIdentity, error) {
+	return &RequestIdentity{UserID: identity.UserID, Groups: identity.Groups}, nil
+}
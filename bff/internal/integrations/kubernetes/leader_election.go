@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod's service account
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// InClusterNamespace returns the namespace the BFF runs in, or "" outside of a cluster
+func InClusterNamespace() string {
+	namespace, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(namespace))
+}
+
+// RunWithLeaderElection runs fn only while this replica holds the named Lease, so background
+// work such as the scheduler runs once even when the BFF is scaled out. fn's context is
+// cancelled when leadership is lost; the replica then competes for the Lease again until ctx ends.
+// The Lease is only released, or competed for again, once fn has returned, and so is
+// RunWithLeaderElection.
+func RunWithLeaderElection(ctx context.Context, logger *slog.Logger, namespace, leaseName string, fn func(ctx context.Context)) error {
+	kubeconfig, err := helper.GetKubeconfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to determine leader election identity: %w", err)
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: namespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	for ctx.Err() == nil {
+		runElection(ctx, logger, lock, leaseName, identity, fn)
+	}
+
+	return nil
+}
+
+// runElection campaigns for the Lease once and runs fn while it is held. The election
+// outlives ctx until fn has returned, so that the Lease is not released while fn still runs.
+func runElection(ctx context.Context, logger *slog.Logger, lock resourcelock.Interface, leaseName, identity string, fn func(ctx context.Context)) {
+	electionCtx, stopElection := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopElection()
+
+	var mu sync.Mutex
+	leading := false
+	done := make(chan struct{})
+
+	// Without leadership there is nothing to wait for when ctx ends
+	stopCampaign := context.AfterFunc(ctx, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if !leading {
+			stopElection()
+		}
+	})
+	defer stopCampaign()
+
+	leaderelection.RunOrDie(electionCtx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				// Ending the election once fn returns releases the Lease
+				defer stopElection()
+
+				mu.Lock()
+				if ctx.Err() != nil || leaderCtx.Err() != nil {
+					mu.Unlock()
+					return
+				}
+				leading = true
+				mu.Unlock()
+				defer close(done)
+
+				workCtx, cancel := context.WithCancel(leaderCtx)
+				defer cancel()
+				stopWork := context.AfterFunc(ctx, cancel)
+				defer stopWork()
+
+				logger.Info("acquired leadership", "lease", leaseName, "identity", identity)
+				fn(workCtx)
+			},
+			OnStoppedLeading: func() {
+				logger.Info("lost leadership", "lease", leaseName, "identity", identity)
+			},
+		},
+	})
+
+	// When renewing the Lease failed, fn is still stopping
+	mu.Lock()
+	defer mu.Unlock()
+	if leading {
+		<-done
+	}
+}
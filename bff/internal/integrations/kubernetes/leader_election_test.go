@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func TestRunElectionWaitsForFnBeforeReleasingTheLease(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: "test-lease", Namespace: "trustyai"},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: "replica-1"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var stopped atomic.Bool
+	var heldWhileStopping atomic.Bool
+	fn := func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		// Slow shutdown, the Lease must still be held
+		time.Sleep(100 * time.Millisecond)
+		lease, err := clientset.CoordinationV1().Leases("trustyai").Get(context.Background(), "test-lease", metav1.GetOptions{})
+		heldWhileStopping.Store(err == nil && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == "replica-1")
+		stopped.Store(true)
+	}
+
+	returned := make(chan struct{})
+	go func() {
+		runElection(ctx, slog.Default(), lock, "test-lease", "replica-1", fn)
+		close(returned)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership was not acquired")
+	}
+	cancel()
+
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the election did not end")
+	}
+	assert.True(t, stopped.Load(), "returned before fn did")
+	assert.True(t, heldWhileStopping.Load(), "released the Lease before fn returned")
+
+	lease, err := clientset.CoordinationV1().Leases("trustyai").Get(context.Background(), "test-lease", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, *lease.Spec.HolderIdentity)
+}
+
+func TestRunElectionStopsCampaigningWhenCancelled(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	newLock := func(identity string) *resourcelock.LeaseLock {
+		return &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Name: "test-lease", Namespace: "trustyai"},
+			Client:     clientset.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		}
+	}
+
+	// replica-1 holds the Lease, so replica-2 keeps campaigning until it is cancelled
+	leaderCtx, stopLeader := context.WithCancel(context.Background())
+	defer stopLeader()
+	leading := make(chan struct{})
+	go runElection(leaderCtx, slog.Default(), newLock("replica-1"), "test-lease", "replica-1", func(ctx context.Context) {
+		close(leading)
+		<-ctx.Done()
+	})
+	<-leading
+
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan struct{})
+	go func() {
+		runElection(ctx, slog.Default(), newLock("replica-2"), "test-lease", "replica-2", func(ctx context.Context) {
+			t.Error("replica-2 should not lead")
+		})
+		close(returned)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the campaign did not stop")
+	}
+}
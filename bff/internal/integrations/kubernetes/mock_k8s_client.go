@@ -2,27 +2,70 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	authv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // MockKubernetesClient provides a mock implementation of KubernetesClientInterface
 // for local development without requiring a real Kubernetes cluster
 type MockKubernetesClient struct {
 	Logger *slog.Logger
+
+	// In-memory state for resources the BFF writes itself
+	mu              sync.Mutex
+	configMaps      map[string]map[string]corev1.ConfigMap
+	secrets         map[string]map[string]corev1.Secret
+	pvcs            map[string]map[string]corev1.PersistentVolumeClaim
+	jobs            map[string]map[string]batchv1.Job
+	events          []corev1.Event
+	lmEvalJobs      map[string]map[string]models.LMEvalJobKind
+	resourceVersion int
+	watchers        map[*mockWatcher]struct{}
+}
+
+// mockWatcher receives the LMEvalJob changes of a namespace, of every namespace when empty
+type mockWatcher struct {
+	namespace string
+	events    chan LMEvalJobEvent
 }
 
 // NewMockKubernetesClient creates a new mock Kubernetes client
 func NewMockKubernetesClient(logger *slog.Logger) KubernetesClientInterface {
 	return &MockKubernetesClient{
-		Logger: logger,
+		Logger:     logger,
+		configMaps: map[string]map[string]corev1.ConfigMap{},
+		secrets:    map[string]map[string]corev1.Secret{},
+		pvcs:       map[string]map[string]corev1.PersistentVolumeClaim{},
+		jobs:       map[string]map[string]batchv1.Job{},
+		lmEvalJobs: map[string]map[string]models.LMEvalJobKind{},
+		watchers:   map[*mockWatcher]struct{}{},
 	}
 }
 
+// nextResourceVersion must be called with m.mu held
+func (m *MockKubernetesClient) nextResourceVersion() string {
+	m.resourceVersion++
+	return strconv.Itoa(m.resourceVersion)
+}
+
 func (m *MockKubernetesClient) GetServiceNames(ctx context.Context, namespace string) ([]string, error) {
 	// Return mock service names
 	return []string{"trustyai-service", "model-registry-service"}, nil
@@ -118,9 +161,25 @@ func (m *MockKubernetesClient) GetModelServingServices(ctx context.Context, name
 	return []ServiceDetails{}, nil
 }
 
-func (m *MockKubernetesClient) GetNamespaces(ctx context.Context, identity *RequestIdentity) ([]corev1.Namespace, error) {
-	// Return mock namespaces that the user has access to
-	mockNamespaces := []corev1.Namespace{
+func (m *MockKubernetesClient) GetGuardrailsOrchestrators(ctx context.Context, namespace string) ([]models.GuardrailsOrchestrator, error) {
+	// project-1 has a guardrails orchestrator, like the one deployed with the model
+	if namespace != "project-1" {
+		return []models.GuardrailsOrchestrator{}, nil
+	}
+	return []models.GuardrailsOrchestrator{
+		{
+			Name:      "guardrails-orchestrator",
+			Namespace: namespace,
+			Service:   "guardrails-orchestrator-service",
+			URL:       "https://guardrails-orchestrator-service.project-1.svc:8032",
+		},
+	}, nil
+}
+
+// mockNamespaces are the namespaces of the mock cluster. The data science project
+// prunes evaluations that finished more than 30 days ago.
+func mockNamespaces() []corev1.Namespace {
+	return []corev1.Namespace{
 		{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "project-1",
@@ -143,22 +202,34 @@ func (m *MockKubernetesClient) GetNamespaces(ctx context.Context, identity *Requ
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "ds-project-3",
 				Annotations: map[string]string{
-					"openshift.io/display-name": "Data Science Project",
-					"openshift.io/description":  "Data science project with model evaluations",
+					"openshift.io/display-name":       "Data Science Project",
+					"openshift.io/description":        "Data science project with model evaluations",
+					constants.RetentionDaysAnnotation: "30",
 				},
 			},
 		},
 	}
+}
 
+func (m *MockKubernetesClient) GetNamespaces(ctx context.Context, identity *RequestIdentity) ([]corev1.Namespace, error) {
 	// Filter namespaces based on user identity (simulate RBAC)
 	if identity != nil && identity.UserID != "" {
 		// In mock mode, allow access to all namespaces for any authenticated user
-		return mockNamespaces, nil
+		return mockNamespaces(), nil
 	}
 
 	return []corev1.Namespace{}, nil
 }
 
+func (m *MockKubernetesClient) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	for _, namespace := range mockNamespaces() {
+		if namespace.Name == name {
+			return &namespace, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, name)
+}
+
 func (m *MockKubernetesClient) CanListServicesInNamespace(ctx context.Context, identity *RequestIdentity, namespace string) (bool, error) {
 	// In mock mode, allow all operations
 	return true, nil
@@ -169,16 +240,46 @@ func (m *MockKubernetesClient) CanAccessServiceInNamespace(ctx context.Context,
 	return true, nil
 }
 
+func (m *MockKubernetesClient) CanSubjectCreateLMEvalJobs(ctx context.Context, subject *RequestIdentity, namespace string) (bool, error) {
+	// In mock mode, allow all operations
+	return true, nil
+}
+
+func (m *MockKubernetesClient) CanSubjectAccess(ctx context.Context, subject *RequestIdentity, attributes authv1.ResourceAttributes) (bool, error) {
+	// In mock mode, allow all operations
+	return true, nil
+}
+
 func (m *MockKubernetesClient) CreateLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace string, lmEvalJob *models.LMEvalJobKind) (*models.LMEvalJobKind, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.lmEvalJobs[namespace][lmEvalJob.Metadata.Name]; exists {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Group: "trustyai.opendatahub.io", Resource: "lmevaljobs"}, lmEvalJob.Metadata.Name)
+	}
+
 	// Create a mock LMEvalJob with some default values
 	createdLMEvalJob := *lmEvalJob
+	createdLMEvalJob.Metadata.Namespace = namespace
+	createdLMEvalJob.Metadata.UID = uuid.NewString()
 	createdLMEvalJob.Metadata.CreationTimestamp = time.Now()
+	createdLMEvalJob.Metadata.ResourceVersion = m.nextResourceVersion()
 	createdLMEvalJob.Status = &models.LMEvalJobStatus{
 		State:   "Pending",
 		Message: "Mock evaluation job created successfully",
 		Reason:  "EvaluationPending",
 	}
 
+	// Keep created jobs so they show up in later list calls
+	if m.lmEvalJobs[namespace] == nil {
+		m.lmEvalJobs[namespace] = map[string]models.LMEvalJobKind{}
+	}
+	if createdLMEvalJob.Spec.Suspend {
+		createdLMEvalJob.Status.State = models.LMEvalJobStateSuspended
+	}
+	m.lmEvalJobs[namespace][createdLMEvalJob.Metadata.Name] = createdLMEvalJob
+	m.notifyWatchers(watch.Added, createdLMEvalJob)
+
 	m.Logger.Info("Mock: Created LMEvalJob",
 		"name", lmEvalJob.Metadata.Name,
 		"namespace", namespace,
@@ -188,6 +289,13 @@ func (m *MockKubernetesClient) CreateLMEvalJob(ctx context.Context, identity *Re
 }
 
 func (m *MockKubernetesClient) GetLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string) (*models.LMEvalJobKind, error) {
+	m.mu.Lock()
+	stored, exists := m.lmEvalJobs[namespace][name]
+	m.mu.Unlock()
+	if exists {
+		return &stored, nil
+	}
+
 	// Return a simple mock LMEvalJob for now
 	mockLMEvalJob := &models.LMEvalJobKind{
 		APIVersion: "trustyai.opendatahub.io/v1alpha1",
@@ -225,14 +333,25 @@ func (m *MockKubernetesClient) GetLMEvalJob(ctx context.Context, identity *Reque
 }
 
 func (m *MockKubernetesClient) ListLMEvalJobs(ctx context.Context, identity *RequestIdentity, namespace string) (*models.LMEvalJobList, error) {
-	// Return empty list for now
+	m.mu.Lock()
+	items := []models.LMEvalJobKind{}
+	for jobNamespace, jobs := range m.lmEvalJobs {
+		if namespace != "" && jobNamespace != namespace {
+			continue
+		}
+		for _, job := range jobs {
+			items = append(items, job)
+		}
+	}
+	m.mu.Unlock()
+
 	mockList := &models.LMEvalJobList{
 		APIVersion: "trustyai.opendatahub.io/v1alpha1",
 		Kind:       "LMEvalJobList",
 		Metadata: models.ListMetadata{
 			ResourceVersion: "1",
 		},
-		Items: []models.LMEvalJobKind{},
+		Items: items,
 	}
 
 	m.Logger.Info("Mock: Listed LMEvalJobs",
@@ -377,6 +496,13 @@ func (m *MockKubernetesClient) ListLMEvals(ctx context.Context, identity *Reques
 }
 
 func (m *MockKubernetesClient) DeleteLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string) error {
+	m.mu.Lock()
+	if existing, exists := m.lmEvalJobs[namespace][name]; exists {
+		delete(m.lmEvalJobs[namespace], name)
+		m.notifyWatchers(watch.Deleted, existing)
+	}
+	m.mu.Unlock()
+
 	m.Logger.Info("Mock: Deleted LMEvalJob",
 		"name", name,
 		"namespace", namespace,
@@ -384,6 +510,118 @@ func (m *MockKubernetesClient) DeleteLMEvalJob(ctx context.Context, identity *Re
 	return nil
 }
 
+func (m *MockKubernetesClient) WatchLMEvalJobs(ctx context.Context, identity *RequestIdentity, namespace string) (<-chan LMEvalJobEvent, error) {
+	watcher := &mockWatcher{namespace: namespace, events: make(chan LMEvalJobEvent, 100)}
+	m.mu.Lock()
+	m.watchers[watcher] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		m.stopWatcher(watcher)
+		m.mu.Unlock()
+	}()
+	return watcher.events, nil
+}
+
+// notifyWatchers must be called with m.mu held. Like the API server, it ends the watches
+// of consumers too slow to keep up.
+func (m *MockKubernetesClient) notifyWatchers(eventType watch.EventType, lmEvalJob models.LMEvalJobKind) {
+	for watcher := range m.watchers {
+		if watcher.namespace != "" && watcher.namespace != lmEvalJob.Metadata.Namespace {
+			continue
+		}
+		select {
+		case watcher.events <- LMEvalJobEvent{Type: eventType, Job: lmEvalJob}:
+		default:
+			m.stopWatcher(watcher)
+		}
+	}
+}
+
+// stopWatcher must be called with m.mu held
+func (m *MockKubernetesClient) stopWatcher(watcher *mockWatcher) {
+	if _, exists := m.watchers[watcher]; exists {
+		delete(m.watchers, watcher)
+		close(watcher.events)
+	}
+}
+
+func (m *MockKubernetesClient) PatchLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string, patch []byte) (*models.LMEvalJobKind, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.lmEvalJobs[namespace][name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "trustyai.opendatahub.io", Resource: "lmevaljobs"}, name)
+	}
+
+	var patchObj map[string]any
+	if err := json.Unmarshal(patch, &patchObj); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid merge patch: %v", err))
+	}
+
+	// Like the API server, a resourceVersion in the patch is a precondition
+	if metadata, ok := patchObj["metadata"].(map[string]any); ok {
+		if version, ok := metadata["resourceVersion"].(string); ok && version != existing.Metadata.ResourceVersion {
+			return nil, apierrors.NewConflict(schema.GroupResource{Group: "trustyai.opendatahub.io", Resource: "lmevaljobs"}, name,
+				fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+		}
+	}
+
+	// Round-trip through JSON so the patch applies the same way as on the API server
+	data, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	var target map[string]any
+	if err := json.Unmarshal(data, &target); err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(mergePatch(target, patchObj))
+	if err != nil {
+		return nil, err
+	}
+
+	var patched models.LMEvalJobKind
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid merge patch: %v", err))
+	}
+	patched.Metadata.ResourceVersion = m.nextResourceVersion()
+	if patched.Status != nil && patched.Status.State == models.LMEvalJobStateSuspended && !patched.Spec.Suspend {
+		patched.Status.State = "Pending"
+	}
+	m.lmEvalJobs[namespace][name] = patched
+	m.notifyWatchers(watch.Modified, patched)
+
+	m.Logger.Info("Mock: Patched LMEvalJob",
+		"name", name,
+		"namespace", namespace,
+		"user", identity.UserID)
+	return &patched, nil
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target
+func mergePatch(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = map[string]any{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		if patchValue, ok := value.(map[string]any); ok {
+			targetValue, _ := target[key].(map[string]any)
+			target[key] = mergePatch(targetValue, patchValue)
+			continue
+		}
+		target[key] = value
+	}
+	return target
+}
+
 func (m *MockKubernetesClient) DeleteLMEval(ctx context.Context, identity *RequestIdentity, namespace, name string) error {
 	// Mock successful deletion
 	m.Logger.Info("Mock: Deleted LMEval",
@@ -413,3 +651,327 @@ func (m *MockKubernetesClient) GetUser(identity *RequestIdentity) (string, error
 	}
 	return "mock-user", nil
 }
+
+func (m *MockKubernetesClient) GetUserInfo(identity *RequestIdentity) (*RequestIdentity, error) {
+	user, _ := m.GetUser(identity)
+	info := &RequestIdentity{UserID: user}
+	if identity != nil {
+		info.Groups = identity.Groups
+	}
+	return info, nil
+}
+
+func (m *MockKubernetesClient) ListConfigMaps(ctx context.Context, namespace, labelSelector string) ([]corev1.ConfigMap, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// An empty namespace lists across all namespaces, as with the real API
+	items := []corev1.ConfigMap{}
+	for configMapNamespace, configMaps := range m.configMaps {
+		if namespace != "" && configMapNamespace != namespace {
+			continue
+		}
+		for _, configMap := range configMaps {
+			if selector.Matches(labels.Set(configMap.Labels)) {
+				items = append(items, *configMap.DeepCopy())
+			}
+		}
+	}
+	return items, nil
+}
+
+func (m *MockKubernetesClient) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	configMap, exists := m.configMaps[namespace][name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	return configMap.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) CreateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.configMaps[namespace][configMap.Name]; exists {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	if m.configMaps[namespace] == nil {
+		m.configMaps[namespace] = map[string]corev1.ConfigMap{}
+	}
+
+	created := configMap.DeepCopy()
+	created.Namespace = namespace
+	created.CreationTimestamp = metav1.Now()
+	created.ResourceVersion = m.nextResourceVersion()
+	m.configMaps[namespace][created.Name] = *created
+
+	m.Logger.Info("Mock: Created ConfigMap", "name", created.Name, "namespace", namespace)
+	return created.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.configMaps[namespace][configMap.Name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	if configMap.ResourceVersion != "" && configMap.ResourceVersion != existing.ResourceVersion {
+		return nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, configMap.Name, nil)
+	}
+
+	updated := configMap.DeepCopy()
+	updated.Namespace = namespace
+	updated.CreationTimestamp = existing.CreationTimestamp
+	updated.ResourceVersion = m.nextResourceVersion()
+	m.configMaps[namespace][updated.Name] = *updated
+
+	m.Logger.Info("Mock: Updated ConfigMap", "name", updated.Name, "namespace", namespace)
+	return updated.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.configMaps[namespace][name]; !exists {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	delete(m.configMaps[namespace], name)
+
+	m.Logger.Info("Mock: Deleted ConfigMap", "name", name, "namespace", namespace)
+	return nil
+}
+
+func (m *MockKubernetesClient) ListSecrets(ctx context.Context, namespace, labelSelector string) ([]corev1.Secret, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := []corev1.Secret{}
+	for secretNamespace, secrets := range m.secrets {
+		if namespace != "" && secretNamespace != namespace {
+			continue
+		}
+		for _, secret := range secrets {
+			if selector.Matches(labels.Set(secret.Labels)) {
+				items = append(items, *secret.DeepCopy())
+			}
+		}
+	}
+	return items, nil
+}
+
+func (m *MockKubernetesClient) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	secret, exists := m.secrets[namespace][name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) CreateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.secrets[namespace][secret.Name]; exists {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, secret.Name)
+	}
+	if m.secrets[namespace] == nil {
+		m.secrets[namespace] = map[string]corev1.Secret{}
+	}
+
+	created := secret.DeepCopy()
+	created.Namespace = namespace
+	created.CreationTimestamp = metav1.Now()
+	created.ResourceVersion = m.nextResourceVersion()
+	m.secrets[namespace][created.Name] = *created
+
+	m.Logger.Info("Mock: Created Secret", "name", created.Name, "namespace", namespace)
+	return created.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) UpdateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.secrets[namespace][secret.Name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, secret.Name)
+	}
+	if secret.ResourceVersion != "" && secret.ResourceVersion != existing.ResourceVersion {
+		return nil, apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, secret.Name, nil)
+	}
+
+	updated := secret.DeepCopy()
+	updated.Namespace = namespace
+	updated.CreationTimestamp = existing.CreationTimestamp
+	updated.ResourceVersion = m.nextResourceVersion()
+	m.secrets[namespace][updated.Name] = *updated
+
+	m.Logger.Info("Mock: Updated Secret", "name", updated.Name, "namespace", namespace)
+	return updated.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) DeleteSecret(ctx context.Context, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.secrets[namespace][name]; !exists {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	delete(m.secrets[namespace], name)
+
+	m.Logger.Info("Mock: Deleted Secret", "name", name, "namespace", namespace)
+	return nil
+}
+
+func (m *MockKubernetesClient) CreatePersistentVolumeClaim(ctx context.Context, namespace string, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.pvcs[namespace][pvc.Name]; exists {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "persistentvolumeclaims"}, pvc.Name)
+	}
+	if m.pvcs[namespace] == nil {
+		m.pvcs[namespace] = map[string]corev1.PersistentVolumeClaim{}
+	}
+
+	created := pvc.DeepCopy()
+	created.Namespace = namespace
+	created.UID = types.UID(uuid.NewString())
+	created.CreationTimestamp = metav1.Now()
+	created.ResourceVersion = m.nextResourceVersion()
+	m.pvcs[namespace][created.Name] = *created
+
+	m.Logger.Info("Mock: Created PersistentVolumeClaim", "name", created.Name, "namespace", namespace)
+	return created.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.pvcs[namespace][name]; !exists {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, name)
+	}
+	delete(m.pvcs[namespace], name)
+
+	m.Logger.Info("Mock: Deleted PersistentVolumeClaim", "name", name, "namespace", namespace)
+	return nil
+}
+
+// CreateJob stores a Job that completes at once, there are no pods to run it
+func (m *MockKubernetesClient) CreateJob(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.jobs[namespace][job.Name]; exists {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Group: "batch", Resource: "jobs"}, job.Name)
+	}
+	if m.jobs[namespace] == nil {
+		m.jobs[namespace] = map[string]batchv1.Job{}
+	}
+
+	created := job.DeepCopy()
+	created.Namespace = namespace
+	created.UID = types.UID(uuid.NewString())
+	created.CreationTimestamp = metav1.Now()
+	created.ResourceVersion = m.nextResourceVersion()
+	created.Status.Succeeded = 1
+	created.Status.CompletionTime = &created.CreationTimestamp
+	m.jobs[namespace][created.Name] = *created
+
+	m.Logger.Info("Mock: Created Job", "name", created.Name, "namespace", namespace)
+	return created.DeepCopy(), nil
+}
+
+func (m *MockKubernetesClient) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, exists := m.jobs[namespace][name]
+	if !exists {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, name)
+	}
+	return job.DeepCopy(), nil
+}
+
+// DeleteJob also deletes the ConfigMaps the Job owns, as the garbage collector would
+func (m *MockKubernetesClient) DeleteJob(ctx context.Context, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, exists := m.jobs[namespace][name]
+	if !exists {
+		return apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, name)
+	}
+	delete(m.jobs[namespace], name)
+	for configMapName, configMap := range m.configMaps[namespace] {
+		for _, owner := range configMap.OwnerReferences {
+			if owner.UID == job.UID {
+				delete(m.configMaps[namespace], configMapName)
+			}
+		}
+	}
+
+	m.Logger.Info("Mock: Deleted Job", "name", name, "namespace", namespace)
+	return nil
+}
+
+func (m *MockKubernetesClient) CreateEvent(ctx context.Context, namespace string, event *corev1.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	created := event.DeepCopy()
+	created.Namespace = namespace
+	created.ResourceVersion = m.nextResourceVersion()
+	m.events = append(m.events, *created)
+
+	m.Logger.Info("Mock: Recorded Event", "object", event.InvolvedObject.Name, "namespace", namespace, "reason", event.Reason)
+	return nil
+}
+
+func (m *MockKubernetesClient) ListEvents(ctx context.Context, namespace, involvedObjectName string) ([]corev1.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := []corev1.Event{}
+	for _, event := range m.events {
+		if event.Namespace == namespace && event.InvolvedObject.Name == involvedObjectName {
+			items = append(items, *event.DeepCopy())
+		}
+	}
+	return items, nil
+}
+
+func (m *MockKubernetesClient) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int64) (string, error) {
+	lines := []string{
+		"INFO Starting lm-evaluation-harness for " + podName,
+		"INFO Building contexts for tasks",
+		"INFO Running loglikelihood requests",
+		"INFO Evaluation finished, writing results",
+	}
+	if tailLines > 0 && int(tailLines) < len(lines) {
+		lines = lines[len(lines)-int(tailLines):]
+	}
+
+	m.Logger.Info("Mock: Retrieved pod logs", "pod", podName, "namespace", namespace)
+	return strings.Join(lines, "\n") + "\n", nil
+}
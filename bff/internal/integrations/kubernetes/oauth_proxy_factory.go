@@ -18,6 +18,8 @@ import (
 type OAuthProxyClientFactory struct {
 	Logger      *slog.Logger
 	TokenHeader string
+
+	serviceAccountClient lazyServiceAccountClient
 }
 
 func NewOAuthProxyClientFactory(logger *slog.Logger, cfg config.EnvConfig) KubernetesClientFactory {
@@ -63,3 +65,7 @@ func (f *OAuthProxyClientFactory) GetClient(ctx context.Context) (KubernetesClie
 
 	return newTokenKubernetesClient(identity.Token, f.Logger)
 }
+
+func (f *OAuthProxyClientFactory) GetServiceAccountClient() (KubernetesClientInterface, error) {
+	return f.serviceAccountClient.get(f.Logger)
+}
@@ -9,11 +9,15 @@ import (
 
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
 	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	authv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -108,6 +112,82 @@ func (kc *SharedClientLogic) GetModelServingServices(sessionCtx context.Context,
 	return modelServices, nil
 }
 
+// GetGuardrailsOrchestrators discovers the guardrails orchestrators of a namespace from the
+// services the TrustyAI operator creates for GuardrailsOrchestrator resources. Services are
+// only trusted when they are controlled by one of the namespace's GuardrailsOrchestrators,
+// matched by UID, since anyone who can create a service could claim to be one.
+func (kc *SharedClientLogic) GetGuardrailsOrchestrators(sessionCtx context.Context, namespace string) ([]models.GuardrailsOrchestrator, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{
+		BearerToken: kc.Token.Raw(),
+		Host:        kc.Client.CoreV1().RESTClient().Get().URL().Host,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: true, // For development - should be configurable
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    "trustyai.opendatahub.io",
+		Version:  "v1alpha1",
+		Resource: "guardrailsorchestrators",
+	}
+	resources, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guardrails orchestrators: %w", err)
+	}
+	owners := map[types.UID]string{}
+	for _, resource := range resources.Items {
+		owners[resource.GetUID()] = resource.GetName()
+	}
+	if len(owners) == 0 {
+		return []models.GuardrailsOrchestrator{}, nil
+	}
+
+	serviceList, err := kc.Client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	orchestrators := []models.GuardrailsOrchestrator{}
+	for _, service := range serviceList.Items {
+		if orchestrator, ok := buildGuardrailsOrchestrator(&service, owners); ok {
+			orchestrators = append(orchestrators, orchestrator)
+		}
+	}
+	return orchestrators, nil
+}
+
+// buildGuardrailsOrchestrator reads the orchestrator API of a service controlled by one of
+// owners, GuardrailsOrchestrator names by UID. It is served over HTTPS unless TLS is
+// disabled on the orchestrator.
+func buildGuardrailsOrchestrator(service *corev1.Service, owners map[types.UID]string) (models.GuardrailsOrchestrator, bool) {
+	owner := metav1.GetControllerOf(service)
+	if owner == nil || owner.Kind != "GuardrailsOrchestrator" || owners[owner.UID] != owner.Name {
+		return models.GuardrailsOrchestrator{}, false
+	}
+	for _, scheme := range []string{"https", "http"} {
+		for _, port := range service.Spec.Ports {
+			if port.Name == scheme {
+				return models.GuardrailsOrchestrator{
+					Name:      owner.Name,
+					Namespace: service.Namespace,
+					Service:   service.Name,
+					URL:       fmt.Sprintf("%s://%s.%s.svc:%d", scheme, service.Name, service.Namespace, port.Port),
+				}, true
+			}
+		}
+	}
+	return models.GuardrailsOrchestrator{}, false
+}
+
 // isModelServingService checks if a service is a model serving service
 func isModelServingService(service *corev1.Service) bool {
 	if service == nil {
@@ -341,6 +421,34 @@ func (kc *SharedClientLogic) BearerToken() (string, error) {
 	return kc.Token.Raw(), nil
 }
 
+func (kc *SharedClientLogic) CanSubjectCreateLMEvalJobs(ctx context.Context, subject *RequestIdentity, namespace string) (bool, error) {
+	return kc.CanSubjectAccess(ctx, subject, authv1.ResourceAttributes{
+		Verb:      "create",
+		Group:     "trustyai.opendatahub.io",
+		Resource:  "lmevaljobs",
+		Namespace: namespace,
+	})
+}
+
+func (kc *SharedClientLogic) CanSubjectAccess(ctx context.Context, subject *RequestIdentity, attributes authv1.ResourceAttributes) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:               subject.UserID,
+			Groups:             subject.Groups,
+			ResourceAttributes: &attributes,
+		},
+	}
+
+	response, err := kc.Client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("SAR failed: %w", err)
+	}
+	return response.Status.Allowed, nil
+}
+
 // LMEvalJob CRUD operations
 func (kc *SharedClientLogic) CreateLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace string, lmEvalJob *models.LMEvalJobKind) (*models.LMEvalJobKind, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -571,6 +679,104 @@ func (kc *SharedClientLogic) DeleteLMEvalJob(ctx context.Context, identity *Requ
 	return nil
 }
 
+func (kc *SharedClientLogic) PatchLMEvalJob(ctx context.Context, identity *RequestIdentity, namespace, name string, patch []byte) (*models.LMEvalJobKind, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Create dynamic client for custom resources
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{
+		BearerToken: kc.Token.Raw(),
+		Host:        kc.Client.CoreV1().RESTClient().Get().URL().Host,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: true, // For development - should be configurable
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	// Define the GVR for LMEvalJob
+	gvr := schema.GroupVersionResource{
+		Group:    "trustyai.opendatahub.io",
+		Version:  "v1alpha1",
+		Resource: "lmevaljobs",
+	}
+
+	// Patch the resource
+	result, err := dynamicClient.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch LMEvalJob: %w", err)
+	}
+
+	var patchedLMEvalJob models.LMEvalJobKind
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(result.UnstructuredContent(), &patchedLMEvalJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert patched resource from unstructured: %w", err)
+	}
+
+	return &patchedLMEvalJob, nil
+}
+
+// WatchLMEvalJobs streams the changes to the LMEvalJobs of a namespace, or of every namespace
+// when it is empty. The channel is closed when ctx is cancelled or the API server ends the
+// watch, callers list again and start a new watch to catch up.
+func (kc *SharedClientLogic) WatchLMEvalJobs(ctx context.Context, identity *RequestIdentity, namespace string) (<-chan LMEvalJobEvent, error) {
+	// Create dynamic client for custom resources
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{
+		BearerToken: kc.Token.Raw(),
+		Host:        kc.Client.CoreV1().RESTClient().Get().URL().Host,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: true, // For development - should be configurable
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	// Define the GVR for LMEvalJob
+	gvr := schema.GroupVersionResource{
+		Group:    "trustyai.opendatahub.io",
+		Version:  "v1alpha1",
+		Resource: "lmevaljobs",
+	}
+
+	// The watch lasts until ctx is cancelled or the API server ends it, so no timeout is set
+	watcher, err := dynamicClient.Resource(gvr).Namespace(namespace).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch LMEvalJobs: %w", err)
+	}
+
+	events := make(chan LMEvalJobEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok || event.Type == watch.Error {
+					return
+				}
+				item, ok := event.Object.(*unstructured.Unstructured)
+				if !ok || event.Type == watch.Bookmark {
+					continue
+				}
+				var lmEvalJob models.LMEvalJobKind
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &lmEvalJob); err != nil {
+					continue
+				}
+				select {
+				case events <- LMEvalJobEvent{Type: event.Type, Job: lmEvalJob}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
 // Helper function to convert unstructured list to LMEvalJobList
 func convertUnstructuredListToLMEvalJobList(unstructuredList *unstructured.UnstructuredList) (*models.LMEvalJobList, error) {
 	var lmEvalJobList models.LMEvalJobList
@@ -593,3 +799,243 @@ func convertUnstructuredListToLMEvalJobList(unstructuredList *unstructured.Unstr
 
 	return &lmEvalJobList, nil
 }
+
+func (kc *SharedClientLogic) ListConfigMaps(ctx context.Context, namespace, labelSelector string) ([]corev1.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	configMapList, err := kc.Client.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps in namespace %s: %w", namespace, err)
+	}
+
+	return configMapList.Items, nil
+}
+
+func (kc *SharedClientLogic) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	namespace, err := kc.Client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", name, err)
+	}
+
+	return namespace, nil
+}
+
+func (kc *SharedClientLogic) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	configMap, err := kc.Client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return configMap, nil
+}
+
+func (kc *SharedClientLogic) CreateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	created, err := kc.Client.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create configmap %q in namespace %q: %w", configMap.Name, namespace, err)
+	}
+
+	return created, nil
+}
+
+func (kc *SharedClientLogic) UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	updated, err := kc.Client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update configmap %q in namespace %q: %w", configMap.Name, namespace, err)
+	}
+
+	return updated, nil
+}
+
+func (kc *SharedClientLogic) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := kc.Client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete configmap %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return nil
+}
+
+func (kc *SharedClientLogic) ListSecrets(ctx context.Context, namespace, labelSelector string) ([]corev1.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	secretList, err := kc.Client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in namespace %s: %w", namespace, err)
+	}
+
+	return secretList.Items, nil
+}
+
+func (kc *SharedClientLogic) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	secret, err := kc.Client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return secret, nil
+}
+
+func (kc *SharedClientLogic) CreateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	created, err := kc.Client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret %q in namespace %q: %w", secret.Name, namespace, err)
+	}
+
+	return created, nil
+}
+
+func (kc *SharedClientLogic) UpdateSecret(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	updated, err := kc.Client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update secret %q in namespace %q: %w", secret.Name, namespace, err)
+	}
+
+	return updated, nil
+}
+
+func (kc *SharedClientLogic) DeleteSecret(ctx context.Context, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := kc.Client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete secret %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return nil
+}
+
+func (kc *SharedClientLogic) CreatePersistentVolumeClaim(ctx context.Context, namespace string, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	created, err := kc.Client.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create persistentvolumeclaim %q in namespace %q: %w", pvc.Name, namespace, err)
+	}
+
+	return created, nil
+}
+
+func (kc *SharedClientLogic) DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	err := kc.Client.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete persistentvolumeclaim %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return nil
+}
+
+func (kc *SharedClientLogic) CreateJob(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	created, err := kc.Client.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job %q in namespace %q: %w", job.Name, namespace, err)
+	}
+
+	return created, nil
+}
+
+func (kc *SharedClientLogic) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	job, err := kc.Client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return job, nil
+}
+
+func (kc *SharedClientLogic) DeleteJob(ctx context.Context, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Jobs are deleted without their pods by default
+	propagation := metav1.DeletePropagationBackground
+	err := kc.Client.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil {
+		return fmt.Errorf("failed to delete job %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return nil
+}
+
+func (kc *SharedClientLogic) CreateEvent(ctx context.Context, namespace string, event *corev1.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := kc.Client.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create event for %q in namespace %q: %w", event.InvolvedObject.Name, namespace, err)
+	}
+
+	return nil
+}
+
+func (kc *SharedClientLogic) ListEvents(ctx context.Context, namespace, involvedObjectName string) ([]corev1.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	eventList, err := kc.Client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.name=" + involvedObjectName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %q in namespace %q: %w", involvedObjectName, namespace, err)
+	}
+
+	return eventList.Items, nil
+}
+
+func (kc *SharedClientLogic) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int64) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	options := &corev1.PodLogOptions{}
+	if tailLines > 0 {
+		options.TailLines = &tailLines
+	}
+	logs, err := kc.Client.CoreV1().Pods(namespace).GetLogs(podName, options).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of pod %q in namespace %q: %w", podName, namespace, err)
+	}
+
+	return string(logs), nil
+}
@@ -201,3 +201,25 @@ func (kc *TokenKubernetesClient) GetUser(_ *RequestIdentity) (string, error) {
 
 	return username, nil
 }
+
+func (kc *TokenKubernetesClient) GetUserInfo(_ *RequestIdentity) (*RequestIdentity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ssr := &authnv1.SelfSubjectReview{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SelfSubjectReview",
+			APIVersion: "authentication.k8s.io/v1",
+		},
+	}
+
+	resp, err := kc.Client.AuthenticationV1().SelfSubjectReviews().Create(ctx, ssr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+	if resp.Status.UserInfo.Username == "" {
+		return nil, fmt.Errorf("no username found in token")
+	}
+
+	return &RequestIdentity{UserID: resp.Status.UserInfo.Username, Groups: resp.Status.UserInfo.Groups}, nil
+}
@@ -1,5 +1,10 @@
 package kubernetes
 
+import (
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
 type ServiceDetails struct {
 	Name        string
 	DisplayName string
@@ -8,6 +13,12 @@ type ServiceDetails struct {
 	HTTPPort    int32
 }
 
+// LMEvalJobEvent is a change of an LMEvalJob: watch.Added, watch.Modified or watch.Deleted
+type LMEvalJobEvent struct {
+	Type watch.EventType
+	Job  models.LMEvalJobKind
+}
+
 type RequestIdentity struct {
 	UserID string
 	Groups []string
@@ -29,3 +40,9 @@ func (t BearerToken) String() string {
 func (t BearerToken) Raw() string {
 	return t.raw
 }
+
+// BackgroundIdentity is the identity used for calls the backend makes on its own behalf,
+// such as the scheduler creating evaluations, where there is no user request.
+func BackgroundIdentity(component string) *RequestIdentity {
+	return &RequestIdentity{UserID: "system:trustyai-dashboard:" + component}
+}
@@ -0,0 +1,160 @@
+// Package leaderboard ranks models per task and metric from completed LMEvalJobs.
+package leaderboard
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// z95 is the two-sided 95% quantile of the standard normal distribution
+const z95 = 1.959963984540054
+
+// Options selects what goes on the leaderboard
+type Options struct {
+	// Tasks restricts the boards to these tasks, all tasks when empty
+	Tasks []string
+	// Selection is models.LeaderboardSelectionLatest or models.LeaderboardSelectionBest
+	Selection string
+}
+
+type boardKey struct {
+	task, metric, filter string
+}
+
+// Build ranks models on every task metric reported by the successfully completed jobs.
+// Each model appears once per board, with its latest or best run depending on the selection.
+func Build(namespace string, jobs []models.LMEvalJobKind, opts Options) *models.Leaderboard {
+	selection := opts.Selection
+	if selection == "" {
+		selection = models.LeaderboardSelectionLatest
+	}
+
+	tasks := map[string]bool{}
+	for _, task := range opts.Tasks {
+		tasks[task] = true
+	}
+
+	candidates := map[boardKey]map[string]models.LeaderboardEntry{}
+	for i := range jobs {
+		job := &jobs[i]
+		if !job.Status.IsFinished() || job.Status.IsFailed() {
+			continue
+		}
+		metrics, err := models.ParseLMEvalResults(job.Status.Results)
+		if err != nil {
+			continue
+		}
+
+		model := job.ModelName()
+		completed := completeTime(job)
+		for _, metric := range metrics {
+			if len(tasks) > 0 && !tasks[metric.Task] {
+				continue
+			}
+
+			key := boardKey{task: metric.Task, metric: metric.Metric, filter: metric.Filter}
+			entry := newEntry(job, model, completed, metric)
+			if candidates[key] == nil {
+				candidates[key] = map[string]models.LeaderboardEntry{}
+			}
+			current, exists := candidates[key][model]
+			if !exists || replaces(entry, current, selection, models.MetricHigherIsBetter(metric.Metric)) {
+				candidates[key][model] = entry
+			}
+		}
+	}
+
+	leaderboard := &models.Leaderboard{
+		Namespace: namespace,
+		Selection: selection,
+		Boards:    []models.LeaderboardBoard{},
+	}
+	for key, entries := range candidates {
+		board := models.LeaderboardBoard{
+			Task:           key.task,
+			Metric:         key.metric,
+			Filter:         key.filter,
+			HigherIsBetter: models.MetricHigherIsBetter(key.metric),
+		}
+		for _, entry := range entries {
+			board.Entries = append(board.Entries, entry)
+		}
+		rank(&board)
+		leaderboard.Boards = append(leaderboard.Boards, board)
+	}
+
+	sort.Slice(leaderboard.Boards, func(i, j int) bool {
+		a, b := leaderboard.Boards[i], leaderboard.Boards[j]
+		return strings.Join([]string{a.Task, a.Metric, a.Filter}, "\x00") < strings.Join([]string{b.Task, b.Metric, b.Filter}, "\x00")
+	})
+	return leaderboard
+}
+
+func newEntry(job *models.LMEvalJobKind, model string, completed time.Time, metric models.LMEvalMetric) models.LeaderboardEntry {
+	entry := models.LeaderboardEntry{
+		Model:      model,
+		Evaluation: job.Metadata.Name,
+		Value:      metric.Value,
+		StdErr:     metric.StdErr,
+	}
+	if !completed.IsZero() {
+		entry.CompleteTime = &completed
+	}
+	if metric.StdErr != nil {
+		lower := metric.Value - z95**metric.StdErr
+		upper := metric.Value + z95**metric.StdErr
+		entry.CILower = &lower
+		entry.CIUpper = &upper
+	}
+	return entry
+}
+
+// replaces reports whether candidate should replace the model's current entry on a board
+func replaces(candidate, current models.LeaderboardEntry, selection string, higherIsBetter bool) bool {
+	if selection == models.LeaderboardSelectionBest && candidate.Value != current.Value {
+		return better(candidate.Value, current.Value, higherIsBetter)
+	}
+	return entryTime(candidate).After(entryTime(current))
+}
+
+// rank orders entries best first. Models with equal scores share a rank.
+func rank(board *models.LeaderboardBoard) {
+	sort.Slice(board.Entries, func(i, j int) bool {
+		a, b := board.Entries[i], board.Entries[j]
+		if a.Value != b.Value {
+			return better(a.Value, b.Value, board.HigherIsBetter)
+		}
+		return a.Model < b.Model
+	})
+	for i := range board.Entries {
+		if i > 0 && board.Entries[i].Value == board.Entries[i-1].Value {
+			board.Entries[i].Rank = board.Entries[i-1].Rank
+			continue
+		}
+		board.Entries[i].Rank = i + 1
+	}
+}
+
+func better(a, b float64, higherIsBetter bool) bool {
+	if higherIsBetter {
+		return a > b
+	}
+	return a < b
+}
+
+func completeTime(job *models.LMEvalJobKind) time.Time {
+	if job.Status != nil && job.Status.CompleteTime != nil {
+		return *job.Status.CompleteTime
+	}
+	return job.Metadata.CreationTimestamp
+}
+
+func entryTime(entry models.LeaderboardEntry) time.Time {
+	if entry.CompleteTime == nil {
+		return time.Time{}
+	}
+	return *entry.CompleteTime
+}
@@ -0,0 +1,92 @@
+package leaderboard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+var baseTime = time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+func completedJob(name, model string, hoursAgo int, results string) models.LMEvalJobKind {
+	completed := baseTime.Add(-time.Duration(hoursAgo) * time.Hour)
+	return models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: name, Namespace: "project-1"},
+		Spec: models.LMEvalJobSpec{
+			Model:     "local-completions",
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: model}},
+		},
+		Status: &models.LMEvalJobStatus{
+			State:        models.LMEvalJobStateComplete,
+			CompleteTime: &completed,
+			Results:      results,
+		},
+	}
+}
+
+func arcResults(acc, stderr float64) string {
+	return fmt.Sprintf(`{"results":{"arc_easy":{"acc,none":%v,"acc_stderr,none":%v},"wikitext":{"word_perplexity,none":%v}}}`, acc, stderr, 20-acc*10)
+}
+
+func testJobs() []models.LMEvalJobKind {
+	failed := completedJob("mistral-failed", "mistral-7b", 0, arcResults(0.99, 0.01))
+	failed.Status.Reason = models.LMEvalJobReasonFailed
+
+	return []models.LMEvalJobKind{
+		completedJob("llama-old", "llama-2-7b", 5, arcResults(0.80, 0.01)),
+		completedJob("llama-new", "llama-2-7b", 1, arcResults(0.75, 0.01)),
+		completedJob("mistral", "mistral-7b", 2, arcResults(0.78, 0.02)),
+		failed,
+	}
+}
+
+func TestBuildLatest(t *testing.T) {
+	board := Build("project-1", testJobs(), Options{Tasks: []string{"arc_easy"}})
+
+	assert.Equal(t, models.LeaderboardSelectionLatest, board.Selection)
+	assert.Len(t, board.Boards, 1)
+
+	arc := board.Boards[0]
+	assert.Equal(t, "arc_easy", arc.Task)
+	assert.True(t, arc.HigherIsBetter)
+	assert.Len(t, arc.Entries, 2)
+
+	// The latest llama run is used, so mistral ranks first; failed runs are ignored
+	assert.Equal(t, "mistral-7b", arc.Entries[0].Model)
+	assert.Equal(t, "mistral", arc.Entries[0].Evaluation)
+	assert.Equal(t, 1, arc.Entries[0].Rank)
+	assert.Equal(t, "llama-new", arc.Entries[1].Evaluation)
+	assert.Equal(t, 2, arc.Entries[1].Rank)
+
+	assert.InDelta(t, 0.78-1.96*0.02, *arc.Entries[0].CILower, 1e-3)
+	assert.InDelta(t, 0.78+1.96*0.02, *arc.Entries[0].CIUpper, 1e-3)
+}
+
+func TestBuildBest(t *testing.T) {
+	board := Build("project-1", testJobs(), Options{Selection: models.LeaderboardSelectionBest})
+	assert.Len(t, board.Boards, 2)
+
+	arc := board.Boards[0]
+	assert.Equal(t, "llama-old", arc.Entries[0].Evaluation)
+	assert.Equal(t, 0.80, arc.Entries[0].Value)
+
+	// Perplexity ranks lower values first
+	perplexity := board.Boards[1]
+	assert.Equal(t, "word_perplexity", perplexity.Metric)
+	assert.False(t, perplexity.HigherIsBetter)
+	assert.Equal(t, "llama-old", perplexity.Entries[0].Evaluation)
+}
+
+func TestBuildSharesRankOnTies(t *testing.T) {
+	jobs := []models.LMEvalJobKind{
+		completedJob("a", "model-a", 1, arcResults(0.7, 0.01)),
+		completedJob("b", "model-b", 1, arcResults(0.7, 0.01)),
+		completedJob("c", "model-c", 1, arcResults(0.6, 0.01)),
+	}
+	arc := Build("project-1", jobs, Options{Tasks: []string{"arc_easy"}}).Boards[0]
+
+	assert.Equal(t, []int{1, 1, 3}, []int{arc.Entries[0].Rank, arc.Entries[1].Rank, arc.Entries[2].Rank})
+}
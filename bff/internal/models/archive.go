@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Archive triggers, the event that made the archive snapshot an evaluation
+const (
+	ArchiveTriggerCompleted = "completed"
+	ArchiveTriggerDeleted   = "deleted"
+)
+
+// ArchivedEvaluationSummary describes an archived evaluation in listings
+type ArchivedEvaluationSummary struct {
+	// ID identifies the archived evaluation within its namespace. Names can be reused
+	// once an evaluation is deleted, so it is derived from the name and the UID.
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace"`
+	DisplayName       string     `json:"displayName,omitempty"`
+	Model             string     `json:"model"`
+	Tasks             []string   `json:"tasks"`
+	State             string     `json:"state"`
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	CompleteTime      *time.Time `json:"completeTime,omitempty"`
+	ArchivedAt        time.Time  `json:"archivedAt"`
+	Trigger           string     `json:"trigger"`
+}
+
+// ArchivedEvaluation is a snapshot of an evaluation that outlives its LMEvalJob: the
+// job with its spec and status, and the metrics parsed from its results
+type ArchivedEvaluation struct {
+	ArchivedEvaluationSummary
+	Job     LMEvalJobKind  `json:"job"`
+	Metrics []LMEvalMetric `json:"metrics"`
+}
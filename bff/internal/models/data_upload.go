@@ -0,0 +1,28 @@
+package models
+
+// Formats of the inference data uploaded to the TrustyAI service
+const (
+	DataUploadFormatCSV  = "csv"
+	DataUploadFormatJSON = "json"
+)
+
+// DataUpload describes inferences of a model uploaded to the TrustyAI service, e.g. the
+// training data drift metrics compare new inferences with
+type DataUpload struct {
+	ModelID string `json:"modelId"`
+	// Tag is the tag the inferences are stored with, see DataTagRequest
+	Tag string `json:"tag"`
+	// Outputs are the CSV columns of the model outputs, the other columns are its inputs
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// DataUploadResult reports an upload of inferences
+type DataUploadResult struct {
+	ModelID string `json:"modelId"`
+	Tag     string `json:"tag"`
+	Format  string `json:"format"`
+	// Rows is the number of inferences uploaded from a CSV file
+	Rows int `json:"rows,omitempty"`
+	// Message is the last answer of the service
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Formats of custom evaluation datasets
+const (
+	DatasetFormatJSONL = "jsonl"
+	DatasetFormatCSV   = "csv"
+)
+
+// Storage of custom evaluation datasets, chosen by their size
+const (
+	DatasetStorageConfigMap = "configmap"
+	DatasetStoragePVC       = "pvc"
+)
+
+// States of custom evaluation datasets. Datasets stored on a PVC are Loading until the
+// loader Job has copied them to the volume.
+const (
+	DatasetStateReady   = "Ready"
+	DatasetStateLoading = "Loading"
+	DatasetStateFailed  = "Failed"
+)
+
+// Dataset is a custom dataset of prompts and expected answers uploaded to a namespace,
+// which evaluations reference to run a generated unitxt card
+type Dataset struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	DisplayName string `json:"displayName,omitempty"`
+	Format      string `json:"format"`
+	Storage     string `json:"storage"`
+	// Size is the size of the file in bytes
+	Size    int64    `json:"size"`
+	Rows    int      `json:"rows"`
+	Columns []string `json:"columns"`
+	// Ref references the dataset in create requests, with the columns guessed or set on upload
+	Ref   DatasetRef `json:"ref"`
+	State string     `json:"state"`
+
+	UID               string    `json:"uid,omitempty"`
+	CreatedBy         string    `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time `json:"creationTimestamp,omitempty"`
+}
+
+// DatasetRef references an uploaded dataset in a create request. Each row is a prompt,
+// the input column, and its expected answer, the output column, evaluated as an open
+// question answering task. Columns left out default to the ones set on upload.
+type DatasetRef struct {
+	Name         string `json:"name"`
+	InputColumn  string `json:"inputColumn,omitempty"`
+	OutputColumn string `json:"outputColumn,omitempty"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Data drift metrics computed by the TrustyAI service, which compare the latest inferences
+// of a model with a reference dataset
+const (
+	DriftMetricMeanShift    = "MeanShift"
+	DriftMetricFourierMMD   = "FourierMMD"
+	DriftMetricKSTest       = "KSTest"
+	DriftMetricApproxKSTest = "ApproxKSTest"
+)
+
+// DriftMetricRequest defines a drift metric of a model as the TrustyAI service takes it
+type DriftMetricRequest struct {
+	ModelID     string `json:"modelId"`
+	MetricName  string `json:"metricName"`
+	RequestName string `json:"requestName,omitempty"`
+	// ReferenceTag is the tag of the inferences the latest ones are compared with, see DataTagRequest
+	ReferenceTag string `json:"referenceTag"`
+	// FitColumns are the columns compared, all of them when empty
+	FitColumns []string `json:"fitColumns,omitempty"`
+	// BatchSize is the number of latest inferences compared
+	BatchSize int `json:"batchSize,omitempty"`
+	// ThresholdDelta is the significance level under which a column is reported as drifting
+	ThresholdDelta *float64 `json:"thresholdDelta,omitempty"`
+	// Epsilon is the approximation error of ApproxKSTest
+	Epsilon *float64 `json:"epsilon,omitempty"`
+	// Gamma and Parameters tune FourierMMD
+	Gamma      *float64              `json:"gamma,omitempty"`
+	Parameters *FourierMMDParameters `json:"parameters,omitempty"`
+}
+
+// FourierMMDParameters are the parameters of the FourierMMD drift metric
+type FourierMMDParameters struct {
+	NWindow    *int     `json:"nWindow,omitempty"`
+	NTest      *int     `json:"nTest,omitempty"`
+	NMode      *int     `json:"nMode,omitempty"`
+	RandomSeed *int     `json:"randomSeed,omitempty"`
+	Sig        *float64 `json:"sig,omitempty"`
+	DeltaStat  *bool    `json:"deltaStat,omitempty"`
+	Epsilon    *float64 `json:"epsilon,omitempty"`
+}
+
+// DriftMetric is a drift metric scheduled on the TrustyAI service
+type DriftMetric struct {
+	ID      string             `json:"id"`
+	Request DriftMetricRequest `json:"request"`
+}
+
+// DataTagRequest tags ranges of the stored inferences of a model, e.g. as the TRAINING
+// reference of drift metrics
+type DataTagRequest struct {
+	ModelID string `json:"modelId"`
+	Tag     string `json:"tag"`
+	// Ranges are [start, end) row ranges of the inferences, in the order they were stored
+	Ranges [][2]int `json:"ranges"`
+}
+
+// MetricSeries is the time series of one value of a scheduled metric. Metrics reporting a
+// value per column, such as MeanShift, have a series per column named by Subcategory.
+type MetricSeries struct {
+	Subcategory string        `json:"subcategory,omitempty"`
+	Points      []MetricPoint `json:"points"`
+}
+
+// MetricPoint is a value of a metric at a point in time
+type MetricPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// MetricSeriesList is the time series of a scheduled metric over a time range
+type MetricSeriesList struct {
+	ID     string         `json:"id"`
+	Metric string         `json:"metric"`
+	Start  time.Time      `json:"start"`
+	End    time.Time      `json:"end"`
+	Series []MetricSeries `json:"series"`
+}
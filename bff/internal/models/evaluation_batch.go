@@ -0,0 +1,68 @@
+package models
+
+// LMEvalBatchRequest expands a matrix of models × task sets into one evaluation per pair
+type LMEvalBatchRequest struct {
+	// BatchName groups the created evaluations and prefixes their names
+	BatchName      string               `json:"batchName"`
+	EvaluationName string               `json:"evaluationName,omitempty"`
+	Models         []LMEvalBatchModel   `json:"models"`
+	TaskSets       []LMEvalBatchTaskSet `json:"taskSets"`
+
+	// Settings shared by every evaluation in the batch
+	AllowRemoteCode *bool  `json:"allowRemoteCode,omitempty"`
+	AllowOnline     *bool  `json:"allowOnline,omitempty"`
+	BatchSize       string `json:"batchSize,omitempty"`
+	NumFewShot      *int   `json:"numFewShot,omitempty"`
+	Limit           string `json:"limit,omitempty"`
+
+	// Labels and Experiment are applied to every evaluation, see LMEvalCreateRequest
+	Labels     map[string]string `json:"labels,omitempty"`
+	Experiment string            `json:"experiment,omitempty"`
+
+	// Regression is applied to every evaluation, typically with the "previous" baseline
+	Regression *RegressionPolicy `json:"regression,omitempty"`
+
+	// Thresholds form the quality gate of every evaluation, replacing those of task set templates
+	Thresholds []string `json:"thresholds,omitempty"`
+
+	// MaxConcurrent caps how many evaluations of the batch run at once, 0 means no cap.
+	// Evaluations over the cap are created suspended and started as others finish.
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+}
+
+// LMEvalBatchModel is one model of the batch matrix
+type LMEvalBatchModel struct {
+	ModelType string            `json:"modelType"`
+	Model     LMEvalModelConfig `json:"model"`
+}
+
+// LMEvalBatchTaskSet is one task set of the batch matrix, given as tasks or a template
+type LMEvalBatchTaskSet struct {
+	Name        string                 `json:"name"`
+	Tasks       []string               `json:"tasks,omitempty"`
+	TemplateRef *EvaluationTemplateRef `json:"templateRef,omitempty"`
+}
+
+// Batch item statuses
+const (
+	BatchItemCreated = "created"
+	BatchItemQueued  = "queued"
+	BatchItemFailed  = "failed"
+)
+
+// LMEvalBatchResult reports the outcome of every evaluation in a batch
+type LMEvalBatchResult struct {
+	BatchName string                  `json:"batchName"`
+	Created   int                     `json:"created"`
+	Failed    int                     `json:"failed"`
+	Items     []LMEvalBatchItemResult `json:"items"`
+}
+
+// LMEvalBatchItemResult is the outcome of creating one evaluation of a batch
+type LMEvalBatchItemResult struct {
+	Name      string `json:"name"`
+	ModelName string `json:"modelName"`
+	TaskSet   string `json:"taskSet"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// LMEvalBulkDeleteRequest selects the evaluations of a namespace to delete. The filters
+// are combined and at least one is required, so a namespace is never emptied by mistake.
+type LMEvalBulkDeleteRequest struct {
+	// LabelSelector selects evaluations by label, e.g. trustyai.opendatahub.io/experiment=q3-release
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// OlderThan selects evaluations created longer ago than a duration such as 30d or 12h
+	OlderThan string `json:"olderThan,omitempty"`
+	// States selects evaluations by their displayed state, see LMEvalJobKind.DisplayState
+	States []string `json:"states,omitempty"`
+	// DryRun reports the evaluations that would be deleted without deleting them
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Bulk delete item statuses
+const (
+	BulkDeleteItemMatched = "matched"
+	BulkDeleteItemDeleted = "deleted"
+	BulkDeleteItemFailed  = "failed"
+)
+
+// LMEvalBulkDeleteResult reports the evaluations a bulk delete matched and the outcome of each
+type LMEvalBulkDeleteResult struct {
+	DryRun  bool                         `json:"dryRun"`
+	Matched int                          `json:"matched"`
+	Deleted int                          `json:"deleted"`
+	Failed  int                          `json:"failed"`
+	Items   []LMEvalBulkDeleteItemResult `json:"items"`
+}
+
+// LMEvalBulkDeleteItemResult is the outcome of deleting one evaluation. Its status is
+// matched in a dry run.
+type LMEvalBulkDeleteItemResult struct {
+	Name              string    `json:"name"`
+	State             string    `json:"state"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	Status            string    `json:"status"`
+	Error             string    `json:"error,omitempty"`
+}
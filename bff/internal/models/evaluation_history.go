@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// EvaluationHistoryRecord is the last known state of an evaluation in the history. Records
+// outlive their LMEvalJob, DeletedAt is set once it is gone.
+type EvaluationHistoryRecord struct {
+	UID               string         `json:"uid"`
+	Name              string         `json:"name"`
+	Namespace         string         `json:"namespace"`
+	DisplayName       string         `json:"displayName,omitempty"`
+	Experiment        string         `json:"experiment,omitempty"`
+	Model             string         `json:"model"`
+	ModelType         string         `json:"modelType"`
+	ModelEndpoint     string         `json:"modelEndpoint,omitempty"`
+	Tasks             []string       `json:"tasks"`
+	State             string         `json:"state"`
+	CreationTimestamp time.Time      `json:"creationTimestamp"`
+	CompleteTime      *time.Time     `json:"completeTime,omitempty"`
+	DeletedAt         *time.Time     `json:"deletedAt,omitempty"`
+	Metrics           []LMEvalMetric `json:"metrics"`
+}
+
+// FinishedAt is the completion time of the evaluation, its creation time until it completes
+func (r *EvaluationHistoryRecord) FinishedAt() time.Time {
+	if r.CompleteTime != nil {
+		return *r.CompleteTime
+	}
+	return r.CreationTimestamp
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// EvaluationReport is the flattened view of an evaluation used for exported reports
+type EvaluationReport struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	DisplayName string `json:"displayName,omitempty"`
+	Model       string `json:"model,omitempty"`
+	ModelType   string `json:"modelType"`
+
+	// Task configuration
+	Tasks      []string `json:"tasks"`
+	NumFewShot *int     `json:"numFewShot,omitempty"`
+	Limit      string   `json:"limit,omitempty"`
+	BatchSize  string   `json:"batchSize,omitempty"`
+
+	CreatedBy         string     `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	StartTime         *time.Time `json:"startTime,omitempty"`
+	CompleteTime      *time.Time `json:"completeTime,omitempty"`
+	// DurationSeconds is the time from start to completion, when both are known
+	DurationSeconds *float64 `json:"durationSeconds,omitempty"`
+
+	State   string         `json:"state,omitempty"`
+	Reason  string         `json:"reason,omitempty"`
+	Metrics []LMEvalMetric `json:"metrics"`
+}
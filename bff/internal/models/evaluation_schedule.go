@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// EvaluationSchedule runs an evaluation on a cron schedule, for example nightly regression runs
+type EvaluationSchedule struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Schedule is a five field cron expression or a macro such as @daily
+	Schedule string `json:"schedule"`
+	// TimeZone is an IANA time zone name the schedule is evaluated in, UTC by default
+	TimeZone string `json:"timeZone,omitempty"`
+	Suspend  bool   `json:"suspend,omitempty"`
+	// Evaluation is the evaluation created on every run. Its k8sName is ignored,
+	// run names are derived from the schedule name and the scheduled time.
+	Evaluation LMEvalCreateRequest `json:"evaluation"`
+
+	SuccessfulRunsHistoryLimit *int `json:"successfulRunsHistoryLimit,omitempty"`
+	FailedRunsHistoryLimit     *int `json:"failedRunsHistoryLimit,omitempty"`
+
+	LastScheduleTime  *time.Time `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime  *time.Time `json:"nextScheduleTime,omitempty"`
+	ResourceVersion   string     `json:"resourceVersion,omitempty"`
+	CreatedBy         string     `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time  `json:"creationTimestamp,omitempty"`
+
+	// RunAs is the user who last saved the schedule. Runs are only created while they may
+	// create evaluations in the namespace. Signature is the BFF's signature over the schedule.
+	RunAs     RunAs  `json:"-"`
+	Signature string `json:"-"`
+}
+
+// EvaluationScheduleRun is an evaluation created by a schedule
+type EvaluationScheduleRun struct {
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace"`
+	State             string     `json:"state,omitempty"`
+	Reason            string     `json:"reason,omitempty"`
+	ScheduledTime     *time.Time `json:"scheduledTime,omitempty"`
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	CompleteTime      *time.Time `json:"completeTime,omitempty"`
+}
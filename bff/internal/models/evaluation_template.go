@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	// TemplateScopeNamespace templates live in the namespace they are used from.
+	TemplateScopeNamespace = "namespace"
+	// TemplateScopeCluster templates are managed by admins and available to every namespace.
+	TemplateScopeCluster = "cluster"
+)
+
+// EvaluationTemplate is a reusable evaluation preset, such as a fixed benchmark suite
+type EvaluationTemplate struct {
+	Name              string    `json:"name"`
+	Namespace         string    `json:"namespace,omitempty"`
+	Scope             string    `json:"scope,omitempty"`
+	DisplayName       string    `json:"displayName,omitempty"`
+	Description       string    `json:"description,omitempty"`
+	Tasks             []string  `json:"tasks"`
+	NumFewShot        *int      `json:"numFewShot,omitempty"`
+	Limit             string    `json:"limit,omitempty"`
+	BatchSize         string    `json:"batchSize,omitempty"`
+	AllowRemoteCode   bool      `json:"allowRemoteCode,omitempty"`
+	AllowOnline       bool      `json:"allowOnline,omitempty"`
+	Thresholds        []string  `json:"thresholds,omitempty"`
+	ResourceVersion   string    `json:"resourceVersion,omitempty"`
+	CreatedBy         string    `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time `json:"creationTimestamp,omitempty"`
+}
+
+// EvaluationTemplateRef points a create request at a stored template
+type EvaluationTemplateRef struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// Experiment phases, derived from the states of its evaluations
+const (
+	ExperimentPhasePending   = "Pending"
+	ExperimentPhaseRunning   = "Running"
+	ExperimentPhaseSucceeded = "Succeeded"
+	ExperimentPhaseFailed    = "Failed"
+)
+
+// Experiment groups the evaluations sharing an experiment label
+type Experiment struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Status    ExperimentStatus `json:"status"`
+
+	// Evaluations and Results are only filled in when fetching a single experiment
+	Evaluations []ExperimentEvaluation `json:"evaluations,omitempty"`
+	Results     []ExperimentResult     `json:"results,omitempty"`
+}
+
+// ExperimentStatus aggregates the states of an experiment's evaluations
+type ExperimentStatus struct {
+	Phase     string `json:"phase"`
+	Total     int    `json:"total"`
+	Pending   int    `json:"pending"`
+	Running   int    `json:"running"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// ExperimentEvaluation summarises one evaluation of an experiment
+type ExperimentEvaluation struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Model       string   `json:"model,omitempty"`
+	Tasks       []string `json:"tasks"`
+	State       string   `json:"state,omitempty"`
+	Reason      string   `json:"reason,omitempty"`
+}
+
+// ExperimentResult is one metric of one evaluation, so the results of the whole
+// experiment can be shown as a single table
+type ExperimentResult struct {
+	Evaluation string `json:"evaluation"`
+	Model      string `json:"model,omitempty"`
+	LMEvalMetric
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Local explainers of the TrustyAI service
+const (
+	ExplainerLIME = "LIME"
+	ExplainerSHAP = "SHAP"
+)
+
+// ExplanationRequest asks for the explanation of a prediction of a deployed model. The
+// TrustyAI service explains the inferences it stored, the explained input is the one
+// stored with PredictionID.
+type ExplanationRequest struct {
+	// Model is the value of the model in GET /api/v1/models
+	Model string `json:"model"`
+	// ModelName is the name of the model on its server, by default the name of its
+	// service without the -predictor suffix of KServe
+	ModelName string `json:"modelName,omitempty"`
+	Explainer string `json:"explainer"`
+	// PredictionID is the id the TrustyAI service stored the inference with
+	PredictionID string `json:"predictionId"`
+	// Samples is the number of perturbed inputs the explainer sends to the model
+	Samples int `json:"samples,omitempty"`
+}
+
+// Explanation is the saliency of the input features for each output of the model
+type Explanation struct {
+	Model        string `json:"model"`
+	PredictionID string `json:"predictionId"`
+	Explainer    string `json:"explainer"`
+	// Timestamp is when the service explained the prediction, absent when it does not report it
+	Timestamp  *time.Time       `json:"timestamp,omitempty"`
+	Saliencies []OutputSaliency `json:"saliencies"`
+}
+
+// OutputSaliency is the saliency of the input features for one output
+type OutputSaliency struct {
+	Output string `json:"output"`
+	// Features are sorted by decreasing absolute score
+	Features []FeatureSaliency `json:"features"`
+}
+
+// FeatureSaliency is the contribution of an input feature to an output
+type FeatureSaliency struct {
+	Name       string  `json:"name"`
+	Score      float64 `json:"score"`
+	Confidence float64 `json:"confidence"`
+}
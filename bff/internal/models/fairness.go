@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Group fairness metrics computed by the TrustyAI service
+const (
+	// FairnessMetricSPD is the statistical parity difference, fair around 0
+	FairnessMetricSPD = "SPD"
+	// FairnessMetricDIR is the disparate impact ratio, fair around 1
+	FairnessMetricDIR = "DIR"
+)
+
+// FairnessMetricRequest defines a group fairness metric of a model as the TrustyAI service
+// takes it. The attribute and outcome values are passed through as given, numbers or
+// strings depending on the model's data.
+type FairnessMetricRequest struct {
+	ModelID               string `json:"modelId"`
+	MetricName            string `json:"metricName"`
+	RequestName           string `json:"requestName,omitempty"`
+	ProtectedAttribute    string `json:"protectedAttribute"`
+	PrivilegedAttribute   any    `json:"privilegedAttribute"`
+	UnprivilegedAttribute any    `json:"unprivilegedAttribute"`
+	OutcomeName           string `json:"outcomeName"`
+	FavorableOutcome      any    `json:"favorableOutcome"`
+	// BatchSize is the number of latest inferences the metric is computed on
+	BatchSize int `json:"batchSize,omitempty"`
+	// ThresholdDelta is how far from fair the value may be before it is reported as outside bounds
+	ThresholdDelta *float64 `json:"thresholdDelta,omitempty"`
+}
+
+// FairnessMetric is a fairness metric scheduled on the TrustyAI service, which computes it
+// on every new batch of inferences
+type FairnessMetric struct {
+	ID      string                `json:"id"`
+	Request FairnessMetricRequest `json:"request"`
+	// Value is the current value of the metric, only returned for a single metric
+	Value *FairnessMetricValue `json:"value,omitempty"`
+}
+
+// FairnessMetricValue is a value of a fairness metric computed by the TrustyAI service
+type FairnessMetricValue struct {
+	Value float64 `json:"value"`
+	// Timestamp is when the service computed the value, absent when it does not report it
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+	Description string     `json:"description,omitempty"`
+	// Thresholds are absent when the service does not report them
+	Thresholds *FairnessThresholds `json:"thresholds,omitempty"`
+}
+
+// FairnessThresholds are the bounds a fairness metric is considered fair within
+type FairnessThresholds struct {
+	LowerBound    float64 `json:"lowerBound"`
+	UpperBound    float64 `json:"upperBound"`
+	OutsideBounds bool    `json:"outsideBounds"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// Kinds of the evaluations listed by GET /evaluations
+const (
+	EvaluationKindLMEval     = "LMEvalJob"
+	EvaluationKindGuardrails = "GuardrailsEvaluation"
+)
+
+// Guardrails evaluation states. A failed evaluation produced no results, for example
+// because its orchestrator could not be reached.
+const (
+	GuardrailsStatePending  = "Pending"
+	GuardrailsStateRunning  = "Running"
+	GuardrailsStateComplete = "Complete"
+	GuardrailsStateFailed   = "Failed"
+)
+
+// GuardrailsOrchestrator is a TrustyAI guardrails orchestrator discovered in a namespace
+type GuardrailsOrchestrator struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Service is the name of the orchestrator service, URL its detection API
+	Service string `json:"service"`
+	URL     string `json:"url"`
+}
+
+// GuardrailsEvaluation runs a prompt dataset through the detectors of a guardrails
+// orchestrator and records how often each detector flags a prompt
+type GuardrailsEvaluation struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Experiment  string `json:"experiment,omitempty"`
+	// Orchestrator is the name of the orchestrator, optional when the namespace has only one
+	Orchestrator string   `json:"orchestrator,omitempty"`
+	Detectors    []string `json:"detectors"`
+	// Prompts are the prompts to run, unless they are read from Dataset
+	Prompts []string           `json:"prompts,omitempty"`
+	Dataset *GuardrailsDataset `json:"dataset,omitempty"`
+
+	Status GuardrailsEvaluationStatus `json:"status"`
+
+	UID               string    `json:"uid,omitempty"`
+	ResourceVersion   string    `json:"resourceVersion,omitempty"`
+	CreatedBy         string    `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time `json:"creationTimestamp,omitempty"`
+
+	// RunAs is the user who created the evaluation. It only runs while they may read its
+	// orchestrator and dataset. Signature is the BFF's signature over the evaluation.
+	RunAs     RunAs  `json:"-"`
+	Signature string `json:"-"`
+}
+
+// GuardrailsDataset is a ConfigMap key holding one prompt per line. Lines may also be
+// JSON objects with a "prompt" or "text" field.
+type GuardrailsDataset struct {
+	ConfigMap string `json:"configMap"`
+	Key       string `json:"key"`
+}
+
+// GuardrailsEvaluationStatus is the progress and outcome of a guardrails evaluation
+type GuardrailsEvaluationStatus struct {
+	State        string              `json:"state"`
+	Message      string              `json:"message,omitempty"`
+	StartTime    *time.Time          `json:"startTime,omitempty"`
+	CompleteTime *time.Time          `json:"completeTime,omitempty"`
+	Progress     *GuardrailsProgress `json:"progress,omitempty"`
+	Results      *GuardrailsResults  `json:"results,omitempty"`
+}
+
+// GuardrailsProgress counts the prompts already sent to the orchestrator
+type GuardrailsProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// GuardrailsResults are the detection rates of an evaluation. Rates are the share of the
+// evaluated prompts flagged, prompts the orchestrator failed to check are left out.
+type GuardrailsResults struct {
+	Prompts        int              `json:"prompts"`
+	Evaluated      int              `json:"evaluated"`
+	Errors         int              `json:"errors"`
+	FlaggedPrompts int              `json:"flaggedPrompts"`
+	DetectionRate  float64          `json:"detectionRate"`
+	Detectors      []DetectorResult `json:"detectors"`
+}
+
+// DetectorResult is how often a detector flagged the prompts of an evaluation
+type DetectorResult struct {
+	Detector       string  `json:"detector"`
+	Detections     int     `json:"detections"`
+	FlaggedPrompts int     `json:"flaggedPrompts"`
+	DetectionRate  float64 `json:"detectionRate"`
+}
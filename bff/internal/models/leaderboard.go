@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Leaderboard run selection: the most recent completed run per model, or its best score
+const (
+	LeaderboardSelectionLatest = "latest"
+	LeaderboardSelectionBest   = "best"
+)
+
+// Leaderboard ranks models per task and metric across the completed evaluations of a namespace
+type Leaderboard struct {
+	Namespace string             `json:"namespace"`
+	Selection string             `json:"selection"`
+	Boards    []LeaderboardBoard `json:"boards"`
+}
+
+// LeaderboardBoard ranks the models evaluated on one task metric
+type LeaderboardBoard struct {
+	Task           string             `json:"task"`
+	Metric         string             `json:"metric"`
+	Filter         string             `json:"filter,omitempty"`
+	HigherIsBetter bool               `json:"higherIsBetter"`
+	Entries        []LeaderboardEntry `json:"entries"`
+}
+
+// LeaderboardEntry is a model's score on a board. The confidence interval is the
+// 95% normal interval derived from the reported standard error, when there is one.
+type LeaderboardEntry struct {
+	Rank         int        `json:"rank"`
+	Model        string     `json:"model"`
+	Evaluation   string     `json:"evaluation"`
+	Value        float64    `json:"value"`
+	StdErr       *float64   `json:"stderr,omitempty"`
+	CILower      *float64   `json:"ciLower,omitempty"`
+	CIUpper      *float64   `json:"ciUpper,omitempty"`
+	CompleteTime *time.Time `json:"completeTime,omitempty"`
+}
@@ -17,6 +17,7 @@ type LMEvalKind struct {
 type LMEvalMetadata struct {
 	Name              string            `json:"name"`
 	Namespace         string            `json:"namespace"`
+	Labels            map[string]string `json:"labels,omitempty"`
 	Annotations       map[string]string `json:"annotations,omitempty"`
 	ResourceVersion   string            `json:"resourceVersion,omitempty"`
 	UID               string            `json:"uid,omitempty"`
@@ -93,14 +94,38 @@ type ListMetadata struct {
 
 // LMEvalCreateRequest represents a request to create a new evaluation
 type LMEvalCreateRequest struct {
-	EvaluationName  string            `json:"evaluationName"`
-	K8sName         string            `json:"k8sName"`
-	ModelType       string            `json:"modelType"`
-	Model           LMEvalModelConfig `json:"model"`
-	Tasks           []string          `json:"tasks"`
-	AllowRemoteCode bool              `json:"allowRemoteCode"`
-	AllowOnline     bool              `json:"allowOnline"`
-	BatchSize       string            `json:"batchSize,omitempty"`
+	EvaluationName string            `json:"evaluationName"`
+	K8sName        string            `json:"k8sName"`
+	ModelType      string            `json:"modelType"`
+	Model          LMEvalModelConfig `json:"model"`
+	Tasks          []string          `json:"tasks"`
+	BatchSize      string            `json:"batchSize,omitempty"`
+	NumFewShot     *int              `json:"numFewShot,omitempty"`
+	Limit          string            `json:"limit,omitempty"`
+
+	// AllowRemoteCode and AllowOnline override the template's values when set,
+	// and are false when neither the request nor the template sets them.
+	AllowRemoteCode *bool `json:"allowRemoteCode,omitempty"`
+	AllowOnline     *bool `json:"allowOnline,omitempty"`
+
+	// TemplateRef applies a stored evaluation template. Fields set on the
+	// request override the values coming from the template.
+	TemplateRef *EvaluationTemplateRef `json:"templateRef,omitempty"`
+
+	// Labels are added to the LMEvalJob. Experiment is a shorthand for the
+	// experiment label that groups related evaluations.
+	Labels     map[string]string `json:"labels,omitempty"`
+	Experiment string            `json:"experiment,omitempty"`
+
+	// Regression compares the results with a baseline evaluation once the job completes
+	Regression *RegressionPolicy `json:"regression,omitempty"`
+
+	// Thresholds form the quality gate of the evaluation, for example "arc_easy.acc >= 0.7"
+	Thresholds []string `json:"thresholds,omitempty"`
+
+	// Dataset evaluates the model on an uploaded dataset, in addition to the tasks, with
+	// the job running offline
+	Dataset *DatasetRef `json:"dataset,omitempty"`
 }
 
 // LMEvalModelConfig represents model configuration
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,10 +15,41 @@ type LMEvalJobKind struct {
 	Status     *LMEvalJobStatus  `json:"status,omitempty"`
 }
 
+// ModelName returns the model the job evaluates, taken from its model args
+func (j *LMEvalJobKind) ModelName() string {
+	for _, arg := range j.Spec.ModelArgs {
+		if arg.Name == "model" || arg.Name == "pretrained" {
+			return arg.Value
+		}
+	}
+	return j.Spec.Model
+}
+
+// ModelEndpoint identifies the in-cluster service the job evaluates, in the form of the
+// values of the /models options: "<service>-<namespace>". It is empty for jobs evaluating
+// external endpoints or a model loaded by the job itself.
+func (j *LMEvalJobKind) ModelEndpoint() string {
+	for _, arg := range j.Spec.ModelArgs {
+		if arg.Name != "base_url" {
+			continue
+		}
+		baseURL, err := url.Parse(arg.Value)
+		if err != nil {
+			return ""
+		}
+		host := strings.Split(baseURL.Hostname(), ".")
+		if len(host) >= 3 && host[2] == "svc" {
+			return host[0] + "-" + host[1]
+		}
+	}
+	return ""
+}
+
 // LMEvalJobMetadata contains metadata for the evaluation job
 type LMEvalJobMetadata struct {
 	Name              string            `json:"name"`
 	Namespace         string            `json:"namespace"`
+	Labels            map[string]string `json:"labels,omitempty"`
 	Annotations       map[string]string `json:"annotations,omitempty"`
 	ResourceVersion   string            `json:"resourceVersion,omitempty"`
 	UID               string            `json:"uid,omitempty"`
@@ -31,9 +64,17 @@ type LMEvalJobSpec struct {
 	LogSamples         bool                `json:"logSamples,omitempty"`
 	Model              string              `json:"model"`
 	ModelArgs          []LMEvalJobModelArg `json:"modelArgs,omitempty"`
+	NumFewShot         *int                `json:"numFewShot,omitempty"`
+	Limit              string              `json:"limit,omitempty"`
 	Timeout            int                 `json:"timeout,omitempty"`
 	TaskList           LMEvalJobTaskList   `json:"taskList"`
 	Outputs            *LMEvalJobOutputs   `json:"outputs,omitempty"`
+	// Offline runs the job without network access, from the models and datasets of a PVC
+	Offline *LMEvalJobOffline `json:"offline,omitempty"`
+	// Pod customises the pod running the job
+	Pod *LMEvalJobPod `json:"pod,omitempty"`
+	// Suspend keeps the job from starting until it is set back to false
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // LMEvalJobModelArg represents a model argument
@@ -44,7 +85,73 @@ type LMEvalJobModelArg struct {
 
 // LMEvalJobTaskList contains the list of tasks to evaluate
 type LMEvalJobTaskList struct {
-	TaskNames []string `json:"taskNames"`
+	TaskNames []string `json:"taskNames,omitempty"`
+	// TaskRecipes are unitxt recipes evaluated besides the named tasks
+	TaskRecipes []LMEvalJobTaskRecipe `json:"taskRecipes,omitempty"`
+}
+
+// LMEvalJobTaskRecipe is a unitxt recipe: a card turning a dataset into task instances
+// and the template rendering them as prompts
+type LMEvalJobTaskRecipe struct {
+	Card     LMEvalJobCard      `json:"card"`
+	Template *LMEvalJobTemplate `json:"template,omitempty"`
+}
+
+// LMEvalJobCard is a unitxt card of the catalog, by name, or a custom one as JSON
+type LMEvalJobCard struct {
+	Name   string `json:"name,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// LMEvalJobTemplate is a unitxt template of the catalog
+type LMEvalJobTemplate struct {
+	Name string `json:"name,omitempty"`
+}
+
+// LMEvalJobOffline is the offline mode of a job
+type LMEvalJobOffline struct {
+	Storage LMEvalJobOfflineStorage `json:"storage"`
+}
+
+// LMEvalJobOfflineStorage is the PVC mounted at the HF_HOME of offline jobs
+type LMEvalJobOfflineStorage struct {
+	PVCName string `json:"pvcName"`
+}
+
+// LMEvalJobPod customises the pod running a job
+type LMEvalJobPod struct {
+	Container *LMEvalJobContainer `json:"container,omitempty"`
+	Volumes   []LMEvalJobVolume   `json:"volumes,omitempty"`
+}
+
+// LMEvalJobContainer customises the container running lm-evaluation-harness
+type LMEvalJobContainer struct {
+	Env          []LMEvalJobEnvVar      `json:"env,omitempty"`
+	VolumeMounts []LMEvalJobVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// LMEvalJobEnvVar is an environment variable of the container
+type LMEvalJobEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// LMEvalJobVolume is a volume of the pod, only ConfigMap volumes are used
+type LMEvalJobVolume struct {
+	Name      string                    `json:"name"`
+	ConfigMap *LMEvalJobConfigMapSource `json:"configMap,omitempty"`
+}
+
+// LMEvalJobConfigMapSource mounts the keys of a ConfigMap as files
+type LMEvalJobConfigMapSource struct {
+	Name string `json:"name"`
+}
+
+// LMEvalJobVolumeMount mounts a volume of the pod in the container
+type LMEvalJobVolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
 }
 
 // LMEvalJobOutputs contains output configuration
@@ -69,6 +176,45 @@ type LMEvalJobStatus struct {
 	ProgressBars     []LMEvalJobProgressBar `json:"progressBars,omitempty"`
 }
 
+// LMEvalJob states reported by the TrustyAI operator
+const (
+	LMEvalJobStateNew       = "New"
+	LMEvalJobStateScheduled = "Scheduled"
+	LMEvalJobStateRunning   = "Running"
+	LMEvalJobStateComplete  = "Complete"
+	LMEvalJobStateCancelled = "Cancelled"
+	LMEvalJobStateSuspended = "Suspended"
+
+	// LMEvalJobStatePending is shown for jobs the operator has not reported a state for yet
+	LMEvalJobStatePending = "Pending"
+
+	// LMEvalJobReasonFailed is set together with the Complete state when the job failed
+	LMEvalJobReasonFailed = "Failed"
+)
+
+// IsFinished reports whether the job reached a terminal state
+func (s *LMEvalJobStatus) IsFinished() bool {
+	return s != nil && (s.State == LMEvalJobStateComplete || s.State == LMEvalJobStateCancelled)
+}
+
+// IsFailed reports whether the job finished without producing results
+func (s *LMEvalJobStatus) IsFailed() bool {
+	return s.IsFinished() && (s.Reason == LMEvalJobReasonFailed || s.State == LMEvalJobStateCancelled)
+}
+
+// DisplayState returns the state shown to users: Pending until the operator reports a
+// state and Failed for a job the operator marks Complete with a Failed reason
+func (j *LMEvalJobKind) DisplayState() string {
+	switch {
+	case j.Status == nil || j.Status.State == "":
+		return LMEvalJobStatePending
+	case j.Status.State == LMEvalJobStateComplete && j.Status.IsFailed():
+		return LMEvalJobReasonFailed
+	default:
+		return j.Status.State
+	}
+}
+
 // LMEvalJobProgressBar represents a progress bar in the status
 type LMEvalJobProgressBar struct {
 	Count                 string `json:"count"`
@@ -86,6 +232,18 @@ type LMEvalJobList struct {
 	Items      []LMEvalJobKind `json:"items"`
 }
 
+// LMEvalJobPatchRequest changes the metadata of an evaluation. Absent fields are left
+// unchanged: an empty display name or description removes it, a null label value
+// removes the label and tags replace the current ones, an empty list removing them.
+type LMEvalJobPatchRequest struct {
+	// ResourceVersion, when set, rejects the patch if the evaluation changed since it was read
+	ResourceVersion string             `json:"resourceVersion,omitempty"`
+	DisplayName     *string            `json:"displayName,omitempty"`
+	Description     *string            `json:"description,omitempty"`
+	Labels          map[string]*string `json:"labels,omitempty"`
+	Tags            []string           `json:"tags,omitempty"`
+}
+
 // LMEvalJobCreateRequest represents a request to create a new evaluation job
 type LMEvalJobCreateRequest struct {
 	EvaluationName  string               `json:"evaluationName"`
@@ -105,3 +263,11 @@ type LMEvalJobModelConfig struct {
 	TokenizedRequest string `json:"tokenizedRequest"`
 	Tokenizer        string `json:"tokenizer"`
 }
+
+// LMEvalJobLogs holds the output of the pod running an evaluation job
+type LMEvalJobLogs struct {
+	Evaluation string `json:"evaluation"`
+	Namespace  string `json:"namespace"`
+	Pod        string `json:"pod"`
+	Logs       string `json:"logs"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LMEvalMetric is a single metric reported by lm-evaluation-harness for a task
+type LMEvalMetric struct {
+	Task   string   `json:"task"`
+	Metric string   `json:"metric"`
+	Filter string   `json:"filter,omitempty"`
+	Value  float64  `json:"value"`
+	StdErr *float64 `json:"stderr,omitempty"`
+}
+
+// Key identifies the metric as "task.metric", the form used by quality gates and comparisons.
+// Metrics of a non-default filter are keyed "task.metric,filter".
+func (m LMEvalMetric) Key() string {
+	key := m.Task + "." + m.Metric
+	if m.Filter != "" && m.Filter != "none" {
+		key += "," + m.Filter
+	}
+	return key
+}
+
+// lowerIsBetterMetrics lists lm-evaluation-harness metrics where a smaller value is better
+var lowerIsBetterMetrics = map[string]bool{
+	"perplexity":      true,
+	"word_perplexity": true,
+	"byte_perplexity": true,
+	"bits_per_byte":   true,
+	"ter":             true,
+}
+
+// MetricHigherIsBetter reports whether a larger value of the metric is an improvement
+func MetricHigherIsBetter(metric string) bool {
+	return !lowerIsBetterMetrics[metric]
+}
+
+// ParseLMEvalResults extracts the metrics from the results JSON that the operator
+// stores in the LMEvalJob status. Metric keys look like "acc,none" with a matching
+// "acc_stderr,none"; aliases and other non-numeric values are skipped.
+func ParseLMEvalResults(raw string) ([]LMEvalMetric, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var payload struct {
+		Results map[string]map[string]any `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse evaluation results: %w", err)
+	}
+
+	var metrics []LMEvalMetric
+	for task, values := range payload.Results {
+		for key, value := range values {
+			number, ok := value.(float64)
+			if !ok {
+				continue
+			}
+			metric, filter, _ := strings.Cut(key, ",")
+			if strings.HasSuffix(metric, "_stderr") {
+				continue
+			}
+
+			entry := LMEvalMetric{Task: task, Metric: metric, Filter: filter, Value: number}
+			stderrKey := metric + "_stderr"
+			if filter != "" {
+				stderrKey += "," + filter
+			}
+			if stderr, ok := values[stderrKey].(float64); ok {
+				entry.StdErr = &stderr
+			}
+			metrics = append(metrics, entry)
+		}
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Key() < metrics[j].Key()
+	})
+	return metrics, nil
+}
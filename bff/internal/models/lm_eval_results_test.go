@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLMEvalResults(t *testing.T) {
+	raw := `{
+		"results": {
+			"arc_easy": {"alias": "arc_easy", "acc,none": 0.82, "acc_stderr,none": 0.01, "acc_norm,none": 0.8, "acc_norm_stderr,none": "N/A"},
+			"gsm8k": {"exact_match,strict-match": 0.4, "exact_match_stderr,strict-match": 0.02}
+		},
+		"config": {"model": "hf"}
+	}`
+
+	metrics, err := ParseLMEvalResults(raw)
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 3)
+
+	assert.Equal(t, "arc_easy.acc", metrics[0].Key())
+	assert.Equal(t, 0.82, metrics[0].Value)
+	assert.Equal(t, 0.01, *metrics[0].StdErr)
+
+	assert.Equal(t, "arc_easy.acc_norm", metrics[1].Key())
+	assert.Nil(t, metrics[1].StdErr)
+
+	assert.Equal(t, "gsm8k.exact_match,strict-match", metrics[2].Key())
+	assert.Equal(t, 0.02, *metrics[2].StdErr)
+}
+
+func TestParseLMEvalResultsEmptyAndInvalid(t *testing.T) {
+	metrics, err := ParseLMEvalResults("")
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+
+	_, err = ParseLMEvalResults("not json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ModelTrends holds the metric values of the completed evaluations of a model over time
+type ModelTrends struct {
+	Model     string        `json:"model"`
+	Namespace string        `json:"namespace"`
+	Series    []MetricTrend `json:"series"`
+}
+
+// MetricTrend is the time series of one task metric, oldest point first
+type MetricTrend struct {
+	Task           string       `json:"task"`
+	Metric         string       `json:"metric"`
+	Filter         string       `json:"filter,omitempty"`
+	HigherIsBetter bool         `json:"higherIsBetter"`
+	Points         []TrendPoint `json:"points"`
+}
+
+// TrendPoint is the value of a metric in one evaluation, with what is needed to label the
+// point and link back to the evaluation
+type TrendPoint struct {
+	Time          time.Time `json:"time"`
+	Value         float64   `json:"value"`
+	StdErr        *float64  `json:"stderr,omitempty"`
+	Change        *float64  `json:"change,omitempty"`
+	Evaluation    string    `json:"evaluation"`
+	UID           string    `json:"uid"`
+	DisplayName   string    `json:"displayName,omitempty"`
+	Experiment    string    `json:"experiment,omitempty"`
+	ModelEndpoint string    `json:"modelEndpoint,omitempty"`
+	Deleted       bool      `json:"deleted"`
+	Link          string    `json:"link,omitempty"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Webhook payload formats
+const (
+	NotificationFormatGeneric     = "generic"
+	NotificationFormatSlack       = "slack"
+	NotificationFormatCloudEvents = "cloudevents"
+)
+
+// Notification event types
+const (
+	NotificationEventCreated   = "evaluation.created"
+	NotificationEventCompleted = "evaluation.completed"
+	NotificationEventFailed    = "evaluation.failed"
+)
+
+// NotificationSubscription sends a webhook when evaluations in its namespace finish
+type NotificationSubscription struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	URL       string `json:"url"`
+	// Format is generic, slack or cloudevents, generic by default
+	Format string `json:"format,omitempty"`
+	// Events filters the event types sent, all of them when empty
+	Events   []string `json:"events,omitempty"`
+	Disabled bool     `json:"disabled,omitempty"`
+
+	// Secret is the HMAC signing key. It is write only: responses report Signed instead.
+	// On update a missing secret keeps the current key and an empty one removes it.
+	Secret *string `json:"secret,omitempty"`
+	Signed bool    `json:"signed"`
+
+	ResourceVersion   string    `json:"resourceVersion,omitempty"`
+	CreatedBy         string    `json:"createdBy,omitempty"`
+	CreationTimestamp time.Time `json:"creationTimestamp,omitempty"`
+}
+
+// Wants reports whether the subscription is sent events of the given type
+func (s *NotificationSubscription) Wants(eventType string) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, eventType)
+}
+
+// NotificationEvent describes an evaluation state transition
+type NotificationEvent struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	Time       time.Time              `json:"time"`
+	Evaluation NotificationEvaluation `json:"evaluation"`
+}
+
+// NotificationEvaluation is the evaluation an event is about
+type NotificationEvaluation struct {
+	Name         string         `json:"name"`
+	Namespace    string         `json:"namespace"`
+	DisplayName  string         `json:"displayName,omitempty"`
+	Model        string         `json:"model,omitempty"`
+	Tasks        []string       `json:"tasks,omitempty"`
+	State        string         `json:"state,omitempty"`
+	Reason       string         `json:"reason,omitempty"`
+	Message      string         `json:"message,omitempty"`
+	CompleteTime *time.Time     `json:"completeTime,omitempty"`
+	Metrics      []LMEvalMetric `json:"metrics,omitempty"`
+}
+
+// NotificationDelivery records one webhook delivery, including its retries
+type NotificationDelivery struct {
+	ID           string    `json:"id"`
+	Subscription string    `json:"subscription"`
+	Event        string    `json:"event"`
+	Evaluation   string    `json:"evaluation"`
+	Timestamp    time.Time `json:"timestamp"`
+	Attempts     int       `json:"attempts"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	Success      bool      `json:"success"`
+	Error        string    `json:"error,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+// Quality gate verdicts
+const (
+	QualityGateVerdictPass    = "pass"
+	QualityGateVerdictFail    = "fail"
+	QualityGateVerdictPending = "pending"
+)
+
+// QualityGateResult is the outcome of an evaluation's metric thresholds. The gate passes
+// only when the evaluation succeeded and every threshold holds.
+type QualityGateResult struct {
+	Evaluation string               `json:"evaluation"`
+	Namespace  string               `json:"namespace"`
+	Verdict    string               `json:"verdict"`
+	Reason     string               `json:"reason,omitempty"`
+	Thresholds []QualityGateOutcome `json:"thresholds"`
+}
+
+// QualityGateOutcome is the result of one threshold, such as "arc_easy.acc >= 0.7".
+// Value is nil when the evaluation has not reported the metric.
+type QualityGateOutcome struct {
+	Expression string   `json:"expression"`
+	Key        string   `json:"key"`
+	Operator   string   `json:"operator"`
+	Threshold  float64  `json:"threshold"`
+	Value      *float64 `json:"value,omitempty"`
+	Passed     bool     `json:"passed"`
+}
@@ -0,0 +1,60 @@
+package models
+
+// RegressionBaselinePrevious compares against the previous successful run of the same model and task set
+const RegressionBaselinePrevious = "previous"
+
+// Regression verdicts
+const (
+	RegressionVerdictPass    = "pass"
+	RegressionVerdictFail    = "fail"
+	RegressionVerdictPending = "pending"
+	RegressionVerdictUnknown = "unknown"
+)
+
+// RegressionPolicy configures regression detection for an evaluation
+type RegressionPolicy struct {
+	// Baseline is the name of the evaluation to compare against, or "previous"
+	Baseline string `json:"baseline"`
+	// Tolerance is the absolute drop in a metric that is still accepted
+	Tolerance float64 `json:"tolerance,omitempty"`
+	// Alpha is the significance level of the test, 0.05 by default
+	Alpha float64 `json:"alpha,omitempty"`
+	// Metrics restricts the comparison to these "task.metric" keys, all shared metrics when empty
+	Metrics []string `json:"metrics,omitempty"`
+}
+
+// RegressionReport compares an evaluation's metrics with its baseline
+type RegressionReport struct {
+	Verdict  string `json:"verdict"`
+	Reason   string `json:"reason,omitempty"`
+	Baseline string `json:"baseline,omitempty"`
+
+	Tolerance   float64            `json:"tolerance"`
+	Alpha       float64            `json:"alpha"`
+	Comparisons []MetricComparison `json:"comparisons"`
+}
+
+// MetricComparison is the change of one metric against the baseline. A regression is a
+// change in the worse direction that exceeds the tolerance and, when both runs report a
+// standard error, is significant under a two-sided z-test.
+type MetricComparison struct {
+	Key            string   `json:"key"`
+	Task           string   `json:"task"`
+	Metric         string   `json:"metric"`
+	Filter         string   `json:"filter,omitempty"`
+	HigherIsBetter bool     `json:"higherIsBetter"`
+	Baseline       float64  `json:"baseline"`
+	Current        float64  `json:"current"`
+	Delta          float64  `json:"delta"`
+	RelativeDelta  *float64 `json:"relativeDelta,omitempty"`
+	ZScore         *float64 `json:"zScore,omitempty"`
+	PValue         *float64 `json:"pValue,omitempty"`
+	Significant    bool     `json:"significant"`
+	Regressed      bool     `json:"regressed"`
+}
+
+// LMEvalJobInsights is derived information returned with an evaluation's details
+type LMEvalJobInsights struct {
+	Regression  *RegressionReport  `json:"regression,omitempty"`
+	QualityGate *QualityGateResult `json:"qualityGate,omitempty"`
+}
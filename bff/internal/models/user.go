@@ -4,3 +4,10 @@ type User struct {
 	UserID       string `json:"userId"`
 	ClusterAdmin bool   `json:"clusterAdmin"`
 }
+
+// RunAs is the user work done later by the BFF on their behalf is authorized as, the user
+// who saved a schedule for example
+type RunAs struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+}
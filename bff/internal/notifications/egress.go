@@ -0,0 +1,111 @@
+package notifications
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Egress decides which addresses webhooks may be sent to. Subscriptions are created by
+// project users while deliveries come from the BFF's pod, so loopback, link-local, private
+// and other non-public addresses are refused unless they fall in an allowed range, such as
+// the Service network of an in-cluster receiver. A nil Egress allows no such address.
+type Egress struct {
+	allowed []netip.Prefix
+}
+
+// NewEgress parses a comma separated list of CIDRs webhooks may be sent to despite
+// being non-public, e.g. "10.96.0.0/12,192.168.10.0/24"
+func NewEgress(allowedCIDRs string) (*Egress, error) {
+	egress := &Egress{}
+	for _, cidr := range strings.Split(allowedCIDRs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook allowed CIDR %q: %w", cidr, err)
+		}
+		egress.allowed = append(egress.allowed, prefix.Masked())
+	}
+	return egress, nil
+}
+
+// CheckURL refuses URLs naming a disallowed address or a localhost name. Other host
+// names are resolved when delivering, where CheckAddr applies to the address dialled.
+func (e *Egress) CheckURL(target *url.URL) error {
+	host := strings.TrimSuffix(strings.ToLower(target.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("webhooks cannot be sent to %s", host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return e.CheckAddr(addr)
+	}
+	return nil
+}
+
+// CheckAddr refuses non-public addresses outside of the allowed ranges
+func (e *Egress) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if e != nil {
+		for _, prefix := range e.allowed {
+			if prefix.Contains(addr) {
+				return nil
+			}
+		}
+	}
+	if !isPublic(addr) {
+		return fmt.Errorf("webhooks cannot be sent to the non-public address %s", addr)
+	}
+	return nil
+}
+
+// HTTPClient returns the client deliveries are sent with. It checks the address of every
+// connection, so host names resolving or redirecting to a refused address are caught too.
+// Proxies from the environment are not used, the receiver is always dialled directly.
+func (e *Egress) HTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return e.CheckAddr(addrPort.Addr())
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// nonPublic lists the special purpose ranges not covered by the netip predicates
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+func isPublic(addr netip.Addr) bool {
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublic {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
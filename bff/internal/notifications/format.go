@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const (
+	// CloudEventsContentType is the media type of structured mode CloudEvents
+	CloudEventsContentType = "application/cloudevents+json"
+	// cloudEventTypePrefix namespaces event types, e.g. io.trustyai.evaluation.completed
+	cloudEventTypePrefix = "io.trustyai."
+
+	// slackMaxMetrics bounds the metrics listed in a Slack message
+	slackMaxMetrics = 10
+)
+
+// CloudEvent is a CloudEvents 1.0 event in structured JSON mode
+type CloudEvent struct {
+	SpecVersion     string                    `json:"specversion"`
+	ID              string                    `json:"id"`
+	Source          string                    `json:"source"`
+	Type            string                    `json:"type"`
+	Subject         string                    `json:"subject,omitempty"`
+	Time            time.Time                 `json:"time"`
+	DataContentType string                    `json:"datacontenttype"`
+	Data            *models.NotificationEvent `json:"data"`
+}
+
+// NewCloudEvent wraps a notification event in a CloudEvents envelope
+func NewCloudEvent(event *models.NotificationEvent) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              event.ID,
+		Source:          "/trustyai-dashboard/namespaces/" + event.Evaluation.Namespace + "/evaluations",
+		Type:            cloudEventTypePrefix + event.Type,
+		Subject:         event.Evaluation.Name,
+		Time:            event.Time,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+}
+
+// Payload renders an event in a subscription's format and returns the body and its content type
+func Payload(format string, event *models.NotificationEvent) ([]byte, string, error) {
+	var (
+		body        []byte
+		contentType = "application/json"
+		err         error
+	)
+	switch format {
+	case models.NotificationFormatGeneric, "":
+		body, err = json.Marshal(event)
+	case models.NotificationFormatSlack:
+		body, err = json.Marshal(map[string]string{"text": slackText(event)})
+	case models.NotificationFormatCloudEvents:
+		body, err = json.Marshal(NewCloudEvent(event))
+		contentType = CloudEventsContentType
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode %s payload: %w", format, err)
+	}
+	return body, contentType, nil
+}
+
+// slackText summarises an event as a Slack mrkdwn message
+func slackText(event *models.NotificationEvent) string {
+	evaluation := event.Evaluation
+	name := evaluation.DisplayName
+	if name == "" {
+		name = evaluation.Name
+	}
+
+	var text strings.Builder
+	if event.Type == models.NotificationEventFailed {
+		fmt.Fprintf(&text, ":x: Evaluation *%s* failed", name)
+	} else {
+		fmt.Fprintf(&text, ":white_check_mark: Evaluation *%s* completed", name)
+	}
+	fmt.Fprintf(&text, " in `%s`", evaluation.Namespace)
+	if evaluation.Model != "" {
+		fmt.Fprintf(&text, "\nModel: `%s`", evaluation.Model)
+	}
+	if event.Type == models.NotificationEventFailed && evaluation.Message != "" {
+		fmt.Fprintf(&text, "\n> %s", evaluation.Message)
+	}
+	for i, metric := range evaluation.Metrics {
+		if i == slackMaxMetrics {
+			fmt.Fprintf(&text, "\n… and %d more metrics", len(evaluation.Metrics)-slackMaxMetrics)
+			break
+		}
+		fmt.Fprintf(&text, "\n• %s: %.4g", metric.Key(), metric.Value)
+	}
+	return text.String()
+}
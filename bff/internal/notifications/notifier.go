@@ -0,0 +1,440 @@
+// Package notifications sends webhooks when evaluations finish or fail.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultInterval is how often evaluations are checked for state transitions
+	DefaultInterval = 30 * time.Second
+
+	// Headers sent with every delivery. The signature is only sent for subscriptions
+	// with a signing key, see Sign.
+	EventHeader     = "X-TrustyAI-Event"
+	DeliveryHeader  = "X-TrustyAI-Delivery"
+	SignatureHeader = "X-TrustyAI-Signature"
+
+	// DeliveryRetryPeriod is how long failed deliveries are retried after an evaluation
+	// finished, on later passes with a growing delay capped at maxRetryDelay
+	DeliveryRetryPeriod = 24 * time.Hour
+	maxRetryDelay       = time.Hour
+
+	defaultMaxAttempts = 3
+	defaultBackoff     = 2 * time.Second
+	deliveryTimeout    = 10 * time.Second
+)
+
+// Notifier watches evaluations and reports the ones that complete or fail: it delivers a
+// webhook to the subscriptions of the evaluation's namespace and publishes a CloudEvent when
+// a sink is configured. Deliveries are retried with exponential backoff and recorded in the
+// subscription's history. An evaluation stays pending until every subscription received it,
+// failed deliveries are retried on later passes for DeliveryRetryPeriod.
+type Notifier struct {
+	client      kubernetes.KubernetesClientInterface
+	publisher   *Publisher
+	logger      *slog.Logger
+	interval    time.Duration
+	identity    *kubernetes.RequestIdentity
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	retryDelay  time.Duration
+	started     time.Time
+
+	// retries holds the failed deliveries of pending evaluations, by evaluation and subscription
+	retries map[string]map[string]*retry
+}
+
+// retry tracks the failed deliveries of an evaluation to a subscription
+type retry struct {
+	failures int
+	next     time.Time
+}
+
+// New creates a notifier sending webhooks to the addresses egress allows. publisher may
+// be nil, in which case no CloudEvents or Kubernetes Events are emitted.
+func New(client kubernetes.KubernetesClientInterface, publisher *Publisher, egress *Egress, logger *slog.Logger, interval time.Duration) *Notifier {
+	return &Notifier{
+		client:      client,
+		publisher:   publisher,
+		logger:      logger.With(slog.String("component", "notifier")),
+		interval:    interval,
+		identity:    kubernetes.BackgroundIdentity("notifier"),
+		httpClient:  egress.HTTPClient(deliveryTimeout),
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		retryDelay:  interval,
+		started:     time.Now(),
+		retries:     map[string]map[string]*retry{},
+	}
+}
+
+// Run checks for finished evaluations every interval until ctx is cancelled
+func (n *Notifier) Run(ctx context.Context) {
+	n.logger.Info("starting notifier", slog.Duration("interval", n.interval), slog.Bool("cloudEvents", n.publisher.SinkConfigured()))
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.Reconcile(ctx); err != nil {
+			n.logger.Error("failed to send evaluation notifications", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			n.logger.Info("stopping notifier")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile reports evaluations that finished since the last pass. Without a CloudEvents
+// sink only namespaces with subscriptions are watched.
+func (n *Notifier) Reconcile(ctx context.Context) error {
+	configMaps, err := n.client.ListConfigMaps(ctx, "", LabelSelector())
+	if err != nil {
+		return err
+	}
+
+	subscriptions := map[string][]*models.NotificationSubscription{}
+	for i := range configMaps {
+		subscription, err := FromConfigMap(&configMaps[i])
+		if err != nil {
+			n.logger.Warn("skipping unreadable subscription", slog.String("configmap", configMaps[i].Name), slog.Any("error", err))
+			continue
+		}
+		if !subscription.Disabled {
+			subscriptions[subscription.Namespace] = append(subscriptions[subscription.Namespace], subscription)
+		}
+	}
+
+	namespaces := make([]string, 0, len(subscriptions))
+	for namespace := range subscriptions {
+		namespaces = append(namespaces, namespace)
+	}
+	if n.publisher.SinkConfigured() {
+		namespaces = []string{""}
+	}
+
+	pending := map[string]bool{}
+	for _, namespace := range namespaces {
+		jobs, err := n.client.ListLMEvalJobs(ctx, n.identity, namespace)
+		if err != nil {
+			n.logger.Error("failed to list evaluations", slog.String("namespace", namespace), slog.Any("error", err))
+			continue
+		}
+		for i := range jobs.Items {
+			job := &jobs.Items[i]
+			if job.Status.IsFinished() && job.Metadata.Annotations[constants.NotifiedEventAnnotation] == "" {
+				pending[jobKey(job)] = true
+				n.reconcileJob(ctx, job, subscriptions[job.Metadata.Namespace])
+			}
+		}
+	}
+
+	// Forget the retries of evaluations that were deleted meanwhile
+	for key := range n.retries {
+		if !pending[key] {
+			delete(n.retries, key)
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) reconcileJob(ctx context.Context, job *models.LMEvalJobKind, subscriptions []*models.NotificationSubscription) {
+	now := time.Now()
+	event := NewEvent(job, now)
+	key := jobKey(job)
+
+	// Subscriptions that already received the event are listed on evaluations still pending
+	notified, retrying := job.Metadata.Annotations[constants.NotifiedSubscriptionsAnnotation]
+	delivered := map[string]bool{}
+	for _, name := range strings.Split(notified, ",") {
+		if name != "" {
+			delivered[name] = true
+		}
+	}
+
+	// Evaluations that finished before the notifier started were already reported, or
+	// predate the sink; publishing them all on the first pass would flood the broker
+	if !retrying && !finishedBefore(job, n.started) {
+		n.publisher.Publish(ctx, event)
+	}
+
+	failed := false
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Type) || finishedBefore(job, subscription.CreationTimestamp) || delivered[subscription.Name] {
+			continue
+		}
+		state := n.retries[key][subscription.Name]
+		if state != nil && now.Before(state.next) {
+			failed = true
+			continue
+		}
+		if n.notify(ctx, job, subscription, event) {
+			delivered[subscription.Name] = true
+			delete(n.retries[key], subscription.Name)
+			continue
+		}
+
+		failed = true
+		if state == nil {
+			state = &retry{}
+			if n.retries[key] == nil {
+				n.retries[key] = map[string]*retry{}
+			}
+			n.retries[key][subscription.Name] = state
+		}
+		state.failures++
+		state.next = now.Add(min(n.retryDelay<<(state.failures-1), maxRetryDelay))
+	}
+
+	annotations := map[string]any{}
+	switch {
+	case failed && finishedAfter(job, now.Add(-DeliveryRetryPeriod)):
+		names := make([]string, 0, len(delivered))
+		for name := range delivered {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if retrying && strings.Join(names, ",") == notified {
+			return
+		}
+		annotations[constants.NotifiedSubscriptionsAnnotation] = strings.Join(names, ",")
+	default:
+		if failed {
+			n.logger.Warn("giving up on undelivered notifications",
+				slog.String("evaluation", job.Metadata.Name),
+				slog.String("namespace", job.Metadata.Namespace),
+				slog.Duration("retryPeriod", DeliveryRetryPeriod))
+		}
+		delete(n.retries, key)
+		annotations[constants.NotifiedEventAnnotation] = event.Type
+		annotations[constants.NotifiedSubscriptionsAnnotation] = nil
+	}
+
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": annotations},
+	})
+	if _, err := n.client.PatchLMEvalJob(ctx, n.identity, job.Metadata.Namespace, job.Metadata.Name, patch); err != nil {
+		n.logger.Error("failed to mark evaluation as notified", slog.String("evaluation", job.Metadata.Name), slog.Any("error", err))
+	}
+}
+
+// notify delivers an event to a subscription and records the delivery, it reports
+// whether the subscription received it
+func (n *Notifier) notify(ctx context.Context, job *models.LMEvalJobKind, subscription *models.NotificationSubscription, event *models.NotificationEvent) bool {
+	signingKey, err := SigningKey(ctx, n.client, subscription.Namespace, subscription.Name)
+	if err != nil {
+		n.logger.Error("failed to read signing key", slog.String("subscription", subscription.Name), slog.Any("error", err))
+		return false
+	}
+
+	delivery := n.Deliver(ctx, subscription, signingKey, event)
+	if delivery.Success {
+		n.publisher.RecordEvent(ctx, job, corev1.EventTypeNormal, EventReasonNotificationSent,
+			fmt.Sprintf("Sent %s to notification subscription %s", event.Type, subscription.Name))
+	} else {
+		n.logger.Warn("webhook delivery failed",
+			slog.String("subscription", subscription.Name),
+			slog.String("namespace", subscription.Namespace),
+			slog.String("evaluation", event.Evaluation.Name),
+			slog.String("error", delivery.Error))
+		n.publisher.RecordEvent(ctx, job, corev1.EventTypeWarning, EventReasonNotificationFailed,
+			fmt.Sprintf("Failed to send %s to notification subscription %s: %s", event.Type, subscription.Name, delivery.Error))
+	}
+	if err := RecordDelivery(ctx, n.client, subscription.Namespace, subscription.Name, delivery); err != nil {
+		n.logger.Error("failed to record delivery", slog.String("subscription", subscription.Name), slog.Any("error", err))
+	}
+	return delivery.Success
+}
+
+// Deliver sends an event to a subscription, retrying network errors, 429 and 5xx responses
+func (n *Notifier) Deliver(ctx context.Context, subscription *models.NotificationSubscription, signingKey string, event *models.NotificationEvent) models.NotificationDelivery {
+	delivery := models.NotificationDelivery{
+		ID:           newDeliveryID(),
+		Subscription: subscription.Name,
+		Event:        event.Type,
+		Evaluation:   event.Evaluation.Name,
+		Timestamp:    time.Now().UTC(),
+	}
+
+	body, contentType, err := Payload(subscription.Format, event)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	header := http.Header{}
+	header.Set(EventHeader, event.Type)
+	header.Set(DeliveryHeader, delivery.ID)
+	sign := func(body []byte) string { return "" }
+	if signingKey != "" {
+		sign = func(body []byte) string { return Sign(signingKey, time.Now().Unix(), body) }
+	}
+
+	delivery.Attempts, delivery.StatusCode, err = postWithRetry(ctx, n.httpClient, subscription.URL, contentType, body, header, sign, n.maxAttempts, n.backoff)
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Success = true
+	}
+	return delivery
+}
+
+// postWithRetry posts body to url, retrying network errors, 429 and 5xx responses with
+// exponential backoff. sign computes the signature header for each attempt, an empty
+// result sends none. It returns the number of attempts and the last status code.
+func postWithRetry(ctx context.Context, httpClient *http.Client, url, contentType string, body []byte, header http.Header, sign func([]byte) string, maxAttempts int, backoff time.Duration) (int, int, error) {
+	var (
+		attempts   int
+		statusCode int
+		err        error
+	)
+	for attempts < maxAttempts {
+		if attempts > 0 {
+			select {
+			case <-ctx.Done():
+				return attempts, statusCode, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		attempts++
+
+		statusCode, err = post(ctx, httpClient, url, contentType, body, header, sign(body))
+		switch {
+		case err != nil:
+		case statusCode >= 200 && statusCode < 300:
+			return attempts, statusCode, nil
+		default:
+			err = fmt.Errorf("receiver responded with HTTP %d", statusCode)
+			if statusCode != http.StatusTooManyRequests && statusCode < 500 {
+				return attempts, statusCode, err
+			}
+		}
+	}
+	return attempts, statusCode, err
+}
+
+func post(ctx context.Context, httpClient *http.Client, url, contentType string, body []byte, header http.Header, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "trustyai-dashboard")
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// Sign computes the signature header value "t=<unix time>,v1=<hex HMAC-SHA256>". The
+// HMAC covers "<unix time>.<body>" so receivers can reject replayed deliveries.
+func Sign(signingKey string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewEvent describes a finished evaluation. The event ID is stable for the evaluation
+// and event type so receivers can drop duplicates.
+func NewEvent(job *models.LMEvalJobKind, now time.Time) *models.NotificationEvent {
+	eventType := models.NotificationEventCompleted
+	if job.Status.IsFailed() {
+		eventType = models.NotificationEventFailed
+	}
+	return newEvent(job, eventType, now)
+}
+
+func newEvent(job *models.LMEvalJobKind, eventType string, now time.Time) *models.NotificationEvent {
+	id := job.Metadata.UID
+	if id == "" {
+		id = job.Metadata.Namespace + "/" + job.Metadata.Name
+	}
+
+	evaluation := models.NotificationEvaluation{
+		Name:        job.Metadata.Name,
+		Namespace:   job.Metadata.Namespace,
+		DisplayName: job.Metadata.Annotations[constants.DisplayNameAnnotation],
+		Model:       job.ModelName(),
+		Tasks:       job.Spec.TaskList.TaskNames,
+	}
+	if job.Status != nil {
+		evaluation.State = job.Status.State
+		evaluation.Reason = job.Status.Reason
+		evaluation.Message = job.Status.Message
+		evaluation.CompleteTime = job.Status.CompleteTime
+		if eventType == models.NotificationEventCompleted {
+			// Results are informational, an unreadable result does not hold back the event
+			evaluation.Metrics, _ = models.ParseLMEvalResults(job.Status.Results)
+		}
+	}
+
+	return &models.NotificationEvent{
+		ID:         id + "/" + eventType,
+		Type:       eventType,
+		Time:       now.UTC(),
+		Evaluation: evaluation,
+	}
+}
+
+// finishedBefore reports whether the job completed before t. Subscriptions are not
+// notified about evaluations that finished before they were created.
+func finishedBefore(job *models.LMEvalJobKind, t time.Time) bool {
+	return job.Status.CompleteTime != nil && job.Status.CompleteTime.Before(t)
+}
+
+// finishedAfter reports whether the job completed after t, or was created after t when
+// its completion time is unknown
+func finishedAfter(job *models.LMEvalJobKind, t time.Time) bool {
+	if job.Status.CompleteTime != nil {
+		return job.Status.CompleteTime.After(t)
+	}
+	return job.Metadata.CreationTimestamp.After(t)
+}
+
+func jobKey(job *models.LMEvalJobKind) string {
+	return job.Metadata.Namespace + "/" + job.Metadata.Name
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
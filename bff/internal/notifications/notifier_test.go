@@ -0,0 +1,301 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// receiver is a local webhook endpoint that fails the first failures requests
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	if rc.failures > 0 {
+		rc.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// testEgress allows the loopback addresses the test receivers listen on
+var testEgress, _ = NewEgress("127.0.0.0/8, ::1/128")
+
+func newTestNotifier(client kubernetes.KubernetesClientInterface) *Notifier {
+	notifier := New(client, NewPublisher(client, slog.Default(), ""), testEgress, slog.Default(), time.Minute)
+	notifier.backoff = time.Millisecond
+	return notifier
+}
+
+func createSubscription(t *testing.T, client kubernetes.KubernetesClientInterface, subscription *models.NotificationSubscription) {
+	assert.NoError(t, Validate(subscription, testEgress))
+	configMap, err := ToConfigMap(subscription)
+	assert.NoError(t, err)
+	_, err = client.CreateConfigMap(context.Background(), "project-1", configMap)
+	assert.NoError(t, err)
+}
+
+func finishJob(t *testing.T, client kubernetes.KubernetesClientInterface, name, status string) {
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err := client.CreateLMEvalJob(context.Background(), identity, "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:        name,
+			Annotations: map[string]string{constants.DisplayNameAnnotation: "Llama " + name},
+		},
+		Spec: models.LMEvalJobSpec{
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: "llama"}},
+			TaskList:  models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+		},
+	})
+	assert.NoError(t, err)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, "project-1", name, []byte(status))
+	assert.NoError(t, err)
+}
+
+func TestReconcileDeliversSignedWebhooksOnce(t *testing.T) {
+	rc := &receiver{failures: 1}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createSubscription(t, client, &models.NotificationSubscription{Name: "ci", URL: server.URL, Signed: true})
+	_, err := client.CreateSecret(context.Background(), "project-1", ToSecret("ci", "s3cr3t"))
+	assert.NoError(t, err)
+
+	finishJob(t, client, "done", `{"status":{"state":"Complete","results":"{\"results\":{\"arc_easy\":{\"acc,none\":0.8}}}"}}`)
+	finishJob(t, client, "running", `{"status":{"state":"Running"}}`)
+
+	notifier := newTestNotifier(client)
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+
+	// The first attempt got a 503 and was retried
+	assert.Len(t, rc.requests, 2)
+	request := rc.requests[1]
+	assert.Equal(t, models.NotificationEventCompleted, request.Header.Get(EventHeader))
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+	signature := request.Header.Get(SignatureHeader)
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, Sign("s3cr3t", timestamp, rc.bodies[1]), signature)
+
+	var event models.NotificationEvent
+	assert.NoError(t, json.Unmarshal(rc.bodies[1], &event))
+	assert.Equal(t, "done", event.Evaluation.Name)
+	assert.Equal(t, "llama", event.Evaluation.Model)
+	assert.Len(t, event.Evaluation.Metrics, 1)
+
+	deliveries, err := ListDeliveries(context.Background(), client, "project-1", "ci")
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Success)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].StatusCode)
+
+	// The evaluation is marked, a second pass sends nothing
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+	assert.Len(t, rc.requests, 2)
+}
+
+func TestReconcileRetriesFailedDeliveries(t *testing.T) {
+	healthy := &receiver{}
+	healthyServer := httptest.NewServer(healthy)
+	defer healthyServer.Close()
+	flaky := &receiver{failures: defaultMaxAttempts}
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createSubscription(t, client, &models.NotificationSubscription{Name: "healthy", URL: healthyServer.URL})
+	createSubscription(t, client, &models.NotificationSubscription{Name: "flaky", URL: flakyServer.URL})
+	finishJob(t, client, "done", `{"status":{"state":"Complete"}}`)
+
+	notifier := newTestNotifier(client)
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+	assert.Len(t, healthy.requests, 1)
+	assert.Len(t, flaky.requests, defaultMaxAttempts)
+
+	// The evaluation stays pending, and is not retried before its delay
+	identity := kubernetes.BackgroundIdentity("test")
+	job, err := client.GetLMEvalJob(context.Background(), identity, "project-1", "done")
+	assert.NoError(t, err)
+	assert.Empty(t, job.Metadata.Annotations[constants.NotifiedEventAnnotation])
+	assert.Equal(t, "healthy", job.Metadata.Annotations[constants.NotifiedSubscriptionsAnnotation])
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+	assert.Len(t, flaky.requests, defaultMaxAttempts)
+
+	// Only the failed delivery is sent again
+	notifier.retries["project-1/done"]["flaky"].next = time.Now()
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+	assert.Len(t, healthy.requests, 1)
+	assert.Len(t, flaky.requests, defaultMaxAttempts+1)
+
+	job, err = client.GetLMEvalJob(context.Background(), identity, "project-1", "done")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NotificationEventCompleted, job.Metadata.Annotations[constants.NotifiedEventAnnotation])
+	assert.NotContains(t, job.Metadata.Annotations, constants.NotifiedSubscriptionsAnnotation)
+	assert.Empty(t, notifier.retries)
+}
+
+func TestReconcileGivesUpOnOldEvaluations(t *testing.T) {
+	rc := &receiver{failures: defaultMaxAttempts}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	completeTime := time.Now().Add(-DeliveryRetryPeriod - time.Minute).UTC().Format(time.RFC3339)
+	finishJob(t, client, "done", `{"status":{"state":"Complete","completeTime":"`+completeTime+`"}}`)
+	job, err := client.GetLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "done")
+	assert.NoError(t, err)
+
+	// The subscription predates the evaluation
+	subscription := &models.NotificationSubscription{Name: "ci", Namespace: "project-1", URL: server.URL, Format: models.NotificationFormatGeneric}
+	newTestNotifier(client).reconcileJob(context.Background(), job, []*models.NotificationSubscription{subscription})
+
+	assert.Len(t, rc.requests, defaultMaxAttempts)
+	job, err = client.GetLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", "done")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NotificationEventCompleted, job.Metadata.Annotations[constants.NotifiedEventAnnotation])
+}
+
+func TestDeliverRefusesNonPublicAddresses(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	notifier := New(client, nil, nil, slog.Default(), time.Minute)
+	notifier.backoff = time.Millisecond
+	event := &models.NotificationEvent{ID: "project-1/llama/evaluation.completed", Type: models.NotificationEventCompleted}
+
+	// Subscriptions are validated when saved, the dialer checks the resolved address too
+	subscription := &models.NotificationSubscription{Name: "ci", URL: strings.Replace(server.URL, "127.0.0.1", "localhost", 1)}
+	delivery := notifier.Deliver(context.Background(), subscription, "", event)
+	assert.False(t, delivery.Success)
+	assert.Contains(t, delivery.Error, "non-public address")
+	assert.Empty(t, rc.requests)
+}
+
+func TestReconcileFiltersEventsAndOldEvaluations(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createSubscription(t, client, &models.NotificationSubscription{
+		Name:   "failures",
+		URL:    server.URL,
+		Format: models.NotificationFormatSlack,
+		Events: []string{models.NotificationEventFailed},
+	})
+
+	finishJob(t, client, "passed", `{"status":{"state":"Complete"}}`)
+	finishJob(t, client, "broken", `{"status":{"state":"Complete","reason":"Failed","message":"pod OOMKilled"}}`)
+	finishJob(t, client, "old", `{"status":{"state":"Complete","reason":"Failed","completeTime":"2020-01-01T00:00:00Z"}}`)
+
+	assert.NoError(t, newTestNotifier(client).Reconcile(context.Background()))
+
+	assert.Len(t, rc.requests, 1)
+	assert.Empty(t, rc.requests[0].Header.Get(SignatureHeader))
+	var message map[string]string
+	assert.NoError(t, json.Unmarshal(rc.bodies[0], &message))
+	assert.Contains(t, message["text"], "*Llama broken* failed")
+	assert.Contains(t, message["text"], "pod OOMKilled")
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	notifier := newTestNotifier(kubernetes.NewMockKubernetesClient(slog.Default()))
+	event := &models.NotificationEvent{ID: "project-1/llama/evaluation.completed", Type: models.NotificationEventCompleted}
+	delivery := notifier.Deliver(context.Background(), &models.NotificationSubscription{
+		Name:   "cloud",
+		URL:    server.URL,
+		Format: models.NotificationFormatCloudEvents,
+	}, "", event)
+
+	assert.False(t, delivery.Success)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusGone, delivery.StatusCode)
+}
+
+func TestCloudEventPayload(t *testing.T) {
+	event := &models.NotificationEvent{
+		ID:         "uid-1/evaluation.failed",
+		Type:       models.NotificationEventFailed,
+		Evaluation: models.NotificationEvaluation{Name: "llama", Namespace: "project-1"},
+	}
+	body, contentType, err := Payload(models.NotificationFormatCloudEvents, event)
+	assert.NoError(t, err)
+	assert.Equal(t, CloudEventsContentType, contentType)
+
+	var cloudEvent map[string]any
+	assert.NoError(t, json.Unmarshal(body, &cloudEvent))
+	assert.Equal(t, "1.0", cloudEvent["specversion"])
+	assert.Equal(t, "io.trustyai.evaluation.failed", cloudEvent["type"])
+	assert.Equal(t, "/trustyai-dashboard/namespaces/project-1/evaluations", cloudEvent["source"])
+	assert.Equal(t, "llama", cloudEvent["subject"])
+}
+
+func TestValidateSubscription(t *testing.T) {
+	subscription := &models.NotificationSubscription{Name: "ci", URL: "https://hooks.example.com/x"}
+	assert.NoError(t, Validate(subscription, nil))
+	assert.Equal(t, models.NotificationFormatGeneric, subscription.Format)
+
+	// Non-public addresses are only accepted in the allowed ranges
+	egress, err := NewEgress("10.96.0.0/12")
+	assert.NoError(t, err)
+	assert.NoError(t, Validate(&models.NotificationSubscription{Name: "ci", URL: "http://10.96.0.10:8080/hook"}, egress))
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost:8080/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[::ffff:10.0.0.5]/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://100.64.0.1/hook",
+	} {
+		assert.Error(t, Validate(&models.NotificationSubscription{Name: "ci", URL: url}, egress), url)
+	}
+	_, err = NewEgress("10.96.0.0/33")
+	assert.Error(t, err)
+
+	for _, invalid := range []models.NotificationSubscription{
+		{Name: "", URL: "https://hooks.example.com"},
+		{Name: "Bad_Name", URL: "https://hooks.example.com"},
+		{Name: "ci", URL: "ftp://hooks.example.com"},
+		{Name: "ci", URL: "/relative"},
+		{Name: "ci", URL: "https://hooks.example.com", Format: "teams"},
+		{Name: "ci", URL: "https://hooks.example.com", Events: []string{"evaluation.started"}},
+	} {
+		assert.Error(t, Validate(&invalid, nil), invalid)
+	}
+}
@@ -0,0 +1,201 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Reasons of the Kubernetes Events recorded on LMEvalJobs
+const (
+	EventReasonCreated            = "EvaluationCreated"
+	EventReasonNotificationSent   = "NotificationSent"
+	EventReasonNotificationFailed = "NotificationFailed"
+)
+
+const (
+	eventSourceComponent = "trustyai-dashboard"
+	sinkMaxAttempts      = 2
+	sinkBackoff          = 500 * time.Millisecond
+	sinkTimeout          = 5 * time.Second
+
+	// publishQueueSize is how many CloudEvents wait for the sink before new ones are dropped
+	publishQueueSize = 256
+)
+
+// Publisher records dashboard actions as Kubernetes Events on LMEvalJobs and publishes
+// lifecycle CloudEvents to a sink such as a Knative broker. Both are best effort: failures
+// are logged and never fail the action that triggered them. A nil Publisher does nothing.
+type Publisher struct {
+	client     kubernetes.KubernetesClientInterface
+	logger     *slog.Logger
+	sinkURL    string
+	httpClient *http.Client
+
+	// Events queued by Enqueue are sent one at a time by a goroutine closing done on exit
+	mu     sync.RWMutex
+	queue  chan *models.NotificationEvent
+	closed bool
+	done   chan struct{}
+}
+
+// NewPublisher creates a publisher recording Events with client. CloudEvents are only
+// published when sinkURL is set; the publisher must then be closed to stop sending the
+// queued ones.
+func NewPublisher(client kubernetes.KubernetesClientInterface, logger *slog.Logger, sinkURL string) *Publisher {
+	p := &Publisher{
+		client:     client,
+		logger:     logger.With(slog.String("component", "publisher")),
+		sinkURL:    sinkURL,
+		httpClient: &http.Client{Timeout: sinkTimeout},
+	}
+	if sinkURL != "" {
+		p.queue = make(chan *models.NotificationEvent, publishQueueSize)
+		p.done = make(chan struct{})
+		go p.send()
+	}
+	return p
+}
+
+// Close sends the events still queued and stops the publisher
+func (p *Publisher) Close() error {
+	if !p.SinkConfigured() {
+		return nil
+	}
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	<-p.done
+	return nil
+}
+
+// SinkConfigured reports whether lifecycle CloudEvents are published
+func (p *Publisher) SinkConfigured() bool {
+	return p != nil && p.sinkURL != ""
+}
+
+// Publish sends a lifecycle event to the CloudEvents sink
+func (p *Publisher) Publish(ctx context.Context, event *models.NotificationEvent) {
+	if !p.SinkConfigured() {
+		return
+	}
+
+	body, contentType, err := Payload(models.NotificationFormatCloudEvents, event)
+	if err != nil {
+		p.logger.Error("failed to encode cloud event", slog.String("type", event.Type), slog.Any("error", err))
+		return
+	}
+	noSignature := func([]byte) string { return "" }
+	if _, _, err := postWithRetry(ctx, p.httpClient, p.sinkURL, contentType, body, http.Header{}, noSignature, sinkMaxAttempts, sinkBackoff); err != nil {
+		p.logger.Warn("failed to publish cloud event",
+			slog.String("type", event.Type),
+			slog.String("evaluation", event.Evaluation.Name),
+			slog.String("namespace", event.Evaluation.Namespace),
+			slog.Any("error", err))
+	}
+}
+
+// Enqueue publishes a lifecycle event in the background, so that a slow sink does not hold
+// up the request that triggered it. Events are dropped while the queue is full.
+func (p *Publisher) Enqueue(event *models.NotificationEvent) {
+	if !p.SinkConfigured() {
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.queue <- event:
+	default:
+		p.logger.Warn("dropping cloud event, too many are waiting for the sink",
+			slog.String("type", event.Type),
+			slog.String("evaluation", event.Evaluation.Name),
+			slog.String("namespace", event.Evaluation.Namespace))
+	}
+}
+
+// send publishes queued events until the queue is closed. They outlive the requests that
+// queued them, so each is sent with a context of its own.
+func (p *Publisher) send() {
+	defer close(p.done)
+	for event := range p.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), sinkMaxAttempts*(sinkTimeout+sinkBackoff))
+		p.Publish(ctx, event)
+		cancel()
+	}
+}
+
+// RecordEvent records a Kubernetes Event on an LMEvalJob so that it shows up in
+// `kubectl describe`. eventType is corev1.EventTypeNormal or corev1.EventTypeWarning.
+func (p *Publisher) RecordEvent(ctx context.Context, job *models.LMEvalJobKind, eventType, reason, message string) {
+	if p == nil {
+		return
+	}
+
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", job.Metadata.Name, now.UnixNano()),
+			Namespace: job.Metadata.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      job.APIVersion,
+			Kind:            job.Kind,
+			Name:            job.Metadata.Name,
+			Namespace:       job.Metadata.Namespace,
+			UID:             types.UID(job.Metadata.UID),
+			ResourceVersion: job.Metadata.ResourceVersion,
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		Source:              corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		ReportingController: eventSourceComponent,
+	}
+	if err := p.client.CreateEvent(ctx, job.Metadata.Namespace, event); err != nil {
+		p.logger.Warn("failed to record event",
+			slog.String("evaluation", job.Metadata.Name),
+			slog.String("namespace", job.Metadata.Namespace),
+			slog.String("reason", reason),
+			slog.Any("error", err))
+	}
+}
+
+// Created records the creation of an evaluation and queues its CloudEvent. actor is the
+// user, or the schedule, that created it.
+func (p *Publisher) Created(ctx context.Context, job *models.LMEvalJobKind, actor string) {
+	if p == nil {
+		return
+	}
+	p.RecordEvent(ctx, job, corev1.EventTypeNormal, EventReasonCreated, "Created by "+actor+" from the TrustyAI dashboard")
+	p.Enqueue(NewCreatedEvent(job, time.Now()))
+}
+
+// NewCreatedEvent describes a newly created evaluation
+func NewCreatedEvent(job *models.LMEvalJobKind, now time.Time) *models.NotificationEvent {
+	event := newEvent(job, models.NotificationEventCreated, now)
+	if job.Metadata.UID == "" {
+		// Without a UID the name may be reused, keep the ID unique
+		event.ID = uuid.NewString()
+	}
+	return event
+}
@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPublisherCreatedRecordsAndPublishes(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	job, err := client.CreateLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: "llama"},
+		Spec: models.LMEvalJobSpec{
+			TaskList: models.LMEvalJobTaskList{TaskNames: []string{"arc_easy"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	publisher := NewPublisher(client, slog.Default(), server.URL)
+	publisher.Created(context.Background(), job, "alice")
+	assert.NoError(t, publisher.Close())
+
+	events, err := client.ListEvents(context.Background(), "project-1", "llama")
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventReasonCreated, events[0].Reason)
+	assert.Equal(t, corev1.EventTypeNormal, events[0].Type)
+	assert.Contains(t, events[0].Message, "alice")
+	assert.Equal(t, job.Metadata.UID, string(events[0].InvolvedObject.UID))
+
+	assert.Len(t, rc.requests, 1)
+	assert.Equal(t, CloudEventsContentType, rc.requests[0].Header.Get("Content-Type"))
+	var cloudEvent map[string]any
+	assert.NoError(t, json.Unmarshal(rc.bodies[0], &cloudEvent))
+	assert.Equal(t, "io.trustyai.evaluation.created", cloudEvent["type"])
+	assert.Equal(t, "llama", cloudEvent["subject"])
+}
+
+func TestPublisherCreatedDoesNotWaitForTheSink(t *testing.T) {
+	release := make(chan struct{})
+	var published atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		published.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	job, err := client.CreateLMEvalJob(context.Background(), kubernetes.BackgroundIdentity("test"), "project-1", &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: "llama"},
+	})
+	assert.NoError(t, err)
+
+	// The request's context ends with the request, the event is still published
+	publisher := NewPublisher(client, slog.Default(), server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	publisher.Created(ctx, job, "alice")
+	cancel()
+	assert.Zero(t, published.Load())
+
+	close(release)
+	assert.NoError(t, publisher.Close())
+	assert.Equal(t, int32(1), published.Load())
+}
+
+func TestReconcilePublishesToSinkAndRecordsEvents(t *testing.T) {
+	sink := &receiver{}
+	sinkServer := httptest.NewServer(sink)
+	defer sinkServer.Close()
+	hook := &receiver{failures: 3}
+	hookServer := httptest.NewServer(hook)
+	defer hookServer.Close()
+
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createSubscription(t, client, &models.NotificationSubscription{Name: "ci", URL: hookServer.URL})
+	finishJob(t, client, "done", `{"status":{"state":"Complete"}}`)
+	finishJob(t, client, "old", `{"status":{"state":"Complete","completeTime":"2020-01-01T00:00:00Z"}}`)
+
+	publisher := NewPublisher(client, slog.Default(), sinkServer.URL)
+	defer publisher.Close()
+	notifier := New(client, publisher, testEgress, slog.Default(), time.Minute)
+	notifier.backoff = time.Millisecond
+	assert.NoError(t, notifier.Reconcile(context.Background()))
+
+	// Only the evaluation that finished after the notifier started reaches the sink
+	assert.Len(t, sink.requests, 1)
+	var cloudEvent map[string]any
+	assert.NoError(t, json.Unmarshal(sink.bodies[0], &cloudEvent))
+	assert.Equal(t, "io.trustyai.evaluation.completed", cloudEvent["type"])
+	assert.Equal(t, "done", cloudEvent["subject"])
+
+	// The webhook kept failing, which is recorded on the evaluation
+	events, err := client.ListEvents(context.Background(), "project-1", "done")
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventReasonNotificationFailed, events[0].Reason)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+}
@@ -0,0 +1,236 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	resourcePrefix  = "eval-notify-"
+	historySuffix   = "-deliveries"
+	subscriptionKey = "subscription.json"
+	deliveriesKey   = "deliveries.json"
+	signingKeyKey   = "signing-key"
+
+	// MaxDeliveryHistory is how many deliveries are kept per subscription
+	MaxDeliveryHistory = 50
+)
+
+// subscriptionSpec is where and what a subscription delivers. Its deliveries are kept in
+// their own ConfigMap, see HistoryConfigMapName.
+type subscriptionSpec struct {
+	URL      string   `json:"url"`
+	Format   string   `json:"format"`
+	Events   []string `json:"events,omitempty"`
+	Disabled bool     `json:"disabled,omitempty"`
+	Signed   bool     `json:"signed,omitempty"`
+}
+
+// ConfigMapName returns the name of the ConfigMap storing the named subscription.
+// Its signing key is kept in a Secret of the same name.
+func ConfigMapName(name string) string {
+	return resourcePrefix + name
+}
+
+// HistoryConfigMapName returns the name of the ConfigMap holding a subscription's deliveries
+func HistoryConfigMapName(name string) string {
+	return resourcePrefix + name + historySuffix
+}
+
+// LabelSelector selects the ConfigMaps storing subscriptions
+func LabelSelector() string {
+	return constants.NotificationSubscriptionLabel + "=true"
+}
+
+// Validate checks a subscription before it is stored and fills in the default format.
+// Its URL must be allowed by egress.
+func Validate(subscription *models.NotificationSubscription, egress *Egress) error {
+	if subscription.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if errs := validation.IsDNS1123Label(subscription.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid subscription name %q: %s", subscription.Name, strings.Join(errs, ", "))
+	}
+	// Leave room for the prefix and the delivery history suffix
+	if len(subscription.Name) > 40 {
+		return fmt.Errorf("subscription name must be no more than 40 characters")
+	}
+
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	if err := egress.CheckURL(target); err != nil {
+		return err
+	}
+
+	switch subscription.Format {
+	case "":
+		subscription.Format = models.NotificationFormatGeneric
+	case models.NotificationFormatGeneric, models.NotificationFormatSlack, models.NotificationFormatCloudEvents:
+	default:
+		return fmt.Errorf("unsupported format %q, expected generic, slack or cloudevents", subscription.Format)
+	}
+
+	for _, event := range subscription.Events {
+		if event != models.NotificationEventCompleted && event != models.NotificationEventFailed {
+			return fmt.Errorf("unsupported event %q, expected %s or %s", event, models.NotificationEventCompleted, models.NotificationEventFailed)
+		}
+	}
+	return nil
+}
+
+// ToConfigMap serialises a subscription into the ConfigMap that stores it
+func ToConfigMap(subscription *models.NotificationSubscription) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(subscriptionSpec{
+		URL:      subscription.URL,
+		Format:   subscription.Format,
+		Events:   subscription.Events,
+		Disabled: subscription.Disabled,
+		Signed:   subscription.Signed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode subscription: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ConfigMapName(subscription.Name),
+			ResourceVersion: subscription.ResourceVersion,
+			Labels: map[string]string{
+				constants.NotificationSubscriptionLabel: "true",
+			},
+			Annotations: map[string]string{
+				constants.CreatedByAnnotation: subscription.CreatedBy,
+			},
+		},
+		Data: map[string]string{
+			subscriptionKey: string(data),
+		},
+	}, nil
+}
+
+// FromConfigMap reads a subscription back from its ConfigMap
+func FromConfigMap(configMap *corev1.ConfigMap) (*models.NotificationSubscription, error) {
+	if configMap.Labels[constants.NotificationSubscriptionLabel] != "true" {
+		return nil, fmt.Errorf("configmap %q does not hold a notification subscription", configMap.Name)
+	}
+
+	var spec subscriptionSpec
+	if err := json.Unmarshal([]byte(configMap.Data[subscriptionKey]), &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription %q: %w", configMap.Name, err)
+	}
+
+	return &models.NotificationSubscription{
+		Name:              strings.TrimPrefix(configMap.Name, resourcePrefix),
+		Namespace:         configMap.Namespace,
+		URL:               spec.URL,
+		Format:            spec.Format,
+		Events:            spec.Events,
+		Disabled:          spec.Disabled,
+		Signed:            spec.Signed,
+		ResourceVersion:   configMap.ResourceVersion,
+		CreatedBy:         configMap.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp: configMap.CreationTimestamp.Time,
+	}, nil
+}
+
+// ToSecret builds the Secret holding a subscription's signing key
+func ToSecret(name, signingKey string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ConfigMapName(name),
+			Labels: map[string]string{
+				constants.NotificationSubscriptionLabel: "true",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			signingKeyKey: []byte(signingKey),
+		},
+	}
+}
+
+// SigningKey returns a subscription's signing key, empty when deliveries are not signed
+func SigningKey(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, name string) (string, error) {
+	secret, err := client.GetSecret(ctx, namespace, ConfigMapName(name))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(secret.Data[signingKeyKey]), nil
+}
+
+// ListDeliveries returns a subscription's recorded deliveries, newest first
+func ListDeliveries(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, name string) ([]models.NotificationDelivery, error) {
+	configMap, err := client.GetConfigMap(ctx, namespace, HistoryConfigMapName(name))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []models.NotificationDelivery{}, nil
+		}
+		return nil, err
+	}
+	return decodeDeliveries(configMap)
+}
+
+// RecordDelivery adds a delivery to the subscription's history, keeping the newest MaxDeliveryHistory
+func RecordDelivery(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace, name string, delivery models.NotificationDelivery) error {
+	configMap, err := client.GetConfigMap(ctx, namespace, HistoryConfigMapName(name))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	create := configMap == nil
+	deliveries := []models.NotificationDelivery{}
+	if create {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: HistoryConfigMapName(name),
+				Labels: map[string]string{
+					constants.NotificationDeliveriesLabel: name,
+				},
+			},
+		}
+	} else if deliveries, err = decodeDeliveries(configMap); err != nil {
+		// Start over rather than failing every delivery on a corrupted history
+		deliveries = []models.NotificationDelivery{}
+	}
+
+	deliveries = append([]models.NotificationDelivery{delivery}, deliveries...)
+	if len(deliveries) > MaxDeliveryHistory {
+		deliveries = deliveries[:MaxDeliveryHistory]
+	}
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		return fmt.Errorf("failed to encode deliveries: %w", err)
+	}
+	configMap.Data = map[string]string{deliveriesKey: string(data)}
+
+	if create {
+		_, err = client.CreateConfigMap(ctx, namespace, configMap)
+	} else {
+		_, err = client.UpdateConfigMap(ctx, namespace, configMap)
+	}
+	return err
+}
+
+func decodeDeliveries(configMap *corev1.ConfigMap) ([]models.NotificationDelivery, error) {
+	deliveries := []models.NotificationDelivery{}
+	if err := json.Unmarshal([]byte(configMap.Data[deliveriesKey]), &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode deliveries %q: %w", configMap.Name, err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,40 @@
+// Command clientgen generates the Go client of the BFF API from openapi.yaml.
+//
+//	go run ./internal/openapi/clientgen -spec openapi.yaml -out pkg/client/client.gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/openapi"
+)
+
+func main() {
+	spec := flag.String("spec", "openapi.yaml", "path of the OpenAPI document")
+	out := flag.String("out", "client.gen.go", "path of the generated Go file")
+	packageName := flag.String("package", "client", "package of the generated Go file")
+	flag.Parse()
+
+	if err := generate(*spec, *out, *packageName); err != nil {
+		fmt.Fprintf(os.Stderr, "clientgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(spec, out, packageName string) error {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+	doc, err := openapi.Load(data)
+	if err != nil {
+		return err
+	}
+	source, err := openapi.GenerateClient(doc, packageName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, source, 0o644)
+}
@@ -0,0 +1,378 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words spelled in upper case in Go identifiers
+var initialisms = map[string]bool{"api": true, "ci": true, "id": true, "uid": true, "url": true}
+
+// GenerateClient renders the Go source of a client for every operation of doc: a
+// type per component schema and a Client method per operation. The Client type and
+// its do method are hand-written in the target package.
+func GenerateClient(doc *Document, packageName string) ([]byte, error) {
+	g := &generator{doc: doc, imports: map[string]bool{}}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.writeType(name, doc.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for _, op := range doc.Operations() {
+		if err := g.writeOperation(op); err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.OperationID, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by clientgen from openapi.yaml. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", packageName)
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	fmt.Fprintf(&out, "import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	fmt.Fprintf(&out, ")\n")
+	out.Write(g.body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated client: %w", err)
+	}
+	return source, nil
+}
+
+type generator struct {
+	doc     *Document
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+func (g *generator) writeType(name string, schema *Schema) error {
+	if schema.Type != "object" || len(schema.Properties) == 0 {
+		return fmt.Errorf("only objects with properties can be generated")
+	}
+	g.printf("\n")
+	if schema.Description != "" {
+		g.printf("// %s is %s\n", name, lowerFirst(schema.Description))
+	}
+	g.printf("type %s struct {\n", name)
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	for _, property := range properties {
+		propertySchema := schema.Properties[property]
+		required := schema.IsRequired(property)
+		goType, err := g.goType(propertySchema, required)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", property, err)
+		}
+		tag := property
+		if !required {
+			tag += ",omitempty"
+		}
+		if propertySchema.Description != "" {
+			g.printf("\t// %s\n", strings.TrimSpace(propertySchema.Description))
+		}
+		g.printf("\t%s %s `json:%q`\n", GoName(property), goType, tag)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// goType maps a schema to a Go type. Optional scalars are pointers so that their
+// zero value can be told apart from an absent one.
+func (g *generator) goType(schema *Schema, required bool) (string, error) {
+	pointer := ""
+	if !required {
+		pointer = "*"
+	}
+	if schema.Ref != "" {
+		if _, err := g.doc.Resolve(schema); err != nil {
+			return "", err
+		}
+		return pointer + schema.RefName(), nil
+	}
+	switch schema.Type {
+	case "":
+		// a schema without a type accepts any value
+		return "any", nil
+	case "string":
+		if schema.Format == "date-time" {
+			g.imports["time"] = true
+			return pointer + "time.Time", nil
+		}
+		return pointer + "string", nil
+	case "integer":
+		return pointer + "int", nil
+	case "number":
+		return pointer + "float64", nil
+	case "boolean":
+		return pointer + "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := g.goType(schema.Items, true)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if len(schema.Properties) > 0 {
+			return "", fmt.Errorf("inline objects are not supported, declare a component schema")
+		}
+		if schema.AdditionalProperties == nil {
+			return "map[string]any", nil
+		}
+		value, err := g.goType(schema.AdditionalProperties, true)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + value, nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q", schema.Type)
+}
+
+func (g *generator) writeOperation(op *Operation) error {
+	name := GoName(op.OperationID)
+	query := op.ParametersIn("query")
+	result, err := g.resultType(op)
+	if err != nil {
+		return err
+	}
+
+	if len(query) > 0 {
+		if err := g.writeParams(name+"Params", query); err != nil {
+			return err
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, param := range op.ParametersIn("path") {
+		args = append(args, param.Name+" string")
+	}
+	if len(query) > 0 {
+		args = append(args, "params *"+name+"Params")
+	}
+	body := "nil"
+	if op.RequestBody != nil {
+		// Multipart forms, such as file uploads, are streamed as built by the caller
+		if _, ok := op.RequestBody.Content[ContentTypeMultipart]; ok {
+			args = append(args, "body *RawBody")
+		} else {
+			media, ok := op.RequestBody.Content[ContentTypeJSON]
+			if !ok || media.Schema == nil || media.Schema.Ref == "" {
+				return fmt.Errorf("request bodies must reference a JSON component schema or be multipart forms")
+			}
+			args = append(args, "body *"+media.Schema.RefName())
+		}
+		body = "body"
+	}
+	returns := "error"
+	if result != "" {
+		returns = "(" + result + ", error)"
+	}
+	g.imports["context"] = true
+
+	g.printf("\n")
+	g.printf("// %s calls %s %s to %s\n", name, op.Method, op.Path, lowerFirst(op.Summary))
+	g.printf("func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	queryArg := "nil"
+	if len(query) > 0 {
+		g.imports["net/url"] = true
+		queryArg = "query"
+		g.printf("query := url.Values{}\n")
+		g.printf("if params != nil {\n")
+		for _, param := range query {
+			if err := g.writeQueryParam(param); err != nil {
+				return err
+			}
+		}
+		g.printf("}\n")
+	}
+
+	g.imports["net/http"] = true
+	method := "http.Method" + string(op.Method[0]) + strings.ToLower(op.Method[1:])
+	call := fmt.Sprintf("c.do(ctx, %s, %s, %s, %s", method, g.pathExpression(op.Path), queryArg, body)
+	switch {
+	case result == "":
+		g.printf("return %s, nil)\n", call)
+	case result == "[]byte":
+		g.printf("var out []byte\n")
+		g.printf("if err := %s, &out); err != nil {\nreturn nil, err\n}\n", call)
+		g.printf("return out, nil\n")
+	default:
+		g.printf("out := &%s{}\n", strings.TrimPrefix(result, "*"))
+		g.printf("if err := %s, out); err != nil {\nreturn nil, err\n}\n", call)
+		g.printf("return out, nil\n")
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// resultType returns the Go type of the successful responses of op: a component
+// schema for JSON, raw bytes when several media types are offered, nothing when
+// the responses have no content
+func (g *generator) resultType(op *Operation) (string, error) {
+	statuses := op.SuccessStatuses()
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("no successful response documented")
+	}
+	result := ""
+	for i, status := range statuses {
+		var current string
+		content := op.Responses[status].Content
+		switch {
+		case len(content) == 0:
+			current = ""
+		case len(content) > 1:
+			current = "[]byte"
+		default:
+			media, ok := content[ContentTypeJSON]
+			if !ok || media.Schema == nil || media.Schema.Ref == "" {
+				return "", fmt.Errorf("response %s must reference a JSON component schema", status)
+			}
+			current = "*" + media.Schema.RefName()
+		}
+		if i > 0 && current != result {
+			return "", fmt.Errorf("successful responses must share the same content")
+		}
+		result = current
+	}
+	return result, nil
+}
+
+func (g *generator) writeParams(name string, params []*Parameter) error {
+	g.printf("\n// %s holds the query parameters of %s\n", name, strings.TrimSuffix(name, "Params"))
+	g.printf("type %s struct {\n", name)
+	for _, param := range params {
+		goType, err := g.paramType(param)
+		if err != nil {
+			return err
+		}
+		if param.Description != "" {
+			g.printf("\t// %s\n", strings.TrimSpace(param.Description))
+		}
+		g.printf("\t%s %s\n", GoName(param.Name), goType)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// paramType maps a query parameter to a Go type, strings are sent when not empty
+// and other types when not nil
+func (g *generator) paramType(param *Parameter) (string, error) {
+	if param.Schema == nil {
+		return "", fmt.Errorf("parameter %s has no schema", param.Name)
+	}
+	switch param.Schema.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "*int", nil
+	case "boolean":
+		return "*bool", nil
+	}
+	return "", fmt.Errorf("parameter %s: unsupported type %q", param.Name, param.Schema.Type)
+}
+
+func (g *generator) writeQueryParam(param *Parameter) error {
+	field := "params." + GoName(param.Name)
+	switch param.Schema.Type {
+	case "string":
+		g.printf("if %s != \"\" {\nquery.Set(%q, %s)\n}\n", field, param.Name, field)
+	case "integer":
+		g.imports["strconv"] = true
+		g.printf("if %s != nil {\nquery.Set(%q, strconv.Itoa(*%s))\n}\n", field, param.Name, field)
+	case "boolean":
+		g.imports["strconv"] = true
+		g.printf("if %s != nil {\nquery.Set(%q, strconv.FormatBool(*%s))\n}\n", field, param.Name, field)
+	default:
+		return fmt.Errorf("parameter %s: unsupported type %q", param.Name, param.Schema.Type)
+	}
+	return nil
+}
+
+// pathExpression turns /evaluations/{name}/logs into a Go expression escaping the
+// path parameters
+func (g *generator) pathExpression(p string) string {
+	var parts []string
+	for {
+		start := strings.Index(p, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(p[start:], "}") + start
+		g.imports["net/url"] = true
+		parts = append(parts, fmt.Sprintf("%q", p[:start]), "url.PathEscape("+p[start+1:end]+")")
+		p = p[end+1:]
+	}
+	if p != "" {
+		parts = append(parts, fmt.Sprintf("%q", p))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func lowerFirst(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// GoName turns a JSON property or operation id into an exported Go identifier,
+// e.g. userId becomes UserID and system_info becomes SystemInfo
+func GoName(name string) string {
+	var words []string
+	var word []rune
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			words, word = append(words, string(word)), nil
+		case unicode.IsUpper(r) && len(word) > 0:
+			words, word = append(words, string(word)), []rune{r}
+		default:
+			word = append(word, r)
+		}
+	}
+	words = append(words, string(word))
+
+	var b strings.Builder
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		runes := []rune(w)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
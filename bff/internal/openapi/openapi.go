@@ -0,0 +1,274 @@
+// Package openapi reads the subset of OpenAPI 3 used by openapi.yaml. It backs the
+// contract test of the API router and the generator of the Go client.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	schemaRefPrefix    = "#/components/schemas/"
+	parameterRefPrefix = "#/components/parameters/"
+	responseRefPrefix  = "#/components/responses/"
+
+	ContentTypeJSON      = "application/json"
+	ContentTypeMultipart = "multipart/form-data"
+)
+
+// Methods are the HTTP methods an operation can be declared for, in the order
+// operations are listed
+var Methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Responses  map[string]*Response  `json:"responses"`
+}
+
+// PathItem holds the operations of a path. Servers overrides the servers of the
+// document, e.g. for routes outside of /api/v1.
+type PathItem struct {
+	Servers []Server   `json:"servers"`
+	Get     *Operation `json:"get"`
+	Post    *Operation `json:"post"`
+	Put     *Operation `json:"put"`
+	Patch   *Operation `json:"patch"`
+	Delete  *Operation `json:"delete"`
+}
+
+func (p *PathItem) operation(method string) *Operation {
+	switch method {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodDelete:
+		return p.Delete
+	}
+	return nil
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Tags        []string             `json:"tags"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+
+	// Method and Path are filled in by Load, Path includes the server prefix
+	Method string `json:"-"`
+	Path   string `json:"-"`
+}
+
+// SuccessStatuses returns the documented 2xx status codes, in ascending order
+func (o *Operation) SuccessStatuses() []string {
+	var statuses []string
+	for status := range o.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// ParametersIn returns the parameters of the operation found in the given location
+func (o *Operation) ParametersIn(in string) []*Parameter {
+	var params []*Parameter
+	for _, param := range o.Parameters {
+		if param.In == in {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+	Example     any     `json:"example"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref         string                `json:"$ref"`
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema  *Schema `json:"schema"`
+	Example any     `json:"example"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Enum                 []any              `json:"enum"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Minimum              *float64           `json:"minimum"`
+	ReadOnly             bool               `json:"readOnly"`
+	WriteOnly            bool               `json:"writeOnly"`
+}
+
+// RefName returns the name of the component schema referenced by s, if any
+func (s *Schema) RefName() string {
+	return strings.TrimPrefix(s.Ref, schemaRefPrefix)
+}
+
+// IsRequired reports whether property is a required property of s
+func (s *Schema) IsRequired(property string) bool {
+	for _, name := range s.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
+// Load parses an OpenAPI document and resolves the references to shared
+// parameters and responses. Schema references are kept and resolved on demand.
+func Load(data []byte) (*Document, error) {
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(raw, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if len(doc.Servers) == 0 {
+		doc.Servers = []Server{{URL: "/"}}
+	}
+
+	ids := map[string]string{}
+	for p, item := range doc.Paths {
+		for _, method := range Methods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("%s %s: missing operationId", method, p)
+			}
+			if other, ok := ids[op.OperationID]; ok {
+				return nil, fmt.Errorf("%s %s: operationId %q already used by %s", method, p, op.OperationID, other)
+			}
+			ids[op.OperationID] = method + " " + p
+
+			op.Method = method
+			op.Path = doc.serverURL(item) + p
+			if err := doc.resolveOperation(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, p, err)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// Operations returns every operation of the document, ordered by path then method
+func (d *Document) Operations() []*Operation {
+	paths := make([]string, 0, len(d.Paths))
+	for p := range d.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []*Operation
+	for _, p := range paths {
+		for _, method := range Methods {
+			if op := d.Paths[p].operation(method); op != nil {
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops
+}
+
+// Resolve follows the reference of a schema to its component definition
+func (d *Document) Resolve(s *Schema) (*Schema, error) {
+	for s != nil && s.Ref != "" {
+		resolved, ok := d.Components.Schemas[s.RefName()]
+		if !ok || !strings.HasPrefix(s.Ref, schemaRefPrefix) {
+			return nil, fmt.Errorf("unknown schema reference %q", s.Ref)
+		}
+		s = resolved
+	}
+	return s, nil
+}
+
+func (d *Document) serverURL(item *PathItem) string {
+	servers := d.Servers
+	if len(item.Servers) > 0 {
+		servers = item.Servers
+	}
+	return strings.TrimSuffix(path.Clean(servers[0].URL), "/")
+}
+
+func (d *Document) resolveOperation(op *Operation) error {
+	for i, param := range op.Parameters {
+		if param.Ref == "" {
+			continue
+		}
+		shared, ok := d.Components.Parameters[strings.TrimPrefix(param.Ref, parameterRefPrefix)]
+		if !ok || !strings.HasPrefix(param.Ref, parameterRefPrefix) {
+			return fmt.Errorf("unknown parameter reference %q", param.Ref)
+		}
+		op.Parameters[i] = shared
+	}
+	for status, response := range op.Responses {
+		if response.Ref == "" {
+			continue
+		}
+		shared, ok := d.Components.Responses[strings.TrimPrefix(response.Ref, responseRefPrefix)]
+		if !ok || !strings.HasPrefix(response.Ref, responseRefPrefix) {
+			return fmt.Errorf("unknown response reference %q", response.Ref)
+		}
+		op.Responses[status] = shared
+	}
+	return nil
+}
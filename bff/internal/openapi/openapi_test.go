@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+openapi: 3.1.0
+servers:
+  - url: /api/v1
+paths:
+  /healthcheck:
+    servers:
+      - url: /
+    get:
+      operationId: healthcheck
+      responses:
+        "200":
+          description: ok
+  /items/{name}:
+    get:
+      operationId: getItem
+      parameters:
+        - $ref: "#/components/parameters/Name"
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Item"
+components:
+  parameters:
+    Name:
+      name: name
+      in: path
+      required: true
+      schema:
+        type: string
+  schemas:
+    Item:
+      type: object
+      properties:
+        name:
+          type: string
+        createdAt:
+          type: string
+          format: date-time
+        count:
+          type: integer
+        secret:
+          type: string
+          writeOnly: true
+        id:
+          type: string
+          readOnly: true
+      required: [name, id]
+`
+
+func TestLoadResolvesReferencesAndServers(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+
+	ops := doc.Operations()
+	assert.Len(t, ops, 2)
+	assert.Equal(t, "/healthcheck", ops[0].Path)
+	assert.Equal(t, "/api/v1/items/{name}", ops[1].Path)
+	assert.Equal(t, "name", ops[1].ParametersIn("path")[0].Name)
+	assert.Equal(t, []string{"200"}, ops[1].SuccessStatuses())
+}
+
+func TestLoadRequiresOperationIDs(t *testing.T) {
+	_, err := Load([]byte("paths:\n  /x:\n    get:\n      responses: {}\n"))
+	assert.ErrorContains(t, err, "missing operationId")
+}
+
+func TestValidate(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+	item := &Schema{Ref: "#/components/schemas/Item"}
+
+	decode := func(s string) any {
+		var value any
+		assert.NoError(t, json.Unmarshal([]byte(s), &value))
+		return value
+	}
+
+	assert.NoError(t, doc.Validate(item, decode(`{"name":"a","id":"1","createdAt":"2024-01-02T03:04:05Z","count":2}`), DirectionResponse))
+	assert.NoError(t, doc.Validate(item, decode(`{"name":"a","secret":"s"}`), DirectionRequest))
+
+	err = doc.Validate(item, decode(`{"name":1,"id":"1","count":1.5,"createdAt":"yesterday","secret":"s","extra":true}`), DirectionResponse)
+	assert.ErrorContains(t, err, `$.name: expected string`)
+	assert.ErrorContains(t, err, `$.count: expected integer`)
+	assert.ErrorContains(t, err, `$.createdAt: expected date-time`)
+	assert.ErrorContains(t, err, `write-only property "secret" returned`)
+	assert.ErrorContains(t, err, `undocumented property "extra"`)
+
+	assert.ErrorContains(t, doc.Validate(item, decode(`{"name":"a"}`), DirectionResponse), `missing required property "id"`)
+}
+
+func TestGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"userId":           "UserID",
+		"system_info":      "SystemInfo",
+		"apiVersion":       "APIVersion",
+		"ciLower":          "CILower",
+		"k8sName":          "K8sName",
+		"getUser":          "GetUser",
+		"tokenizedRequest": "TokenizedRequest",
+	} {
+		assert.Equal(t, expected, GoName(name), name)
+	}
+}
@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Direction tells Validate whether a value is sent to or returned by the API,
+// write-only properties must never be returned
+type Direction int
+
+const (
+	DirectionRequest Direction = iota
+	DirectionResponse
+)
+
+// Validate checks that a value decoded by encoding/json conforms to schema.
+// Objects with declared properties are closed: a property the schema does not
+// declare is reported, so renamed or undocumented fields are caught.
+func (d *Document) Validate(schema *Schema, value any, direction Direction) error {
+	var errs []error
+	d.validate(schema, value, direction, "$", &errs)
+	return errors.Join(errs...)
+}
+
+func (d *Document) validate(schema *Schema, value any, direction Direction, at string, errs *[]error) {
+	schema, err := d.Resolve(schema)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: %w", at, err))
+		return
+	}
+	if schema == nil {
+		return
+	}
+
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, fmt.Errorf("%s: "+format, append([]any{at}, args...)...))
+	}
+
+	// Go encodes nil slices, maps and pointers as null
+	if value == nil {
+		switch schema.Type {
+		case "string", "integer", "number", "boolean":
+			fail("expected %s, got null", schema.Type)
+		}
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %T", value)
+			return
+		}
+		for _, name := range schema.Required {
+			// read-only properties are set by the server and only required in responses
+			if property := schema.Properties[name]; direction == DirectionRequest && property != nil && property.ReadOnly {
+				continue
+			}
+			if _, ok := object[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, declared := schema.Properties[name]
+			switch {
+			case declared:
+				if direction == DirectionResponse && property.WriteOnly {
+					fail("write-only property %q returned", name)
+				}
+				d.validate(property, object[name], direction, at+"."+name, errs)
+			case schema.AdditionalProperties != nil:
+				d.validate(schema.AdditionalProperties, object[name], direction, at+"."+name, errs)
+			case len(schema.Properties) > 0:
+				fail("undocumented property %q", name)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %T", value)
+			return
+		}
+		for i, item := range array {
+			d.validate(schema.Items, item, direction, fmt.Sprintf("%s[%d]", at, i), errs)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("expected string, got %T", value)
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				fail("expected date-time, got %q", s)
+			}
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			fail("expected %s, got %T", schema.Type, value)
+			return
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			fail("expected integer, got %v", n)
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			fail("%v is lower than the minimum %v", n, *schema.Minimum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %T", value)
+			return
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		fail("%v is not one of %v", value, schema.Enum)
+	}
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
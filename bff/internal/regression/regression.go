@@ -0,0 +1,211 @@
+// Package regression compares evaluation results with a baseline evaluation.
+package regression
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// DefaultAlpha is the significance level used when the policy does not set one
+const DefaultAlpha = 0.05
+
+// Validate checks a regression policy before it is stored on an evaluation
+func Validate(policy *models.RegressionPolicy) error {
+	if policy.Baseline == "" {
+		return fmt.Errorf("regression.baseline is required")
+	}
+	if policy.Tolerance < 0 {
+		return fmt.Errorf("regression.tolerance must not be negative")
+	}
+	if policy.Alpha < 0 || policy.Alpha >= 1 {
+		return fmt.Errorf("regression.alpha must be between 0 and 1")
+	}
+	return nil
+}
+
+// EncodePolicy serialises a policy for the regression policy annotation
+func EncodePolicy(policy *models.RegressionPolicy) (string, error) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode regression policy: %w", err)
+	}
+	return string(data), nil
+}
+
+// PolicyFor reads the regression policy of an evaluation, nil when it has none
+func PolicyFor(job *models.LMEvalJobKind) (*models.RegressionPolicy, error) {
+	value := job.Metadata.Annotations[constants.RegressionPolicyAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+	var policy models.RegressionPolicy
+	if err := json.Unmarshal([]byte(value), &policy); err != nil {
+		return nil, fmt.Errorf("invalid regression policy on %q: %w", job.Metadata.Name, err)
+	}
+	return &policy, nil
+}
+
+// FindBaseline resolves the policy's baseline among the namespace's evaluations.
+// For "previous" it picks the latest successful run of the same model and task set
+// created before the evaluation; it returns nil when there is none.
+func FindBaseline(job *models.LMEvalJobKind, policy *models.RegressionPolicy, jobs []models.LMEvalJobKind) *models.LMEvalJobKind {
+	if policy.Baseline != models.RegressionBaselinePrevious {
+		for i := range jobs {
+			if jobs[i].Metadata.Name == policy.Baseline {
+				return &jobs[i]
+			}
+		}
+		return nil
+	}
+
+	model := job.ModelName()
+	tasks := taskSetKey(job)
+	var baseline *models.LMEvalJobKind
+	for i := range jobs {
+		candidate := &jobs[i]
+		if candidate.Metadata.Name == job.Metadata.Name ||
+			candidate.ModelName() != model ||
+			taskSetKey(candidate) != tasks ||
+			!candidate.Metadata.CreationTimestamp.Before(job.Metadata.CreationTimestamp) ||
+			!candidate.Status.IsFinished() || candidate.Status.IsFailed() {
+			continue
+		}
+		if baseline == nil || candidate.Metadata.CreationTimestamp.After(baseline.Metadata.CreationTimestamp) {
+			baseline = candidate
+		}
+	}
+	return baseline
+}
+
+// Compare evaluates the policy for an evaluation against its baseline, which may be nil
+func Compare(job, baseline *models.LMEvalJobKind, policy *models.RegressionPolicy) *models.RegressionReport {
+	report := &models.RegressionReport{
+		Tolerance:   policy.Tolerance,
+		Alpha:       policy.Alpha,
+		Comparisons: []models.MetricComparison{},
+	}
+	if report.Alpha == 0 {
+		report.Alpha = DefaultAlpha
+	}
+
+	switch {
+	case baseline == nil:
+		return unknown(report, "baseline evaluation not found")
+	case !job.Status.IsFinished():
+		report.Verdict = models.RegressionVerdictPending
+		report.Reason = "evaluation has not completed"
+		report.Baseline = baseline.Metadata.Name
+		return report
+	case job.Status.IsFailed():
+		report.Baseline = baseline.Metadata.Name
+		return unknown(report, "evaluation failed")
+	case !baseline.Status.IsFinished() || baseline.Status.IsFailed():
+		report.Baseline = baseline.Metadata.Name
+		return unknown(report, "baseline evaluation has no results")
+	}
+	report.Baseline = baseline.Metadata.Name
+
+	current, err := models.ParseLMEvalResults(job.Status.Results)
+	if err != nil {
+		return unknown(report, err.Error())
+	}
+	previous, err := models.ParseLMEvalResults(baseline.Status.Results)
+	if err != nil {
+		return unknown(report, err.Error())
+	}
+
+	selected := map[string]bool{}
+	for _, key := range policy.Metrics {
+		selected[key] = true
+	}
+	baselineMetrics := map[string]models.LMEvalMetric{}
+	for _, metric := range previous {
+		baselineMetrics[metric.Key()] = metric
+	}
+
+	regressions := 0
+	for _, metric := range current {
+		key := metric.Key()
+		if len(selected) > 0 && !selected[key] {
+			continue
+		}
+		base, ok := baselineMetrics[key]
+		if !ok {
+			continue
+		}
+		comparison := compareMetric(base, metric, report.Tolerance, report.Alpha)
+		if comparison.Regressed {
+			regressions++
+		}
+		report.Comparisons = append(report.Comparisons, comparison)
+	}
+	sort.Slice(report.Comparisons, func(i, j int) bool {
+		return report.Comparisons[i].Key < report.Comparisons[j].Key
+	})
+
+	switch {
+	case len(report.Comparisons) == 0:
+		return unknown(report, "no metrics in common with the baseline")
+	case regressions > 0:
+		report.Verdict = models.RegressionVerdictFail
+		report.Reason = fmt.Sprintf("%d of %d metrics regressed", regressions, len(report.Comparisons))
+	default:
+		report.Verdict = models.RegressionVerdictPass
+	}
+	return report
+}
+
+func compareMetric(base, current models.LMEvalMetric, tolerance, alpha float64) models.MetricComparison {
+	higherIsBetter := models.MetricHigherIsBetter(current.Metric)
+	comparison := models.MetricComparison{
+		Key:            current.Key(),
+		Task:           current.Task,
+		Metric:         current.Metric,
+		Filter:         current.Filter,
+		HigherIsBetter: higherIsBetter,
+		Baseline:       base.Value,
+		Current:        current.Value,
+		Delta:          current.Value - base.Value,
+	}
+	if base.Value != 0 {
+		relative := comparison.Delta / math.Abs(base.Value)
+		comparison.RelativeDelta = &relative
+	}
+
+	// Without standard errors on both sides the change cannot be tested and counts as significant
+	comparison.Significant = true
+	if base.StdErr != nil && current.StdErr != nil {
+		if se := math.Hypot(*base.StdErr, *current.StdErr); se > 0 {
+			z := comparison.Delta / se
+			p := math.Erfc(math.Abs(z) / math.Sqrt2)
+			comparison.ZScore = &z
+			comparison.PValue = &p
+			comparison.Significant = p < alpha
+		}
+	}
+
+	worsening := comparison.Delta
+	if higherIsBetter {
+		worsening = -comparison.Delta
+	}
+	comparison.Regressed = worsening > tolerance && comparison.Significant
+	return comparison
+}
+
+func unknown(report *models.RegressionReport, reason string) *models.RegressionReport {
+	report.Verdict = models.RegressionVerdictUnknown
+	report.Reason = reason
+	return report
+}
+
+func taskSetKey(job *models.LMEvalJobKind) string {
+	tasks := append([]string{}, job.Spec.TaskList.TaskNames...)
+	sort.Strings(tasks)
+	return strings.Join(tasks, ",")
+}
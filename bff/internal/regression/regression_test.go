@@ -0,0 +1,102 @@
+package regression
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+var baseTime = time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+func evaluation(name, model string, hoursAgo int, tasks []string, results string) models.LMEvalJobKind {
+	return models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:              name,
+			Namespace:         "project-1",
+			CreationTimestamp: baseTime.Add(-time.Duration(hoursAgo) * time.Hour),
+		},
+		Spec: models.LMEvalJobSpec{
+			ModelArgs: []models.LMEvalJobModelArg{{Name: "model", Value: model}},
+			TaskList:  models.LMEvalJobTaskList{TaskNames: tasks},
+		},
+		Status: &models.LMEvalJobStatus{State: models.LMEvalJobStateComplete, Results: results},
+	}
+}
+
+func results(acc, accStderr, perplexity float64) string {
+	return fmt.Sprintf(`{"results":{"arc_easy":{"acc,none":%v,"acc_stderr,none":%v},"wikitext":{"word_perplexity,none":%v}}}`, acc, accStderr, perplexity)
+}
+
+func TestFindPreviousBaseline(t *testing.T) {
+	current := evaluation("llama-v3", "llama", 0, []string{"wikitext", "arc_easy"}, results(0.8, 0.01, 10))
+	jobs := []models.LMEvalJobKind{
+		evaluation("llama-v1", "llama", 3, []string{"arc_easy", "wikitext"}, results(0.8, 0.01, 10)),
+		evaluation("llama-v2", "llama", 2, []string{"arc_easy", "wikitext"}, results(0.8, 0.01, 10)),
+		evaluation("llama-other-tasks", "llama", 1, []string{"arc_easy"}, results(0.8, 0.01, 10)),
+		evaluation("mistral", "mistral", 1, []string{"arc_easy", "wikitext"}, results(0.8, 0.01, 10)),
+		current,
+	}
+	failed := evaluation("llama-failed", "llama", 1, []string{"arc_easy", "wikitext"}, "")
+	failed.Status.Reason = models.LMEvalJobReasonFailed
+	jobs = append(jobs, failed)
+
+	baseline := FindBaseline(&current, &models.RegressionPolicy{Baseline: models.RegressionBaselinePrevious}, jobs)
+	assert.Equal(t, "llama-v2", baseline.Metadata.Name)
+
+	baseline = FindBaseline(&current, &models.RegressionPolicy{Baseline: "llama-v1"}, jobs)
+	assert.Equal(t, "llama-v1", baseline.Metadata.Name)
+
+	assert.Nil(t, FindBaseline(&current, &models.RegressionPolicy{Baseline: "missing"}, jobs))
+}
+
+func TestCompareDetectsSignificantRegressions(t *testing.T) {
+	baseline := evaluation("v1", "llama", 1, []string{"arc_easy"}, results(0.80, 0.01, 10))
+
+	// A 5 point drop with 1 point stderr is significant
+	current := evaluation("v2", "llama", 0, []string{"arc_easy"}, results(0.75, 0.01, 10))
+	report := Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1"})
+	assert.Equal(t, models.RegressionVerdictFail, report.Verdict)
+	assert.Equal(t, "v1", report.Baseline)
+	assert.Len(t, report.Comparisons, 2)
+	assert.True(t, report.Comparisons[0].Regressed)
+	assert.InDelta(t, -0.05, report.Comparisons[0].Delta, 1e-9)
+	assert.Less(t, *report.Comparisons[0].PValue, 0.05)
+
+	// A 1 point drop is within noise
+	current = evaluation("v2", "llama", 0, []string{"arc_easy"}, results(0.79, 0.01, 10))
+	report = Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1"})
+	assert.Equal(t, models.RegressionVerdictPass, report.Verdict)
+	assert.False(t, report.Comparisons[0].Significant)
+
+	// A tolerance accepts the significant drop
+	current = evaluation("v2", "llama", 0, []string{"arc_easy"}, results(0.75, 0.01, 10))
+	report = Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1", Tolerance: 0.1})
+	assert.Equal(t, models.RegressionVerdictPass, report.Verdict)
+}
+
+func TestCompareHonoursMetricDirectionAndSelection(t *testing.T) {
+	baseline := evaluation("v1", "llama", 1, []string{"arc_easy"}, results(0.80, 0.01, 10))
+	current := evaluation("v2", "llama", 0, []string{"arc_easy"}, results(0.80, 0.01, 12))
+
+	// Higher perplexity is worse; without stderr any change beyond tolerance counts
+	report := Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1"})
+	assert.Equal(t, models.RegressionVerdictFail, report.Verdict)
+
+	report = Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1", Metrics: []string{"arc_easy.acc"}})
+	assert.Equal(t, models.RegressionVerdictPass, report.Verdict)
+	assert.Len(t, report.Comparisons, 1)
+}
+
+func TestCompareWithoutUsableBaseline(t *testing.T) {
+	current := evaluation("v2", "llama", 0, []string{"arc_easy"}, results(0.80, 0.01, 10))
+	report := Compare(&current, nil, &models.RegressionPolicy{Baseline: models.RegressionBaselinePrevious})
+	assert.Equal(t, models.RegressionVerdictUnknown, report.Verdict)
+
+	baseline := evaluation("v1", "llama", 1, []string{"arc_easy"}, results(0.80, 0.01, 10))
+	current.Status.State = models.LMEvalJobStateRunning
+	report = Compare(&current, &baseline, &models.RegressionPolicy{Baseline: "v1"})
+	assert.Equal(t, models.RegressionVerdictPending, report.Verdict)
+}
@@ -0,0 +1,214 @@
+// Package report renders evaluation reports as CSV, Markdown or JSON.
+// Reports are written row by row so large exports stream to the client.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// Supported report formats
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+)
+
+// ContentType returns the media type of a report format, or false when the format is unknown
+func ContentType(format string) (string, bool) {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", true
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8", true
+	case FormatJSON:
+		return "application/json", true
+	default:
+		return "", false
+	}
+}
+
+// Write renders reports in the given format
+func Write(w io.Writer, format string, reports []models.EvaluationReport) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, reports)
+	case FormatMarkdown:
+		return writeMarkdown(w, reports)
+	case FormatJSON:
+		return writeJSON(w, reports)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+var csvHeader = []string{
+	"evaluation", "display_name", "namespace", "model", "model_type",
+	"tasks", "num_fewshot", "limit", "batch_size",
+	"created_by", "created_at", "started_at", "completed_at", "duration_seconds", "state",
+	"task", "metric", "filter", "value", "stderr",
+}
+
+// escapeFormula prefixes text that spreadsheets would evaluate as a formula with a quote,
+// so that names and labels set by users cannot run formulas when the export is opened.
+// Numbers are written by the BFF and left as they are, negative values included.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// writeCSV writes one row per metric. Evaluations without results get a single row
+// with empty metric columns so they still show up in the export.
+func writeCSV(w io.Writer, reports []models.EvaluationReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		metadata := []string{
+			escapeFormula(report.Name),
+			escapeFormula(report.DisplayName),
+			escapeFormula(report.Namespace),
+			escapeFormula(report.Model),
+			escapeFormula(report.ModelType),
+			escapeFormula(strings.Join(report.Tasks, ";")),
+			formatIntPtr(report.NumFewShot),
+			escapeFormula(report.Limit),
+			escapeFormula(report.BatchSize),
+			escapeFormula(report.CreatedBy),
+			formatTime(&report.CreationTimestamp),
+			formatTime(report.StartTime),
+			formatTime(report.CompleteTime),
+			formatFloatPtr(report.DurationSeconds),
+			report.State,
+		}
+
+		if len(report.Metrics) == 0 {
+			if err := writer.Write(append(metadata, "", "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, metric := range report.Metrics {
+			row := append(append([]string{}, metadata...),
+				escapeFormula(metric.Task), escapeFormula(metric.Metric), escapeFormula(metric.Filter), formatFloat(metric.Value), formatFloatPtr(metric.StdErr))
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeMarkdown writes a section per evaluation, suitable for pasting into a PR description
+func writeMarkdown(w io.Writer, reports []models.EvaluationReport) error {
+	for i, report := range reports {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		title := report.DisplayName
+		if title == "" {
+			title = report.Name
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(title))
+		fmt.Fprintf(&b, "- **Evaluation:** `%s/%s`\n", report.Namespace, report.Name)
+		fmt.Fprintf(&b, "- **Model:** %s (%s)\n", escapeMarkdown(report.Model), escapeMarkdown(report.ModelType))
+		fmt.Fprintf(&b, "- **Tasks:** %s\n", escapeMarkdown(strings.Join(report.Tasks, ", ")))
+		if report.NumFewShot != nil {
+			fmt.Fprintf(&b, "- **Few-shot:** %d\n", *report.NumFewShot)
+		}
+		if report.Limit != "" {
+			fmt.Fprintf(&b, "- **Limit:** %s\n", escapeMarkdown(report.Limit))
+		}
+		if report.CreatedBy != "" {
+			fmt.Fprintf(&b, "- **Created by:** %s\n", escapeMarkdown(report.CreatedBy))
+		}
+		fmt.Fprintf(&b, "- **Created:** %s\n", formatTime(&report.CreationTimestamp))
+		if report.CompleteTime != nil {
+			fmt.Fprintf(&b, "- **Completed:** %s\n", formatTime(report.CompleteTime))
+		}
+		if report.DurationSeconds != nil {
+			fmt.Fprintf(&b, "- **Duration:** %s\n", time.Duration(*report.DurationSeconds*float64(time.Second)).Round(time.Second))
+		}
+		fmt.Fprintf(&b, "- **State:** %s\n\n", escapeMarkdown(report.State))
+
+		if len(report.Metrics) == 0 {
+			b.WriteString("_No results available._\n")
+		} else {
+			b.WriteString("| Task | Metric | Filter | Value | Stderr |\n")
+			b.WriteString("| --- | --- | --- | ---: | ---: |\n")
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+
+		for _, metric := range report.Metrics {
+			row := fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				escapeMarkdown(metric.Task), escapeMarkdown(metric.Metric), escapeMarkdown(metric.Filter),
+				formatFloat(metric.Value), formatFloatPtr(metric.StdErr))
+			if _, err := io.WriteString(w, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeJSON writes the reports in the API's data envelope
+func writeJSON(w io.Writer, reports []models.EvaluationReport) error {
+	return json.NewEncoder(w).Encode(struct {
+		Data []models.EvaluationReport `json:"data"`
+	}{Data: reports})
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ", "*", `\*`, "_", `\_`, "`", "\\`")
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatFloatPtr(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+func formatIntPtr(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
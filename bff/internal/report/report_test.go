@@ -0,0 +1,113 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func testReports() []models.EvaluationReport {
+	stderr := 0.01
+	duration := 90.0
+	completed := time.Date(2026, time.March, 14, 11, 0, 0, 0, time.UTC)
+	return []models.EvaluationReport{
+		{
+			Name:              "llama-arc",
+			Namespace:         "project-1",
+			DisplayName:       "Llama | ARC",
+			Model:             "llama-2-7b",
+			ModelType:         "local-completions",
+			Tasks:             []string{"arc_easy", "hellaswag"},
+			CreatedBy:         "test-user",
+			CreationTimestamp: completed.Add(-time.Hour),
+			CompleteTime:      &completed,
+			DurationSeconds:   &duration,
+			State:             "Complete",
+			Metrics: []models.LMEvalMetric{
+				{Task: "arc_easy", Metric: "acc", Filter: "none", Value: 0.82, StdErr: &stderr},
+				{Task: "hellaswag", Metric: "acc", Filter: "none", Value: 0.61},
+			},
+		},
+		{
+			Name:      "llama-pending",
+			Namespace: "project-1",
+			ModelType: "local-completions",
+			Tasks:     []string{"arc_easy"},
+			State:     "Pending",
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatCSV, testReports()))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	// Header, one row per metric and one row for the evaluation without results
+	assert.Len(t, rows, 4)
+	assert.Equal(t, csvHeader, rows[0])
+	assert.Equal(t, []string{"llama-arc", "arc_easy;hellaswag", "test-user", "90", "arc_easy", "acc", "0.82", "0.01"},
+		[]string{rows[1][0], rows[1][5], rows[1][9], rows[1][13], rows[1][15], rows[1][16], rows[1][18], rows[1][19]})
+	assert.Equal(t, "llama-pending", rows[3][0])
+	assert.Equal(t, "", rows[3][16])
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	value := -0.5
+	reports := []models.EvaluationReport{{
+		Name:        "llama-arc",
+		DisplayName: "=HYPERLINK(\"https://example.com\")",
+		Model:       "+cmd",
+		CreatedBy:   "@user",
+		Tasks:       []string{"-arc"},
+		Limit:       "\t1",
+		BatchSize:   "\r2",
+		Metrics:     []models.LMEvalMetric{{Task: "arc_easy", Metric: "=acc", Value: value, StdErr: &value}},
+	}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatCSV, reports))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"llama-arc", "'=HYPERLINK(\"https://example.com\")", "'+cmd", "'-arc", "'\t1", "'\r2", "'@user", "arc_easy", "'=acc", "-0.5", "-0.5"},
+		[]string{rows[1][0], rows[1][1], rows[1][3], rows[1][5], rows[1][7], rows[1][8], rows[1][9], rows[1][15], rows[1][16], rows[1][18], rows[1][19]})
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatMarkdown, testReports()))
+
+	out := buf.String()
+	assert.Contains(t, out, `## Llama \| ARC`)
+	assert.Contains(t, out, "- **Duration:** 1m30s")
+	assert.Contains(t, out, `| arc\_easy | acc | none | 0.82 | 0.01 |`)
+	assert.Contains(t, out, "## llama-pending")
+	assert.Contains(t, out, "_No results available._")
+	assert.Equal(t, 2, strings.Count(out, "## "))
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatJSON, testReports()))
+
+	var decoded struct {
+		Data []models.EvaluationReport `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Data, 2)
+	assert.Len(t, decoded.Data[0].Metrics, 2)
+}
+
+func TestWriteRejectsUnknownFormat(t *testing.T) {
+	assert.Error(t, Write(&bytes.Buffer{}, "xlsx", testReports()))
+	_, ok := ContentType("xlsx")
+	assert.False(t, ok)
+}
@@ -0,0 +1,153 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// DefaultInterval is how often finished evaluations are checked against their retention period
+const DefaultInterval = time.Hour
+
+// Controller deletes finished evaluations once they are older than the number of days
+// set with constants.RetentionDaysAnnotation on their namespace. Namespaces without the
+// annotation keep their evaluations. Results stored on a PVC managed by the operator are
+// deleted with their evaluation.
+type Controller struct {
+	client   kubernetes.KubernetesClientInterface
+	logger   *slog.Logger
+	interval time.Duration
+	identity *kubernetes.RequestIdentity
+
+	// Audit, when set, records the evaluations deleted by the controller
+	Audit *audit.Logger
+
+	// BeforeDelete, when set, is called with every evaluation before it is deleted. The
+	// evaluation is kept when it returns an error.
+	BeforeDelete func(ctx context.Context, job *models.LMEvalJobKind) error
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewController(client kubernetes.KubernetesClientInterface, logger *slog.Logger, interval time.Duration) *Controller {
+	return &Controller{
+		client:   client,
+		logger:   logger.With(slog.String("component", "retention-controller")),
+		interval: interval,
+		identity: kubernetes.BackgroundIdentity("retention-controller"),
+		now:      time.Now,
+	}
+}
+
+// Run prunes expired evaluations every interval until ctx is cancelled
+func (c *Controller) Run(ctx context.Context) {
+	c.logger.Info("starting retention controller", slog.Duration("interval", c.interval))
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Reconcile(ctx); err != nil {
+			c.logger.Error("failed to prune expired evaluations", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Info("stopping retention controller")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile deletes the finished evaluations of every namespace that are past its retention period
+func (c *Controller) Reconcile(ctx context.Context) error {
+	jobs, err := c.client.ListLMEvalJobs(ctx, c.identity, "")
+	if err != nil {
+		return err
+	}
+
+	finished := map[string][]models.LMEvalJobKind{}
+	for _, job := range jobs.Items {
+		if job.Status.IsFinished() {
+			finished[job.Metadata.Namespace] = append(finished[job.Metadata.Namespace], job)
+		}
+	}
+
+	for namespace, namespaceJobs := range finished {
+		retention, err := c.retentionPeriod(ctx, namespace)
+		if err != nil {
+			c.logger.Error("failed to read the retention period",
+				slog.String("namespace", namespace),
+				slog.Any("error", err))
+			continue
+		}
+		if retention == 0 {
+			continue
+		}
+
+		cutoff := c.now().Add(-retention)
+		for _, job := range namespaceJobs {
+			if !finishedAt(&job).Before(cutoff) {
+				continue
+			}
+			if c.BeforeDelete != nil {
+				if err := c.BeforeDelete(ctx, &job); err != nil {
+					c.logger.Error("keeping expired evaluation",
+						slog.String("evaluation", job.Metadata.Name),
+						slog.String("namespace", namespace),
+						slog.Any("error", err))
+					continue
+				}
+			}
+			err := c.client.DeleteLMEvalJob(ctx, c.identity, namespace, job.Metadata.Name)
+			c.Audit.Log(ctx, audit.NewEvent(c.identity.UserID, nil, audit.ActionDeleteEvaluation, namespace, "lmevaljobs/"+job.Metadata.Name, err))
+			if err != nil {
+				c.logger.Error("failed to delete expired evaluation",
+					slog.String("evaluation", job.Metadata.Name),
+					slog.String("namespace", namespace),
+					slog.Any("error", err))
+				continue
+			}
+			c.logger.Info("deleted expired evaluation",
+				slog.String("evaluation", job.Metadata.Name),
+				slog.String("namespace", namespace),
+				slog.Time("finishedAt", finishedAt(&job)))
+		}
+	}
+
+	return nil
+}
+
+// retentionPeriod reads the retention period of a namespace, 0 when it keeps its evaluations
+func (c *Controller) retentionPeriod(ctx context.Context, namespace string) (time.Duration, error) {
+	ns, err := c.client.GetNamespace(ctx, namespace)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := ns.Annotations[constants.RetentionDaysAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q: expected a positive number of days", constants.RetentionDaysAnnotation, value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// finishedAt is the completion time of a job, or its creation time when the operator did not record one
+func finishedAt(job *models.LMEvalJobKind) time.Time {
+	if job.Status.CompleteTime != nil {
+		return *job.Status.CompleteTime
+	}
+	return job.Metadata.CreationTimestamp
+}
@@ -0,0 +1,100 @@
+package retention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func createJob(t *testing.T, client kubernetes.KubernetesClientInterface, namespace, name, status string) {
+	identity := kubernetes.BackgroundIdentity("test")
+	_, err := client.CreateLMEvalJob(context.Background(), identity, namespace, &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{Name: name},
+	})
+	assert.NoError(t, err)
+	_, err = client.PatchLMEvalJob(context.Background(), identity, namespace, name, []byte(`{"status":`+status+`}`))
+	assert.NoError(t, err)
+}
+
+func jobNames(t *testing.T, client kubernetes.KubernetesClientInterface, namespace string) []string {
+	jobs, err := client.ListLMEvalJobs(context.Background(), kubernetes.BackgroundIdentity("test"), namespace)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, job := range jobs.Items {
+		names = append(names, job.Metadata.Name)
+	}
+	return names
+}
+
+func TestReconcileDeletesExpiredEvaluations(t *testing.T) {
+	// The mock ds-project-3 namespace keeps evaluations for 30 days, project-1 keeps them forever
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	now := time.Now()
+	completedAgo := func(days int) string {
+		return now.Add(-time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339)
+	}
+
+	createJob(t, client, "ds-project-3", "expired", `{"state":"Complete","completeTime":"`+completedAgo(40)+`"}`)
+	createJob(t, client, "ds-project-3", "expired-failed", `{"state":"Complete","reason":"Failed","completeTime":"`+completedAgo(31)+`"}`)
+	createJob(t, client, "ds-project-3", "recent", `{"state":"Complete","completeTime":"`+completedAgo(5)+`"}`)
+	createJob(t, client, "ds-project-3", "running", `{"state":"Running"}`)
+	createJob(t, client, "project-1", "kept", `{"state":"Complete","completeTime":"`+completedAgo(400)+`"}`)
+
+	controller := NewController(client, slog.Default(), time.Minute)
+	controller.now = func() time.Time { return now }
+	assert.NoError(t, controller.Reconcile(context.Background()))
+
+	assert.ElementsMatch(t, []string{"recent", "running"}, jobNames(t, client, "ds-project-3"))
+	assert.ElementsMatch(t, []string{"kept"}, jobNames(t, client, "project-1"))
+
+	// Finished evaluations expire as time passes, running ones are kept
+	controller.now = func() time.Time { return now.Add(60 * 24 * time.Hour) }
+	assert.NoError(t, controller.Reconcile(context.Background()))
+	assert.ElementsMatch(t, []string{"running"}, jobNames(t, client, "ds-project-3"))
+}
+
+func TestReconcileKeepsEvaluationsRejectedBeforeDeletion(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	completed := time.Now().Add(-40 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	createJob(t, client, "ds-project-3", "archived", `{"state":"Complete","completeTime":"`+completed+`"}`)
+	createJob(t, client, "ds-project-3", "unarchived", `{"state":"Complete","completeTime":"`+completed+`"}`)
+
+	controller := NewController(client, slog.Default(), time.Minute)
+	controller.BeforeDelete = func(_ context.Context, job *models.LMEvalJobKind) error {
+		if job.Metadata.Name == "unarchived" {
+			return errors.New("archive unavailable")
+		}
+		return nil
+	}
+	assert.NoError(t, controller.Reconcile(context.Background()))
+	assert.ElementsMatch(t, []string{"unarchived"}, jobNames(t, client, "ds-project-3"))
+}
+
+func TestReconcileAuditsDeletionsAsTheController(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	completed := time.Now().Add(-40 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	createJob(t, client, "ds-project-3", "expired", `{"state":"Complete","completeTime":"`+completed+`"}`)
+
+	var auditBuf bytes.Buffer
+	controller := NewController(client, slog.Default(), time.Minute)
+	controller.Audit = audit.NewLogger(&auditBuf)
+	assert.NoError(t, controller.Reconcile(context.Background()))
+
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal(auditBuf.Bytes(), &event))
+	assert.Equal(t, "system:trustyai-dashboard:retention-controller", event["user"])
+	assert.Equal(t, audit.ActionDeleteEvaluation, event["action"])
+	assert.Equal(t, "ds-project-3", event["namespace"])
+	assert.Equal(t, "lmevaljobs/expired", event["resource"])
+	assert.Equal(t, audit.OutcomeSuccess, event["outcome"])
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	from := time.Date(2026, time.March, 14, 10, 30, 0, 0, time.UTC) // a Saturday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"@hourly", time.Date(2026, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * mon-fri", time.Date(2026, time.March, 16, 2, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month and day of week are OR-ed when both are restricted
+		{"0 0 20 * sun", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr)
+		assert.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, schedule.Next(from), tt.expr)
+	}
+}
+
+func TestCronNextRespectsLocation(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	schedule, err := ParseCron("0 2 * * *")
+	assert.NoError(t, err)
+
+	next := schedule.Next(time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC).In(location))
+	assert.Equal(t, time.Date(2026, time.June, 2, 0, 0, 0, 0, time.UTC), next.UTC())
+}
@@ -0,0 +1,301 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DefaultInterval is how often schedules are checked for due runs
+const DefaultInterval = 30 * time.Second
+
+// JobBuilder turns the evaluation stored on a schedule into the LMEvalJob created for a run
+type JobBuilder func(namespace string, evaluation models.LMEvalCreateRequest, createdBy string) *models.LMEvalJobKind
+
+// Scheduler creates LMEvalJobs for due schedules and prunes old runs.
+// Only one replica should run it at a time, see kubernetes.RunWithLeaderElection.
+//
+// Runs are created with the service account, so only schedules signed by the BFF are run,
+// and only while the user who saved them may create evaluations in their namespace.
+type Scheduler struct {
+	client   kubernetes.KubernetesClientInterface
+	signer   *signing.Signer
+	buildJob JobBuilder
+	logger   *slog.Logger
+	interval time.Duration
+	identity *kubernetes.RequestIdentity
+
+	// Audit, when set, records the runs created as the user who saved their schedule, and
+	// the runs pruned as the scheduler
+	Audit *audit.Logger
+
+	// OnRunCreated, when set, is called with every run the scheduler creates
+	OnRunCreated func(ctx context.Context, job *models.LMEvalJobKind)
+
+	// BeforeDelete, when set, is called with every run before it is pruned. A run is
+	// kept when it returns an error.
+	BeforeDelete func(ctx context.Context, job *models.LMEvalJobKind) error
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func New(client kubernetes.KubernetesClientInterface, signer *signing.Signer, buildJob JobBuilder, logger *slog.Logger, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		client:   client,
+		signer:   signer,
+		buildJob: buildJob,
+		logger:   logger.With(slog.String("component", "scheduler")),
+		interval: interval,
+		identity: kubernetes.BackgroundIdentity("scheduler"),
+		now:      time.Now,
+	}
+}
+
+// Run reconciles schedules every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	s.logger.Info("starting evaluation scheduler", slog.Duration("interval", s.interval))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Reconcile(ctx); err != nil {
+			s.logger.Error("failed to reconcile evaluation schedules", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("stopping evaluation scheduler")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile runs every due schedule once across all namespaces
+func (s *Scheduler) Reconcile(ctx context.Context) error {
+	configMaps, err := s.client.ListConfigMaps(ctx, "", LabelSelector())
+	if err != nil {
+		return err
+	}
+
+	for i := range configMaps {
+		schedule, err := FromConfigMap(&configMaps[i])
+		if err != nil {
+			s.logger.Warn("skipping unreadable schedule", slog.String("configmap", configMaps[i].Name), slog.Any("error", err))
+			continue
+		}
+
+		if err := s.reconcileSchedule(ctx, schedule); err != nil {
+			s.logger.Error("failed to reconcile schedule",
+				slog.String("schedule", schedule.Name),
+				slog.String("namespace", schedule.Namespace),
+				slog.Any("error", err))
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) reconcileSchedule(ctx context.Context, schedule *models.EvaluationSchedule) error {
+	if err := Verify(s.signer, schedule); err != nil {
+		return err
+	}
+
+	if !schedule.Suspend {
+		scheduledTime, due, err := s.dueTime(schedule)
+		if err != nil {
+			return err
+		}
+		if due {
+			if err := s.startRun(ctx, schedule, scheduledTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.pruneHistory(ctx, schedule)
+}
+
+// dueTime returns the most recent missed activation. Like a CronJob, missed runs are
+// collapsed into a single run rather than replayed one by one.
+func (s *Scheduler) dueTime(schedule *models.EvaluationSchedule) (time.Time, bool, error) {
+	next, err := NextScheduleTime(schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	now := s.now()
+	if next.IsZero() || next.After(now) {
+		return time.Time{}, false, nil
+	}
+
+	cron, _ := ParseCron(schedule.Schedule)
+	for {
+		following := cron.Next(next)
+		if following.IsZero() || following.After(now) {
+			return next, true, nil
+		}
+		next = following
+	}
+}
+
+func (s *Scheduler) startRun(ctx context.Context, schedule *models.EvaluationSchedule, scheduledTime time.Time) error {
+	runName := RunName(schedule.Name, scheduledTime)
+
+	runAs := &kubernetes.RequestIdentity{UserID: schedule.RunAs.User, Groups: schedule.RunAs.Groups}
+	allowed, err := s.client.CanSubjectCreateLMEvalJobs(ctx, runAs, schedule.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to authorize run %q: %w", runName, err)
+	}
+	if !allowed {
+		// The activation is skipped rather than retried until the user regains access
+		s.logger.Warn("skipping scheduled evaluation, the user who saved the schedule may not create evaluations",
+			slog.String("schedule", schedule.Name),
+			slog.String("namespace", schedule.Namespace),
+			slog.String("user", schedule.RunAs.User))
+		return s.recordScheduleTime(ctx, schedule, scheduledTime)
+	}
+
+	evaluation := schedule.Evaluation
+	evaluation.K8sName = runName
+	displayName := evaluation.EvaluationName
+	if displayName == "" {
+		displayName = schedule.Name
+	}
+	evaluation.EvaluationName = fmt.Sprintf("%s (%s)", displayName, scheduledTime.UTC().Format(time.RFC3339))
+
+	job := s.buildJob(schedule.Namespace, evaluation, schedule.CreatedBy)
+	if job.Metadata.Labels == nil {
+		job.Metadata.Labels = map[string]string{}
+	}
+	job.Metadata.Labels[constants.ScheduleLabel] = schedule.Name
+	job.Metadata.Annotations[constants.ScheduledTimeAnnotation] = scheduledTime.UTC().Format(time.RFC3339)
+
+	// Run names are deterministic, so a run created before a failed status update is not duplicated
+	created, err := s.client.CreateLMEvalJob(ctx, s.identity, schedule.Namespace, job)
+	if !apierrors.IsAlreadyExists(err) {
+		s.Audit.Log(ctx, audit.NewEvent(runAs.UserID, runAs.Groups, audit.ActionCreateEvaluation, schedule.Namespace, "lmevaljobs/"+runName, err))
+	}
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create run %q: %w", runName, err)
+	}
+	if err == nil && s.OnRunCreated != nil {
+		s.OnRunCreated(ctx, created)
+	}
+
+	s.logger.Info("started scheduled evaluation",
+		slog.String("schedule", schedule.Name),
+		slog.String("namespace", schedule.Namespace),
+		slog.String("run", runName))
+
+	return s.recordScheduleTime(ctx, schedule, scheduledTime)
+}
+
+// recordScheduleTime stores the activation a run was started, or skipped, for
+func (s *Scheduler) recordScheduleTime(ctx context.Context, schedule *models.EvaluationSchedule, scheduledTime time.Time) error {
+	schedule.LastScheduleTime = &scheduledTime
+	configMap, err := ToConfigMap(schedule)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.UpdateConfigMap(ctx, schedule.Namespace, configMap)
+	if err != nil {
+		return fmt.Errorf("failed to record last schedule time: %w", err)
+	}
+
+	return nil
+}
+
+// pruneHistory deletes finished runs beyond the schedule's history limits, oldest first
+func (s *Scheduler) pruneHistory(ctx context.Context, schedule *models.EvaluationSchedule) error {
+	runs, err := ListRuns(ctx, s.client, s.identity, schedule.Namespace, schedule.Name)
+	if err != nil {
+		return err
+	}
+
+	successfulLimit := DefaultSuccessfulRunsHistoryLimit
+	if schedule.SuccessfulRunsHistoryLimit != nil {
+		successfulLimit = *schedule.SuccessfulRunsHistoryLimit
+	}
+	failedLimit := DefaultFailedRunsHistoryLimit
+	if schedule.FailedRunsHistoryLimit != nil {
+		failedLimit = *schedule.FailedRunsHistoryLimit
+	}
+
+	var successful, failed []models.LMEvalJobKind
+	for _, run := range runs {
+		switch {
+		case run.Status.IsFailed():
+			failed = append(failed, run)
+		case run.Status.IsFinished():
+			successful = append(successful, run)
+		}
+	}
+
+	var expired []models.LMEvalJobKind
+	if len(successful) > successfulLimit {
+		expired = append(expired, successful[successfulLimit:]...)
+	}
+	if len(failed) > failedLimit {
+		expired = append(expired, failed[failedLimit:]...)
+	}
+
+	for _, run := range expired {
+		if s.BeforeDelete != nil {
+			if err := s.BeforeDelete(ctx, &run); err != nil {
+				return fmt.Errorf("failed to prune run %q: %w", run.Metadata.Name, err)
+			}
+		}
+		err := s.client.DeleteLMEvalJob(ctx, s.identity, run.Metadata.Namespace, run.Metadata.Name)
+		if !apierrors.IsNotFound(err) {
+			s.Audit.Log(ctx, audit.NewEvent(s.identity.UserID, nil, audit.ActionDeleteEvaluation, run.Metadata.Namespace, "lmevaljobs/"+run.Metadata.Name, err))
+			if err != nil {
+				return fmt.Errorf("failed to prune run %q: %w", run.Metadata.Name, err)
+			}
+		}
+		s.logger.Info("pruned scheduled evaluation run",
+			slog.String("schedule", schedule.Name),
+			slog.String("namespace", run.Metadata.Namespace),
+			slog.String("run", run.Metadata.Name))
+	}
+
+	return nil
+}
+
+// ListRuns returns the evaluations created by a schedule, newest first
+func ListRuns(ctx context.Context, client kubernetes.KubernetesClientInterface, identity *kubernetes.RequestIdentity, namespace, scheduleName string) ([]models.LMEvalJobKind, error) {
+	jobs, err := client.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []models.LMEvalJobKind
+	for _, job := range jobs.Items {
+		if job.Metadata.Labels[constants.ScheduleLabel] == scheduleName {
+			runs = append(runs, job)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Metadata.CreationTimestamp.After(runs[j].Metadata.CreationTimestamp)
+	})
+
+	return runs, nil
+}
+
+// RunName derives a stable evaluation name from the schedule name and scheduled time
+func RunName(scheduleName string, scheduledTime time.Time) string {
+	return scheduleName + "-" + strconv.FormatInt(scheduledTime.Unix()/60, 10)
+}
@@ -0,0 +1,268 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/audit"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+)
+
+// completingClient reports every evaluation as complete so history pruning can be exercised
+type completingClient struct {
+	kubernetes.KubernetesClientInterface
+}
+
+func (c completingClient) ListLMEvalJobs(ctx context.Context, identity *kubernetes.RequestIdentity, namespace string) (*models.LMEvalJobList, error) {
+	list, err := c.KubernetesClientInterface.ListLMEvalJobs(ctx, identity, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		list.Items[i].Status = &models.LMEvalJobStatus{State: models.LMEvalJobStateComplete}
+	}
+	return list, nil
+}
+
+func testJobBuilder(namespace string, evaluation models.LMEvalCreateRequest, createdBy string) *models.LMEvalJobKind {
+	return &models.LMEvalJobKind{
+		Metadata: models.LMEvalJobMetadata{
+			Name:        evaluation.K8sName,
+			Namespace:   namespace,
+			Annotations: map[string]string{"created-by": createdBy},
+		},
+		Spec: models.LMEvalJobSpec{
+			TaskList: models.LMEvalJobTaskList{TaskNames: evaluation.Tasks},
+		},
+	}
+}
+
+// denyingClient reports that no user may create evaluations
+type denyingClient struct {
+	kubernetes.KubernetesClientInterface
+}
+
+func (c denyingClient) CanSubjectCreateLMEvalJobs(ctx context.Context, subject *kubernetes.RequestIdentity, namespace string) (bool, error) {
+	return false, nil
+}
+
+var testSigner = signing.New([]byte("0123456789abcdef0123456789abcdef"))
+
+// createTestSchedule stores a schedule as saved by the API, run as test-user
+func createTestSchedule(t *testing.T, client kubernetes.KubernetesClientInterface, schedule *models.EvaluationSchedule) {
+	schedule.RunAs = models.RunAs{User: "test-user", Groups: []string{"system:authenticated"}}
+	assert.NoError(t, Sign(testSigner, schedule))
+	configMap, err := ToConfigMap(schedule)
+	assert.NoError(t, err)
+	_, err = client.CreateConfigMap(context.Background(), schedule.Namespace, configMap)
+	assert.NoError(t, err)
+}
+
+func TestReconcileStartsDueRunsOnce(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestSchedule(t, client, &models.EvaluationSchedule{
+		Name:       "nightly",
+		Namespace:  "project-1",
+		Schedule:   "@hourly",
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+		CreatedBy:  "test-user",
+	})
+
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	now := time.Now().Add(3 * time.Hour)
+	s.now = func() time.Time { return now }
+
+	// Missed activations collapse into a single run
+	assert.NoError(t, s.Reconcile(context.Background()))
+	assert.NoError(t, s.Reconcile(context.Background()))
+
+	runs, err := ListRuns(context.Background(), client, s.identity, "project-1", "nightly")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, "test-user", runs[0].Metadata.Annotations["created-by"])
+	assert.Equal(t, []string{"arc_easy"}, runs[0].Spec.TaskList.TaskNames)
+
+	configMap, err := client.GetConfigMap(context.Background(), "project-1", ConfigMapName("nightly"))
+	assert.NoError(t, err)
+	schedule, err := FromConfigMap(configMap)
+	assert.NoError(t, err)
+	assert.NotNil(t, schedule.LastScheduleTime)
+	assert.True(t, schedule.NextScheduleTime.After(now))
+}
+
+func TestReconcileSkipsSuspendedSchedules(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	createTestSchedule(t, client, &models.EvaluationSchedule{
+		Name:       "nightly",
+		Namespace:  "project-1",
+		Schedule:   "@hourly",
+		Suspend:    true,
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+	})
+
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	s.now = func() time.Time { return time.Now().Add(3 * time.Hour) }
+
+	assert.NoError(t, s.Reconcile(context.Background()))
+
+	runs, err := ListRuns(context.Background(), client, s.identity, "project-1", "nightly")
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestReconcilePrunesRunHistory(t *testing.T) {
+	mockClient := kubernetes.NewMockKubernetesClient(slog.Default())
+	client := completingClient{mockClient}
+	historyLimit := 2
+	createTestSchedule(t, client, &models.EvaluationSchedule{
+		Name:                       "nightly",
+		Namespace:                  "project-1",
+		Schedule:                   "@hourly",
+		Evaluation:                 models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+		SuccessfulRunsHistoryLimit: &historyLimit,
+	})
+
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	for hours := 1; hours <= 4; hours++ {
+		now := time.Now().Add(time.Duration(hours)*time.Hour + time.Minute)
+		s.now = func() time.Time { return now }
+		assert.NoError(t, s.Reconcile(context.Background()))
+	}
+
+	runs, err := ListRuns(context.Background(), client, s.identity, "project-1", "nightly")
+	assert.NoError(t, err)
+	assert.Len(t, runs, historyLimit)
+}
+
+func TestReconcileAuditsRunsAsTheScheduleUser(t *testing.T) {
+	mockClient := kubernetes.NewMockKubernetesClient(slog.Default())
+	client := completingClient{mockClient}
+	historyLimit := 1
+	createTestSchedule(t, client, &models.EvaluationSchedule{
+		Name:                       "nightly",
+		Namespace:                  "project-1",
+		Schedule:                   "@hourly",
+		Evaluation:                 models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+		SuccessfulRunsHistoryLimit: &historyLimit,
+	})
+
+	var auditBuf bytes.Buffer
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	s.Audit = audit.NewLogger(&auditBuf)
+	for hours := 1; hours <= 2; hours++ {
+		now := time.Now().Add(time.Duration(hours)*time.Hour + time.Minute)
+		s.now = func() time.Time { return now }
+		assert.NoError(t, s.Reconcile(context.Background()))
+	}
+
+	var events []map[string]any
+	decoder := json.NewDecoder(&auditBuf)
+	for decoder.More() {
+		var event map[string]any
+		assert.NoError(t, decoder.Decode(&event))
+		events = append(events, event)
+	}
+	assert.Len(t, events, 3)
+	assert.Equal(t, audit.ActionCreateEvaluation, events[0]["action"])
+	assert.Equal(t, "test-user", events[0]["user"])
+	assert.Equal(t, []any{"system:authenticated"}, events[0]["groups"])
+	assert.Equal(t, audit.ActionCreateEvaluation, events[1]["action"])
+	assert.Equal(t, audit.ActionDeleteEvaluation, events[2]["action"])
+	assert.Equal(t, s.identity.UserID, events[2]["user"])
+	assert.Equal(t, audit.OutcomeSuccess, events[2]["outcome"])
+}
+
+func TestReconcileSkipsUnsignedSchedules(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+	schedule := &models.EvaluationSchedule{
+		Name:       "nightly",
+		Namespace:  "project-1",
+		Schedule:   "@hourly",
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+	}
+	createTestSchedule(t, client, schedule)
+
+	// The ConfigMap is edited by hand to run as someone else
+	schedule.RunAs = models.RunAs{User: "cluster-admin"}
+	configMap, err := ToConfigMap(schedule)
+	assert.NoError(t, err)
+	_, err = client.UpdateConfigMap(context.Background(), "project-1", configMap)
+	assert.NoError(t, err)
+
+	// So is one written without the API
+	forged := *schedule
+	forged.Name = "forged"
+	forged.Signature = ""
+	configMap, err = ToConfigMap(&forged)
+	assert.NoError(t, err)
+	_, err = client.CreateConfigMap(context.Background(), "project-1", configMap)
+	assert.NoError(t, err)
+
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	s.now = func() time.Time { return time.Now().Add(3 * time.Hour) }
+	assert.NoError(t, s.Reconcile(context.Background()))
+
+	jobs, err := client.ListLMEvalJobs(context.Background(), s.identity, "project-1")
+	assert.NoError(t, err)
+	for _, job := range jobs.Items {
+		assert.Empty(t, job.Metadata.Labels[constants.ScheduleLabel])
+	}
+}
+
+func TestReconcileSkipsRunsTheUserMayNotCreate(t *testing.T) {
+	mockClient := kubernetes.NewMockKubernetesClient(slog.Default())
+	client := denyingClient{mockClient}
+	createTestSchedule(t, client, &models.EvaluationSchedule{
+		Name:       "nightly",
+		Namespace:  "project-1",
+		Schedule:   "@hourly",
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+	})
+
+	s := New(client, testSigner, testJobBuilder, slog.Default(), time.Minute)
+	now := time.Now().Add(3 * time.Hour)
+	s.now = func() time.Time { return now }
+	assert.NoError(t, s.Reconcile(context.Background()))
+
+	runs, err := ListRuns(context.Background(), client, s.identity, "project-1", "nightly")
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+
+	// The activation is skipped, not retried
+	configMap, err := client.GetConfigMap(context.Background(), "project-1", ConfigMapName("nightly"))
+	assert.NoError(t, err)
+	schedule, err := FromConfigMap(configMap)
+	assert.NoError(t, err)
+	assert.True(t, schedule.NextScheduleTime.After(now))
+	assert.NoError(t, Verify(testSigner, schedule))
+}
+
+func TestValidateSchedule(t *testing.T) {
+	valid := models.EvaluationSchedule{
+		Name:       "nightly",
+		Schedule:   "0 2 * * *",
+		TimeZone:   "Europe/Berlin",
+		Evaluation: models.LMEvalCreateRequest{ModelType: "llama", Tasks: []string{"arc_easy"}},
+	}
+	assert.NoError(t, Validate(&valid))
+
+	invalidCron := valid
+	invalidCron.Schedule = "every night"
+	assert.Error(t, Validate(&invalidCron))
+
+	invalidZone := valid
+	invalidZone.TimeZone = "Mars/Olympus"
+	assert.Error(t, Validate(&invalidZone))
+
+	noTasks := valid
+	noTasks.Evaluation.Tasks = nil
+	assert.Error(t, Validate(&noTasks))
+}
@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/signing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	configMapPrefix = "eval-schedule-"
+	dataKey         = "schedule.json"
+
+	DefaultSuccessfulRunsHistoryLimit = 3
+	DefaultFailedRunsHistoryLimit     = 1
+)
+
+// scheduleSpec is what a schedule runs and when, the JSON document kept under dataKey
+type scheduleSpec struct {
+	Schedule                   string                     `json:"schedule"`
+	TimeZone                   string                     `json:"timeZone,omitempty"`
+	Suspend                    bool                       `json:"suspend,omitempty"`
+	Evaluation                 models.LMEvalCreateRequest `json:"evaluation"`
+	SuccessfulRunsHistoryLimit *int                       `json:"successfulRunsHistoryLimit,omitempty"`
+	FailedRunsHistoryLimit     *int                       `json:"failedRunsHistoryLimit,omitempty"`
+	RunAs                      models.RunAs               `json:"runAs"`
+}
+
+// signedSchedule is the part of a schedule its signature covers: what runs, where and as whom
+type signedSchedule struct {
+	Namespace  string                     `json:"namespace"`
+	Name       string                     `json:"name"`
+	RunAs      models.RunAs               `json:"runAs"`
+	Evaluation models.LMEvalCreateRequest `json:"evaluation"`
+}
+
+func signedFields(schedule *models.EvaluationSchedule) signedSchedule {
+	return signedSchedule{
+		Namespace:  schedule.Namespace,
+		Name:       schedule.Name,
+		RunAs:      schedule.RunAs,
+		Evaluation: schedule.Evaluation,
+	}
+}
+
+// Sign sets the signature of a schedule saved through the API
+func Sign(signer *signing.Signer, schedule *models.EvaluationSchedule) error {
+	signature, err := signer.Sign(signedFields(schedule))
+	if err != nil {
+		return err
+	}
+	schedule.Signature = signature
+	return nil
+}
+
+// Verify checks that a schedule read back from its ConfigMap was saved through the API and
+// not written by hand, so that its RunAs can be trusted
+func Verify(signer *signing.Signer, schedule *models.EvaluationSchedule) error {
+	if err := signer.Verify(signedFields(schedule), schedule.Signature); err != nil {
+		return fmt.Errorf("schedule %s/%s was not saved through the dashboard API: %w", schedule.Namespace, schedule.Name, err)
+	}
+	return nil
+}
+
+// ConfigMapName returns the name of the ConfigMap storing the named schedule
+func ConfigMapName(name string) string {
+	return configMapPrefix + name
+}
+
+// LabelSelector selects the ConfigMaps storing schedules
+func LabelSelector() string {
+	return constants.EvaluationScheduleLabel + "=true"
+}
+
+// Validate checks a schedule before it is stored
+func Validate(schedule *models.EvaluationSchedule) error {
+	if schedule.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if errs := validation.IsDNS1123Label(schedule.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid schedule name %q: %s", schedule.Name, strings.Join(errs, ", "))
+	}
+	// Leave room for the run suffix appended to the schedule name
+	if len(schedule.Name) > 52 {
+		return fmt.Errorf("schedule name must be no more than 52 characters")
+	}
+	if _, err := ParseCron(schedule.Schedule); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		return fmt.Errorf("invalid timeZone %q: %w", schedule.TimeZone, err)
+	}
+	if schedule.Evaluation.ModelType == "" {
+		return fmt.Errorf("evaluation.modelType is required")
+	}
+	if len(schedule.Evaluation.Tasks) == 0 {
+		return fmt.Errorf("evaluation must have at least one task")
+	}
+	// Runs are built without a client to look up the dataset with
+	if schedule.Evaluation.Dataset != nil {
+		return fmt.Errorf("scheduled evaluations cannot use a dataset")
+	}
+	if limit := schedule.SuccessfulRunsHistoryLimit; limit != nil && *limit < 0 {
+		return fmt.Errorf("successfulRunsHistoryLimit must not be negative")
+	}
+	if limit := schedule.FailedRunsHistoryLimit; limit != nil && *limit < 0 {
+		return fmt.Errorf("failedRunsHistoryLimit must not be negative")
+	}
+	return nil
+}
+
+// ToConfigMap serialises a schedule into the ConfigMap that stores it
+func ToConfigMap(schedule *models.EvaluationSchedule) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(scheduleSpec{
+		Schedule:                   schedule.Schedule,
+		TimeZone:                   schedule.TimeZone,
+		Suspend:                    schedule.Suspend,
+		Evaluation:                 schedule.Evaluation,
+		SuccessfulRunsHistoryLimit: schedule.SuccessfulRunsHistoryLimit,
+		FailedRunsHistoryLimit:     schedule.FailedRunsHistoryLimit,
+		RunAs:                      schedule.RunAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schedule: %w", err)
+	}
+
+	annotations := map[string]string{
+		constants.CreatedByAnnotation: schedule.CreatedBy,
+		constants.SignatureAnnotation: schedule.Signature,
+	}
+	if schedule.LastScheduleTime != nil {
+		annotations[constants.LastScheduleTimeAnnotation] = schedule.LastScheduleTime.UTC().Format(time.RFC3339)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ConfigMapName(schedule.Name),
+			ResourceVersion: schedule.ResourceVersion,
+			Labels: map[string]string{
+				constants.EvaluationScheduleLabel: "true",
+			},
+			Annotations: annotations,
+		},
+		Data: map[string]string{
+			dataKey: string(data),
+		},
+	}, nil
+}
+
+// FromConfigMap reads a schedule back from its ConfigMap and computes the next run time
+func FromConfigMap(configMap *corev1.ConfigMap) (*models.EvaluationSchedule, error) {
+	if configMap.Labels[constants.EvaluationScheduleLabel] != "true" {
+		return nil, fmt.Errorf("configmap %q does not hold an evaluation schedule", configMap.Name)
+	}
+
+	var spec scheduleSpec
+	if err := json.Unmarshal([]byte(configMap.Data[dataKey]), &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule %q: %w", configMap.Name, err)
+	}
+
+	schedule := &models.EvaluationSchedule{
+		Name:                       strings.TrimPrefix(configMap.Name, configMapPrefix),
+		Namespace:                  configMap.Namespace,
+		Schedule:                   spec.Schedule,
+		TimeZone:                   spec.TimeZone,
+		Suspend:                    spec.Suspend,
+		Evaluation:                 spec.Evaluation,
+		SuccessfulRunsHistoryLimit: spec.SuccessfulRunsHistoryLimit,
+		FailedRunsHistoryLimit:     spec.FailedRunsHistoryLimit,
+		ResourceVersion:            configMap.ResourceVersion,
+		CreatedBy:                  configMap.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp:          configMap.CreationTimestamp.Time,
+		RunAs:                      spec.RunAs,
+		Signature:                  configMap.Annotations[constants.SignatureAnnotation],
+	}
+
+	if value := configMap.Annotations[constants.LastScheduleTimeAnnotation]; value != "" {
+		if last, err := time.Parse(time.RFC3339, value); err == nil {
+			schedule.LastScheduleTime = &last
+		}
+	}
+
+	if !schedule.Suspend {
+		if next, err := NextScheduleTime(schedule); err == nil && !next.IsZero() {
+			schedule.NextScheduleTime = &next
+		}
+	}
+
+	return schedule, nil
+}
+
+// NextScheduleTime returns the first activation after the last run, or after creation
+// when the schedule has not run yet.
+func NextScheduleTime(schedule *models.EvaluationSchedule) (time.Time, error) {
+	cron, err := ParseCron(schedule.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from := schedule.CreationTimestamp
+	if schedule.LastScheduleTime != nil {
+		from = *schedule.LastScheduleTime
+	}
+
+	return cron.Next(from.In(location)), nil
+}
@@ -0,0 +1,110 @@
+// Package signing authenticates the records the BFF stores in user namespaces and later acts
+// on with its service account, such as evaluation schedules. Users who can write ConfigMaps in
+// a namespace could otherwise forge such a record, and the identity it runs as, by hand.
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretName is the Secret in the BFF's namespace holding the key shared by its replicas
+	SecretName = "trustyai-dashboard-signing-key"
+	secretKey  = "key"
+	keyBytes   = 32
+)
+
+// ErrInvalidSignature is returned for records without a valid signature of the BFF
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Signer signs records with HMAC-SHA256
+type Signer struct {
+	key []byte
+}
+
+func New(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// NewEphemeral returns a signer with a random key, for a single BFF process outside of a
+// cluster. Records it signs are not valid for other processes.
+func NewEphemeral() (*Signer, error) {
+	key := make([]byte, keyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return New(key), nil
+}
+
+// Load reads the signing key from its Secret in namespace, creating the Secret on first start
+func Load(ctx context.Context, client kubernetes.KubernetesClientInterface, namespace string) (*Signer, error) {
+	secret, err := client.GetSecret(ctx, namespace, SecretName)
+	if apierrors.IsNotFound(err) {
+		key := make([]byte, keyBytes)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+		secret, err = client.CreateSecret(ctx, namespace, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: SecretName},
+			Data:       map[string][]byte{secretKey: key},
+		})
+		// Another replica created it first
+		if apierrors.IsAlreadyExists(err) {
+			secret, err = client.GetSecret(ctx, namespace, SecretName)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key %s/%s: %w", namespace, SecretName, err)
+	}
+	if len(secret.Data[secretKey]) < keyBytes {
+		return nil, fmt.Errorf("signing key %s/%s must hold at least %d bytes in %q", namespace, SecretName, keyBytes, secretKey)
+	}
+	return New(secret.Data[secretKey]), nil
+}
+
+// Sign returns the signature of the JSON encoding of v
+func (s *Signer) Sign(v any) (string, error) {
+	mac, err := s.mac(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(mac), nil
+}
+
+// Verify checks that signature was made by Sign for v
+func (s *Signer) Verify(v any, signature string) error {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+	mac, err := s.mac(v)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(mac, decoded) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Signer) mac(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed record: %w", err)
+	}
+	h := hmac.New(sha256.New, s.key)
+	h.Write(data)
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer := New([]byte("0123456789abcdef0123456789abcdef"))
+	record := map[string]string{"user": "alice"}
+
+	signature, err := signer.Sign(record)
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Verify(record, signature))
+
+	assert.ErrorIs(t, signer.Verify(map[string]string{"user": "admin"}, signature), ErrInvalidSignature)
+	assert.ErrorIs(t, signer.Verify(record, ""), ErrInvalidSignature)
+	assert.ErrorIs(t, signer.Verify(record, "not base64!"), ErrInvalidSignature)
+
+	other, err := NewEphemeral()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, other.Verify(record, signature), ErrInvalidSignature)
+}
+
+func TestLoadSharesTheKey(t *testing.T) {
+	client := kubernetes.NewMockKubernetesClient(slog.Default())
+
+	first, err := Load(context.Background(), client, "trustyai-dashboard")
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := Load(context.Background(), client, "trustyai-dashboard")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	signature, err := first.Sign("record")
+	assert.NoError(t, err)
+	assert.NoError(t, second.Verify("record", signature))
+}
@@ -0,0 +1,126 @@
+// Package templates stores evaluation templates as labelled ConfigMaps, in a project's
+// namespace or, for cluster-wide templates, in the dashboard's templates namespace.
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/constants"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/gate"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	configMapPrefix = "eval-template-"
+	dataKey         = "template.json"
+)
+
+// preset holds the evaluation settings of a template. Its name, scope, author and
+// resourceVersion come from the ConfigMap.
+type preset struct {
+	DisplayName     string   `json:"displayName,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	Tasks           []string `json:"tasks"`
+	NumFewShot      *int     `json:"numFewShot,omitempty"`
+	Limit           string   `json:"limit,omitempty"`
+	BatchSize       string   `json:"batchSize,omitempty"`
+	AllowRemoteCode bool     `json:"allowRemoteCode,omitempty"`
+	AllowOnline     bool     `json:"allowOnline,omitempty"`
+	Thresholds      []string `json:"thresholds,omitempty"`
+}
+
+// ConfigMapName returns the name of the ConfigMap storing the named template
+func ConfigMapName(name string) string {
+	return configMapPrefix + name
+}
+
+// LabelSelector selects the ConfigMaps storing templates
+func LabelSelector() string {
+	return constants.EvaluationTemplateLabel + "=true"
+}
+
+// IsTemplate reports whether a ConfigMap stores a template
+func IsTemplate(configMap *corev1.ConfigMap) bool {
+	return configMap.Labels[constants.EvaluationTemplateLabel] == "true"
+}
+
+// Validate checks a template before it is stored
+func Validate(template *models.EvaluationTemplate) error {
+	if template.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if errs := validation.IsDNS1123Subdomain(ConfigMapName(template.Name)); len(errs) > 0 {
+		return fmt.Errorf("invalid template name %q: %s", template.Name, strings.Join(errs, ", "))
+	}
+	if len(template.Tasks) == 0 {
+		return fmt.Errorf("at least one task is required")
+	}
+	if template.NumFewShot != nil && *template.NumFewShot < 0 {
+		return fmt.Errorf("numFewShot must not be negative")
+	}
+	return gate.Validate(template.Thresholds)
+}
+
+// ToConfigMap serialises a template into the ConfigMap that stores it
+func ToConfigMap(template *models.EvaluationTemplate) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(preset{
+		DisplayName:     template.DisplayName,
+		Description:     template.Description,
+		Tasks:           template.Tasks,
+		NumFewShot:      template.NumFewShot,
+		Limit:           template.Limit,
+		BatchSize:       template.BatchSize,
+		AllowRemoteCode: template.AllowRemoteCode,
+		AllowOnline:     template.AllowOnline,
+		Thresholds:      template.Thresholds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ConfigMapName(template.Name),
+			Labels: map[string]string{
+				constants.EvaluationTemplateLabel: "true",
+			},
+			Annotations: map[string]string{
+				constants.CreatedByAnnotation: template.CreatedBy,
+			},
+		},
+		Data: map[string]string{
+			dataKey: string(data),
+		},
+	}, nil
+}
+
+// FromConfigMap reads back a template stored with the given scope
+func FromConfigMap(configMap *corev1.ConfigMap, scope string) (*models.EvaluationTemplate, error) {
+	var preset preset
+	if err := json.Unmarshal([]byte(configMap.Data[dataKey]), &preset); err != nil {
+		return nil, fmt.Errorf("failed to decode template %q: %w", configMap.Name, err)
+	}
+
+	return &models.EvaluationTemplate{
+		Name:              strings.TrimPrefix(configMap.Name, configMapPrefix),
+		Namespace:         configMap.Namespace,
+		Scope:             scope,
+		DisplayName:       preset.DisplayName,
+		Description:       preset.Description,
+		Tasks:             preset.Tasks,
+		NumFewShot:        preset.NumFewShot,
+		Limit:             preset.Limit,
+		BatchSize:         preset.BatchSize,
+		AllowRemoteCode:   preset.AllowRemoteCode,
+		AllowOnline:       preset.AllowOnline,
+		Thresholds:        preset.Thresholds,
+		ResourceVersion:   configMap.ResourceVersion,
+		CreatedBy:         configMap.Annotations[constants.CreatedByAnnotation],
+		CreationTimestamp: configMap.CreationTimestamp.Time,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	numFewShot := 5
+	template := &models.EvaluationTemplate{
+		Name:        "reasoning",
+		DisplayName: "Reasoning",
+		Tasks:       []string{"arc_easy", "hellaswag"},
+		NumFewShot:  &numFewShot,
+		Limit:       "100",
+		Thresholds:  []string{"arc_easy.acc >= 0.7"},
+		CreatedBy:   "test-user",
+	}
+
+	configMap, err := ToConfigMap(template)
+	assert.NoError(t, err)
+	assert.Equal(t, "eval-template-reasoning", configMap.Name)
+	assert.True(t, IsTemplate(configMap))
+
+	configMap.Namespace = "project-1"
+	configMap.ResourceVersion = "42"
+	read, err := FromConfigMap(configMap, models.TemplateScopeNamespace)
+	assert.NoError(t, err)
+	assert.Equal(t, "reasoning", read.Name)
+	assert.Equal(t, "project-1", read.Namespace)
+	assert.Equal(t, models.TemplateScopeNamespace, read.Scope)
+	assert.Equal(t, template.Tasks, read.Tasks)
+	assert.Equal(t, &numFewShot, read.NumFewShot)
+	assert.Equal(t, template.Thresholds, read.Thresholds)
+	assert.Equal(t, "test-user", read.CreatedBy)
+	assert.Equal(t, "42", read.ResourceVersion)
+
+	configMap.Data[dataKey] = "not json"
+	_, err = FromConfigMap(configMap, models.TemplateScopeNamespace)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	negative := -1
+	for name, template := range map[string]models.EvaluationTemplate{
+		"missing name":        {Tasks: []string{"arc_easy"}},
+		"invalid name":        {Name: "Not_Valid", Tasks: []string{"arc_easy"}},
+		"no tasks":            {Name: "reasoning"},
+		"negative numFewShot": {Name: "reasoning", Tasks: []string{"arc_easy"}, NumFewShot: &negative},
+		"invalid threshold":   {Name: "reasoning", Tasks: []string{"arc_easy"}, Thresholds: []string{"accuracy"}},
+	} {
+		assert.Error(t, Validate(&template), name)
+	}
+
+	assert.NoError(t, Validate(&models.EvaluationTemplate{Name: "reasoning", Tasks: []string{"arc_easy"}}))
+}
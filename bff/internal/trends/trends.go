@@ -0,0 +1,93 @@
+// Package trends follows the metrics of a model across its evaluations, showing how its
+// quality drifts across redeployments.
+package trends
+
+import (
+	"sort"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// Options selects the series of the trends
+type Options struct {
+	// Task restricts the series to a task, all tasks when empty
+	Task string
+	// Metric restricts the series to a metric name, such as acc, all metrics when empty
+	Metric string
+}
+
+type seriesKey struct {
+	task, metric, filter string
+}
+
+// Matches reports whether a recorded evaluation is of the model, given either by its name
+// or by the value of its /models option
+func Matches(record *models.EvaluationHistoryRecord, model string) bool {
+	return record.Model == model || (record.ModelEndpoint != "" && record.ModelEndpoint == model)
+}
+
+// Build returns a series per task metric reported by the successfully completed
+// evaluations of the model, with one point per evaluation in completion order
+func Build(model, namespace string, records []models.EvaluationHistoryRecord, opts Options) *models.ModelTrends {
+	series := map[seriesKey]*models.MetricTrend{}
+	for i := range records {
+		record := &records[i]
+		if record.State != models.LMEvalJobStateComplete || !Matches(record, model) {
+			continue
+		}
+
+		for _, metric := range record.Metrics {
+			if (opts.Task != "" && metric.Task != opts.Task) || (opts.Metric != "" && metric.Metric != opts.Metric) {
+				continue
+			}
+
+			key := seriesKey{task: metric.Task, metric: metric.Metric, filter: metric.Filter}
+			if series[key] == nil {
+				series[key] = &models.MetricTrend{
+					Task:           metric.Task,
+					Metric:         metric.Metric,
+					Filter:         metric.Filter,
+					HigherIsBetter: models.MetricHigherIsBetter(metric.Metric),
+				}
+			}
+			series[key].Points = append(series[key].Points, models.TrendPoint{
+				Time:          record.FinishedAt(),
+				Value:         metric.Value,
+				StdErr:        metric.StdErr,
+				Evaluation:    record.Name,
+				UID:           record.UID,
+				DisplayName:   record.DisplayName,
+				Experiment:    record.Experiment,
+				ModelEndpoint: record.ModelEndpoint,
+				Deleted:       record.DeletedAt != nil,
+			})
+		}
+	}
+
+	trends := &models.ModelTrends{Model: model, Namespace: namespace, Series: []models.MetricTrend{}}
+	for _, trend := range series {
+		sort.Slice(trend.Points, func(i, j int) bool {
+			if trend.Points[i].Time.Equal(trend.Points[j].Time) {
+				return trend.Points[i].Evaluation < trend.Points[j].Evaluation
+			}
+			return trend.Points[i].Time.Before(trend.Points[j].Time)
+		})
+		// Change is relative to the previous evaluation, positive when the value went up
+		for i := 1; i < len(trend.Points); i++ {
+			change := trend.Points[i].Value - trend.Points[i-1].Value
+			trend.Points[i].Change = &change
+		}
+		trends.Series = append(trends.Series, *trend)
+	}
+	sort.Slice(trends.Series, func(i, j int) bool {
+		a, b := trends.Series[i], trends.Series[j]
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		if a.Metric != b.Metric {
+			return a.Metric < b.Metric
+		}
+		return a.Filter < b.Filter
+	})
+	return trends
+}
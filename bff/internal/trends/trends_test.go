@@ -0,0 +1,103 @@
+package trends
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+var january = time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+func record(uid, model string, month int, metrics ...models.LMEvalMetric) models.EvaluationHistoryRecord {
+	completed := january.AddDate(0, month, 0)
+	return models.EvaluationHistoryRecord{
+		UID:               uid,
+		Name:              "eval-" + uid,
+		Namespace:         "project-1",
+		Model:             model,
+		State:             models.LMEvalJobStateComplete,
+		CreationTimestamp: completed.Add(-time.Hour),
+		CompleteTime:      &completed,
+		Metrics:           metrics,
+	}
+}
+
+func acc(value float64) models.LMEvalMetric {
+	return models.LMEvalMetric{Task: "arc_easy", Metric: "acc", Filter: "none", Value: value}
+}
+
+func perplexity(value float64) models.LMEvalMetric {
+	return models.LMEvalMetric{Task: "wikitext", Metric: "word_perplexity", Filter: "none", Value: value}
+}
+
+func testRecords() []models.EvaluationHistoryRecord {
+	running := record("running", "llama", 3, acc(0.1))
+	running.State = models.LMEvalJobStateRunning
+	deleted := record("deleted", "llama", 0, acc(0.70), perplexity(12))
+	deletedAt := january.AddDate(0, 1, 0)
+	deleted.DeletedAt = &deletedAt
+	redeployed := record("redeployed", "llama-v2", 2, acc(0.75))
+	redeployed.ModelEndpoint = "llama-project-1"
+
+	// Records are listed newest first, like the history does
+	return []models.EvaluationHistoryRecord{
+		running,
+		redeployed,
+		record("latest", "llama", 1, acc(0.80)),
+		record("mistral", "mistral", 1, acc(0.90)),
+		deleted,
+	}
+}
+
+func TestBuild(t *testing.T) {
+	trends := Build("llama", "project-1", testRecords(), Options{})
+
+	assert.Equal(t, "llama", trends.Model)
+	if !assert.Len(t, trends.Series, 2) {
+		return
+	}
+
+	arc := trends.Series[0]
+	assert.Equal(t, "arc_easy", arc.Task)
+	assert.True(t, arc.HigherIsBetter)
+	// Running evaluations and other models are left out, points are oldest first
+	if assert.Len(t, arc.Points, 2) {
+		assert.Equal(t, "eval-deleted", arc.Points[0].Evaluation)
+		assert.True(t, arc.Points[0].Deleted)
+		assert.Nil(t, arc.Points[0].Change)
+		assert.Equal(t, january.AddDate(0, 1, 0), arc.Points[1].Time)
+		assert.InDelta(t, 0.10, *arc.Points[1].Change, 1e-9)
+	}
+
+	wikitext := trends.Series[1]
+	assert.Equal(t, "word_perplexity", wikitext.Metric)
+	assert.False(t, wikitext.HigherIsBetter)
+	assert.Len(t, wikitext.Points, 1)
+}
+
+func TestBuildByModelEndpoint(t *testing.T) {
+	trends := Build("llama-project-1", "project-1", testRecords(), Options{})
+
+	if assert.Len(t, trends.Series, 1) && assert.Len(t, trends.Series[0].Points, 1) {
+		assert.Equal(t, "eval-redeployed", trends.Series[0].Points[0].Evaluation)
+		assert.Equal(t, "llama-project-1", trends.Series[0].Points[0].ModelEndpoint)
+	}
+}
+
+func TestBuildFilters(t *testing.T) {
+	trends := Build("llama", "project-1", testRecords(), Options{Task: "wikitext"})
+	if assert.Len(t, trends.Series, 1) {
+		assert.Equal(t, "wikitext", trends.Series[0].Task)
+	}
+
+	trends = Build("llama", "project-1", testRecords(), Options{Metric: "acc"})
+	if assert.Len(t, trends.Series, 1) {
+		assert.Equal(t, "arc_easy", trends.Series[0].Task)
+	}
+
+	trends = Build("unknown", "project-1", testRecords(), Options{})
+	assert.NotNil(t, trends.Series)
+	assert.Empty(t, trends.Series)
+}
@@ -0,0 +1,344 @@
+// Package trustyai calls the TrustyAI service deployed in a namespace, which monitors the
+// inferences of the models served there.
+package trustyai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// RequestTimeout bounds the requests to the service, apart from explanations
+const RequestTimeout = 30 * time.Second
+
+// FairnessMetrics are the fairness metrics that can be scheduled
+var FairnessMetrics = []string{models.FairnessMetricSPD, models.FairnessMetricDIR}
+
+// DriftMetrics are the drift metrics that can be scheduled
+var DriftMetrics = []string{models.DriftMetricMeanShift, models.DriftMetricFourierMMD, models.DriftMetricKSTest, models.DriftMetricApproxKSTest}
+
+// Client is the API of a TrustyAI service used by the dashboard
+type Client interface {
+	// ListFairnessMetrics lists the scheduled fairness metrics
+	ListFairnessMetrics(ctx context.Context) ([]models.FairnessMetric, error)
+	// ScheduleFairnessMetric schedules a fairness metric and returns its id
+	ScheduleFairnessMetric(ctx context.Context, request models.FairnessMetricRequest) (string, error)
+	// DeleteFairnessMetric stops computing a scheduled fairness metric
+	DeleteFairnessMetric(ctx context.Context, metric, id string) error
+	// ComputeFairnessMetric computes a fairness metric on the latest inferences
+	ComputeFairnessMetric(ctx context.Context, request models.FairnessMetricRequest) (*models.FairnessMetricValue, error)
+
+	// ListDriftMetrics lists the scheduled drift metrics
+	ListDriftMetrics(ctx context.Context) ([]models.DriftMetric, error)
+	// ScheduleDriftMetric schedules a drift metric and returns its id
+	ScheduleDriftMetric(ctx context.Context, request models.DriftMetricRequest) (string, error)
+	// DeleteDriftMetric stops computing a scheduled drift metric
+	DeleteDriftMetric(ctx context.Context, metric, id string) error
+	// TagData tags ranges of the stored inferences of a model, e.g. as a drift reference
+	TagData(ctx context.Context, request models.DataTagRequest) error
+	// UploadData stores inferences given as a /data/upload payload, the KServe v2 request
+	// and response along with the model and tag. It returns the answer of the service.
+	UploadData(ctx context.Context, payload io.Reader) (string, error)
+
+	// MetricSeries returns the values of a scheduled metric between start and end, or
+	// ErrSeriesUnavailable
+	MetricSeries(ctx context.Context, metric, id string, start, end time.Time) ([]models.MetricSeries, error)
+
+	// Explain explains a stored prediction of model with a local explainer, LIME or SHAP.
+	// It returns an error wrapping ErrExplanationTimeout when the explainer is too slow.
+	Explain(ctx context.Context, explainer, predictionID string, model ModelConfig, samples int) (*models.Explanation, error)
+}
+
+// IsFairnessMetric reports whether metric is a supported fairness metric
+func IsFairnessMetric(metric string) bool {
+	return slices.Contains(FairnessMetrics, metric)
+}
+
+// IsDriftMetric reports whether metric is a supported drift metric
+func IsDriftMetric(metric string) bool {
+	return slices.Contains(DriftMetrics, metric)
+}
+
+// Validate checks a fairness metric request before it is sent to the service
+func Validate(request *models.FairnessMetricRequest) error {
+	switch {
+	case !IsFairnessMetric(request.MetricName):
+		return fmt.Errorf("metricName must be one of %s", strings.Join(FairnessMetrics, ", "))
+	case request.ModelID == "":
+		return fmt.Errorf("modelId is required")
+	case request.ProtectedAttribute == "":
+		return fmt.Errorf("protectedAttribute is required")
+	case request.OutcomeName == "":
+		return fmt.Errorf("outcomeName is required")
+	case request.PrivilegedAttribute == nil || request.UnprivilegedAttribute == nil:
+		return fmt.Errorf("privilegedAttribute and unprivilegedAttribute are required")
+	case request.FavorableOutcome == nil:
+		return fmt.Errorf("favorableOutcome is required")
+	case request.BatchSize < 0:
+		return fmt.Errorf("batchSize must not be negative")
+	}
+	return nil
+}
+
+// ValidateDrift checks a drift metric request before it is sent to the service
+func ValidateDrift(request *models.DriftMetricRequest) error {
+	switch {
+	case !IsDriftMetric(request.MetricName):
+		return fmt.Errorf("metricName must be one of %s", strings.Join(DriftMetrics, ", "))
+	case request.ModelID == "":
+		return fmt.Errorf("modelId is required")
+	case request.ReferenceTag == "":
+		return fmt.Errorf("referenceTag is required")
+	case request.BatchSize < 0:
+		return fmt.Errorf("batchSize must not be negative")
+	case request.Epsilon != nil && request.MetricName != models.DriftMetricApproxKSTest:
+		return fmt.Errorf("epsilon only applies to %s", models.DriftMetricApproxKSTest)
+	case (request.Gamma != nil || request.Parameters != nil) && request.MetricName != models.DriftMetricFourierMMD:
+		return fmt.Errorf("gamma and parameters only apply to %s", models.DriftMetricFourierMMD)
+	}
+	return nil
+}
+
+// ValidateDataTag checks a data tag request before it is sent to the service
+func ValidateDataTag(request *models.DataTagRequest) error {
+	switch {
+	case request.ModelID == "":
+		return fmt.Errorf("modelId is required")
+	case request.Tag == "":
+		return fmt.Errorf("tag is required")
+	case len(request.Ranges) == 0:
+		return fmt.Errorf("at least one range is required")
+	}
+	for _, r := range request.Ranges {
+		if r[0] < 0 || r[1] <= r[0] {
+			return fmt.Errorf("range [%d, %d] is invalid, ranges are [start, end) with 0 <= start < end", r[0], r[1])
+		}
+	}
+	return nil
+}
+
+// httpClient calls the TrustyAI service REST API
+type httpClient struct {
+	http       integrations.HTTPClientInterface
+	explainer  integrations.HTTPClientInterface
+	upload     integrations.HTTPClientInterface
+	prometheus *PrometheusClient
+}
+
+// NewClient returns a client of the TrustyAI service that http is bound to. Explanations,
+// which take longer than the other requests, are requested with explainer and uploads,
+// only bounded by their context, with upload, each falling back to http when nil. Time series are read from prometheus, which may be nil when there is none.
+func NewClient(http, explainer, upload integrations.HTTPClientInterface, prometheus *PrometheusClient) Client {
+	if explainer == nil {
+		explainer = http
+	}
+	if upload == nil {
+		upload = http
+	}
+	return &httpClient{http: http, explainer: explainer, upload: upload, prometheus: prometheus}
+}
+
+// fairnessPath is the path of the API of a group fairness metric, e.g. /metrics/group/fairness/spd
+func fairnessPath(metric string) string {
+	return "/metrics/group/fairness/" + strings.ToLower(metric)
+}
+
+// driftPath is the path of the API of a drift metric, e.g. /metrics/drift/meanshift
+func driftPath(metric string) string {
+	return "/metrics/drift/" + strings.ToLower(metric)
+}
+
+// scheduledRequest is a metric request as listed by the service
+type scheduledRequest struct {
+	ID      string          `json:"id"`
+	Request json.RawMessage `json:"request"`
+}
+
+// listRequests lists the scheduled requests of the metric API at path
+func (c *httpClient) listRequests(ctx context.Context, path string) ([]scheduledRequest, error) {
+	body, err := c.http.GET(ctx, path+"/requests")
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Requests []scheduledRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode the requests of %s: %w", path, err)
+	}
+	return response.Requests, nil
+}
+
+// scheduleRequest schedules a request on the metric API at path and returns its id
+func (c *httpClient) scheduleRequest(ctx context.Context, path string, request any) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	body, err := c.http.POST(ctx, path+"/request", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		RequestID string `json:"requestId"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to decode the scheduled request: %w", err)
+	}
+	return response.RequestID, nil
+}
+
+// deleteRequest deletes a scheduled request of the metric API at path
+func (c *httpClient) deleteRequest(ctx context.Context, path, id string) error {
+	payload, err := json.Marshal(map[string]string{"requestId": id})
+	if err != nil {
+		return err
+	}
+	_, err = c.http.DELETE(ctx, path+"/request", bytes.NewReader(payload))
+	return err
+}
+
+func (c *httpClient) ListFairnessMetrics(ctx context.Context) ([]models.FairnessMetric, error) {
+	metrics := []models.FairnessMetric{}
+	for _, metric := range FairnessMetrics {
+		requests, err := c.listRequests(ctx, fairnessPath(metric))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s requests: %w", metric, err)
+		}
+		for _, scheduled := range requests {
+			var request models.FairnessMetricRequest
+			if err := json.Unmarshal(scheduled.Request, &request); err != nil {
+				return nil, fmt.Errorf("failed to decode %s request %s: %w", metric, scheduled.ID, err)
+			}
+			// Older services leave the metric name out
+			request.MetricName = metric
+			metrics = append(metrics, models.FairnessMetric{ID: scheduled.ID, Request: request})
+		}
+	}
+	return metrics, nil
+}
+
+func (c *httpClient) ScheduleFairnessMetric(ctx context.Context, request models.FairnessMetricRequest) (string, error) {
+	return c.scheduleRequest(ctx, fairnessPath(request.MetricName), request)
+}
+
+func (c *httpClient) DeleteFairnessMetric(ctx context.Context, metric, id string) error {
+	return c.deleteRequest(ctx, fairnessPath(metric), id)
+}
+
+func (c *httpClient) ComputeFairnessMetric(ctx context.Context, request models.FairnessMetricRequest) (*models.FairnessMetricValue, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.http.POST(ctx, fairnessPath(request.MetricName), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Value              float64                    `json:"value"`
+		Timestamp          json.RawMessage            `json:"timestamp"`
+		SpecificDefinition string                     `json:"specificDefinition"`
+		Thresholds         *models.FairnessThresholds `json:"thresholds"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode the %s value: %w", request.MetricName, err)
+	}
+	return &models.FairnessMetricValue{
+		Value:       response.Value,
+		Timestamp:   parseTimestamp(response.Timestamp),
+		Description: response.SpecificDefinition,
+		Thresholds:  response.Thresholds,
+	}, nil
+}
+
+func (c *httpClient) ListDriftMetrics(ctx context.Context) ([]models.DriftMetric, error) {
+	metrics := []models.DriftMetric{}
+	for _, metric := range DriftMetrics {
+		requests, err := c.listRequests(ctx, driftPath(metric))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s requests: %w", metric, err)
+		}
+		for _, scheduled := range requests {
+			var request models.DriftMetricRequest
+			if err := json.Unmarshal(scheduled.Request, &request); err != nil {
+				return nil, fmt.Errorf("failed to decode %s request %s: %w", metric, scheduled.ID, err)
+			}
+			// The service names metrics in upper case
+			request.MetricName = metric
+			metrics = append(metrics, models.DriftMetric{ID: scheduled.ID, Request: request})
+		}
+	}
+	return metrics, nil
+}
+
+func (c *httpClient) ScheduleDriftMetric(ctx context.Context, request models.DriftMetricRequest) (string, error) {
+	return c.scheduleRequest(ctx, driftPath(request.MetricName), request)
+}
+
+func (c *httpClient) DeleteDriftMetric(ctx context.Context, metric, id string) error {
+	return c.deleteRequest(ctx, driftPath(metric), id)
+}
+
+func (c *httpClient) TagData(ctx context.Context, request models.DataTagRequest) error {
+	ranges := make([][]int, 0, len(request.Ranges))
+	for _, r := range request.Ranges {
+		ranges = append(ranges, []int{r[0], r[1]})
+	}
+	payload, err := json.Marshal(map[string]any{
+		"modelId":     request.ModelID,
+		"dataTagging": map[string][][]int{request.Tag: ranges},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.http.POST(ctx, "/data/tag", bytes.NewReader(payload))
+	return err
+}
+
+func (c *httpClient) UploadData(ctx context.Context, payload io.Reader) (string, error) {
+	body, err := c.upload.POST(ctx, "/data/upload", payload)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (c *httpClient) MetricSeries(ctx context.Context, metric, id string, start, end time.Time) ([]models.MetricSeries, error) {
+	if c.prometheus == nil {
+		return nil, ErrSeriesUnavailable
+	}
+	return c.prometheus.MetricSeries(ctx, metric, id, start, end)
+}
+
+// timestampLayouts are the formats the service versions write timestamps in
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999-07:00", "2006-01-02T15:04:05.999999999"}
+
+// parseTimestamp reads a timestamp written as a string or as epoch milliseconds, nil when
+// it is missing or unreadable
+func parseTimestamp(raw json.RawMessage) *time.Time {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		if millis, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			timestamp := time.UnixMilli(millis).UTC()
+			return &timestamp
+		}
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, text); err == nil {
+			timestamp = timestamp.UTC()
+			return &timestamp
+		}
+	}
+	return nil
+}
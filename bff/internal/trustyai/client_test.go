@@ -0,0 +1,459 @@
+package trustyai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	http, err := integrations.NewHTTPClient(slog.New(slog.NewTextHandler(io.Discard, nil)), server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the HTTP client: %v", err)
+	}
+	return NewClient(http, nil, nil, nil)
+}
+
+func TestListFairnessMetrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics/group/fairness/spd/requests":
+			_, _ = w.Write([]byte(`{"requests":[{"id":"spd-1","request":{"modelId":"loan","metricName":"SPD","protectedAttribute":"gender","privilegedAttribute":"male","unprivilegedAttribute":"female","outcomeName":"approved","favorableOutcome":1,"batchSize":100}}]}`))
+		case "/metrics/group/fairness/dir/requests":
+			// Older services leave the metric name out
+			_, _ = w.Write([]byte(`{"requests":[{"id":"dir-1","request":{"modelId":"loan","protectedAttribute":"age","privilegedAttribute":1,"unprivilegedAttribute":0,"outcomeName":"approved","favorableOutcome":1}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	metrics, err := client.ListFairnessMetrics(context.Background())
+	if !assert.NoError(t, err) || !assert.Len(t, metrics, 2) {
+		return
+	}
+	assert.Equal(t, "spd-1", metrics[0].ID)
+	assert.Equal(t, "male", metrics[0].Request.PrivilegedAttribute)
+	assert.Equal(t, 100, metrics[0].Request.BatchSize)
+	assert.Equal(t, "dir-1", metrics[1].ID)
+	assert.Equal(t, models.FairnessMetricDIR, metrics[1].Request.MetricName)
+	assert.Equal(t, float64(1), metrics[1].Request.PrivilegedAttribute)
+}
+
+func TestScheduleComputeAndDeleteFairnessMetric(t *testing.T) {
+	request := models.FairnessMetricRequest{
+		ModelID:               "loan",
+		MetricName:            models.FairnessMetricSPD,
+		ProtectedAttribute:    "gender",
+		PrivilegedAttribute:   1,
+		UnprivilegedAttribute: 0,
+		OutcomeName:           "approved",
+		FavorableOutcome:      1,
+	}
+	var deleted string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /metrics/group/fairness/spd/request":
+			var body models.FairnessMetricRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "gender", body.ProtectedAttribute)
+			_, _ = w.Write([]byte(`{"requestId":"spd-1","timestamp":"2026-03-01T10:00:00.000+00:00"}`))
+		case "POST /metrics/group/fairness/spd":
+			_, _ = w.Write([]byte(`{"timestamp":"2026-03-01T10:00:00.586+00:00","type":"metric","value":-0.15,"specificDefinition":"The SPD of -0.15 indicates bias","name":"SPD","id":"x","thresholds":{"lowerBound":-0.1,"upperBound":0.1,"outsideBounds":true}}`))
+		case "DELETE /metrics/group/fairness/spd/request":
+			var body struct {
+				RequestID string `json:"requestId"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			deleted = body.RequestID
+			_, _ = w.Write([]byte("Removed"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	id, err := client.ScheduleFairnessMetric(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, "spd-1", id)
+
+	value, err := client.ComputeFairnessMetric(context.Background(), request)
+	if assert.NoError(t, err) {
+		assert.InDelta(t, -0.15, value.Value, 1e-9)
+		if assert.NotNil(t, value.Timestamp) {
+			assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 586000000, time.UTC), *value.Timestamp)
+		}
+		assert.Equal(t, "The SPD of -0.15 indicates bias", value.Description)
+		if assert.NotNil(t, value.Thresholds) {
+			assert.True(t, value.Thresholds.OutsideBounds)
+		}
+	}
+
+	assert.NoError(t, client.DeleteFairnessMetric(context.Background(), models.FairnessMetricSPD, "spd-1"))
+	assert.Equal(t, "spd-1", deleted)
+}
+
+func TestFairnessMetricServiceErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No feature found with name=gender"))
+	})
+
+	_, err := client.ComputeFairnessMetric(context.Background(), models.FairnessMetricRequest{MetricName: models.FairnessMetricDIR})
+	var httpError *integrations.HTTPError
+	if assert.ErrorAs(t, err, &httpError) {
+		assert.Equal(t, http.StatusBadRequest, httpError.StatusCode)
+		assert.Equal(t, "No feature found with name=gender", httpError.Message)
+	}
+}
+
+func TestFairnessMetricRequestsAreCancelledWithTheirContext(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.ListFairnessMetrics(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2026, 3, 1, 10, 0, 0, 586000000, time.UTC)
+	for _, raw := range []string{`"2026-03-01T10:00:00.586+00:00"`, `"2026-03-01T10:00:00.586Z"`, `"2026-03-01T10:00:00.586"`, `1772359200586`} {
+		timestamp := parseTimestamp(json.RawMessage(raw))
+		if assert.NotNil(t, timestamp, raw) {
+			assert.Equal(t, expected, *timestamp, raw)
+		}
+	}
+
+	// Missing and unreadable timestamps are left out rather than replaced by the current time
+	for _, raw := range []string{``, `null`, `"yesterday"`, `{}`} {
+		assert.Nil(t, parseTimestamp(json.RawMessage(raw)), raw)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := models.FairnessMetricRequest{
+		ModelID:               "loan",
+		MetricName:            models.FairnessMetricDIR,
+		ProtectedAttribute:    "gender",
+		PrivilegedAttribute:   "male",
+		UnprivilegedAttribute: "female",
+		OutcomeName:           "approved",
+		FavorableOutcome:      true,
+	}
+	assert.NoError(t, Validate(&valid))
+
+	invalid := valid
+	invalid.MetricName = "EOD"
+	assert.ErrorContains(t, Validate(&invalid), "metricName must be one of SPD, DIR")
+
+	invalid = valid
+	invalid.FavorableOutcome = nil
+	assert.Error(t, Validate(&invalid))
+
+	invalid = valid
+	invalid.ProtectedAttribute = ""
+	assert.Error(t, Validate(&invalid))
+}
+
+func TestDriftMetrics(t *testing.T) {
+	var tagged map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /metrics/drift/meanshift/requests":
+			_, _ = w.Write([]byte(`{"requests":[{"id":"ms-1","request":{"modelId":"credit","metricName":"MEANSHIFT","referenceTag":"TRAINING","fitColumns":["age"],"batchSize":100}}]}`))
+		case "GET /metrics/drift/fouriermmd/requests", "GET /metrics/drift/kstest/requests", "GET /metrics/drift/approxkstest/requests":
+			_, _ = w.Write([]byte(`{"requests":[]}`))
+		case "POST /metrics/drift/approxkstest/request":
+			var body models.DriftMetricRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "TRAINING", body.ReferenceTag)
+			if assert.NotNil(t, body.Epsilon) {
+				assert.InDelta(t, 0.01, *body.Epsilon, 1e-9)
+			}
+			_, _ = w.Write([]byte(`{"requestId":"aks-1","timestamp":"2026-03-01T10:00:00.000+00:00"}`))
+		case "POST /data/tag":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&tagged))
+			_, _ = w.Write([]byte("1 datapoints successfully tagged."))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	metrics, err := client.ListDriftMetrics(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, metrics, 1) {
+		assert.Equal(t, "ms-1", metrics[0].ID)
+		// The metric name of the dashboard replaces the upper case one of the service
+		assert.Equal(t, models.DriftMetricMeanShift, metrics[0].Request.MetricName)
+		assert.Equal(t, []string{"age"}, metrics[0].Request.FitColumns)
+	}
+
+	epsilon := 0.01
+	id, err := client.ScheduleDriftMetric(context.Background(), models.DriftMetricRequest{
+		ModelID: "credit", MetricName: models.DriftMetricApproxKSTest, ReferenceTag: "TRAINING", Epsilon: &epsilon,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "aks-1", id)
+
+	assert.NoError(t, client.TagData(context.Background(), models.DataTagRequest{ModelID: "credit", Tag: "TRAINING", Ranges: [][2]int{{0, 500}}}))
+	assert.Equal(t, map[string]any{
+		"modelId":     "credit",
+		"dataTagging": map[string]any{"TRAINING": []any{[]any{float64(0), float64(500)}}},
+	}, tagged)
+
+	_, err = client.MetricSeries(context.Background(), models.DriftMetricMeanShift, "ms-1", time.Now().Add(-time.Hour), time.Now())
+	assert.ErrorIs(t, err, ErrSeriesUnavailable)
+}
+
+func TestPrometheusMetricSeries(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, queryRangePath, r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, `trustyai_meanshift{request="ms-1"}`, r.URL.Query().Get("query"))
+		assert.Equal(t, "project-1", r.URL.Query().Get("namespace"))
+		assert.Equal(t, "345", r.URL.Query().Get("step"))
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"__name__":"trustyai_meanshift","request":"ms-1","subcategory":"income"},"values":[[1772323200,"0.5"]]},
+			{"metric":{"__name__":"trustyai_meanshift","request":"ms-1","subcategory":"age"},"values":[[1772323200,"0.9"],[1772323545.5,"0.04"]]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	series, err := NewPrometheusClient(server.URL, "project-1", "token").MetricSeries(context.Background(), models.DriftMetricMeanShift, "ms-1", start, end)
+	if !assert.NoError(t, err) || !assert.Len(t, series, 2) {
+		return
+	}
+	assert.Equal(t, "age", series[0].Subcategory)
+	assert.Equal(t, []models.MetricPoint{
+		{Time: time.Unix(1772323200, 0).UTC(), Value: 0.9},
+		{Time: time.UnixMilli(1772323545500).UTC(), Value: 0.04},
+	}, series[0].Points)
+	assert.Equal(t, "income", series[1].Subcategory)
+}
+
+func TestPrometheusErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"invalid parameter"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewPrometheusClient(server.URL, "project-1", "").MetricSeries(context.Background(), models.DriftMetricKSTest, "ks-1", time.Now().Add(-time.Hour), time.Now())
+	assert.ErrorContains(t, err, "HTTP 400: invalid parameter")
+}
+
+func TestValidateDrift(t *testing.T) {
+	valid := models.DriftMetricRequest{ModelID: "credit", MetricName: models.DriftMetricKSTest, ReferenceTag: "TRAINING"}
+	assert.NoError(t, ValidateDrift(&valid))
+
+	invalid := valid
+	invalid.MetricName = "PSI"
+	assert.ErrorContains(t, ValidateDrift(&invalid), "metricName must be one of MeanShift, FourierMMD, KSTest, ApproxKSTest")
+
+	invalid = valid
+	invalid.ReferenceTag = ""
+	assert.Error(t, ValidateDrift(&invalid))
+
+	gamma := 1.5
+	invalid = valid
+	invalid.Gamma = &gamma
+	assert.ErrorContains(t, ValidateDrift(&invalid), "only apply to FourierMMD")
+
+	assert.Error(t, ValidateDataTag(&models.DataTagRequest{ModelID: "credit", Tag: "TRAINING", Ranges: [][2]int{{10, 10}}}))
+	assert.NoError(t, ValidateDataTag(&models.DataTagRequest{ModelID: "credit", Tag: "TRAINING", Ranges: [][2]int{{0, 10}}}))
+}
+
+func TestExplain(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/explainers/local/shap" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"timestamp":"2026-03-01T10:00:00.000+00:00","type":"explanation","saliencies":{"risk":[{"name":"age","score":0.1,"confidence":0.02},{"name":"income","score":-0.4,"confidence":0.03}],"approved":[{"name":"age","score":0.2,"confidence":0}]}}`))
+	})
+
+	explanation, err := client.Explain(context.Background(), models.ExplainerSHAP, "inference-1", ModelConfig{Target: "loan-predictor.project-1.svc.cluster.local:8080", Name: "loan"}, 200)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "inference-1", body["predictionId"])
+	assert.Equal(t, map[string]any{
+		"model":     map[string]any{"target": "loan-predictor.project-1.svc.cluster.local:8080", "name": "loan"},
+		"explainer": map[string]any{"n_samples": float64(200)},
+	}, body["config"])
+
+	if assert.NotNil(t, explanation.Timestamp) {
+		assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), *explanation.Timestamp)
+	}
+	if assert.Len(t, explanation.Saliencies, 2) {
+		assert.Equal(t, "approved", explanation.Saliencies[0].Output)
+		assert.Equal(t, "risk", explanation.Saliencies[1].Output)
+		assert.Equal(t, []models.FeatureSaliency{
+			{Name: "income", Score: -0.4, Confidence: 0.03},
+			{Name: "age", Score: 0.1, Confidence: 0.02},
+		}, explanation.Saliencies[1].Features)
+	}
+}
+
+func TestExplainTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	t.Cleanup(server.Close)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	http, _ := integrations.NewHTTPClient(logger, server.URL)
+	explainer, _ := integrations.NewHTTPClientWithTimeout(logger, server.URL, 50*time.Millisecond)
+
+	_, err := NewClient(http, explainer, nil, nil).Explain(context.Background(), models.ExplainerLIME, "inference-1", ModelConfig{Name: "loan"}, 0)
+	assert.ErrorIs(t, err, ErrExplanationTimeout)
+}
+
+func TestValidateExplanation(t *testing.T) {
+	valid := models.ExplanationRequest{Model: "loan-predictor-project-1", Explainer: models.ExplainerLIME, PredictionID: "inference-1"}
+	assert.NoError(t, ValidateExplanation(&valid))
+
+	for name, change := range map[string]func(*models.ExplanationRequest){
+		"no model":         func(r *models.ExplanationRequest) { r.Model = "" },
+		"unknown":          func(r *models.ExplanationRequest) { r.Explainer = "anchors" },
+		"no prediction":    func(r *models.ExplanationRequest) { r.PredictionID = "" },
+		"too many samples": func(r *models.ExplanationRequest) { r.Samples = 100000 },
+	} {
+		request := valid
+		change(&request)
+		assert.Error(t, ValidateExplanation(&request), name)
+	}
+}
+
+// uploadPayload is a /data/upload payload as the stand-ins of the service read it
+type uploadPayload struct {
+	ModelName string `json:"model_name"`
+	DataTag   string `json:"data_tag"`
+	Request   struct {
+		Inputs []tensor `json:"inputs"`
+	} `json:"request"`
+	Response struct {
+		Outputs []tensor `json:"outputs"`
+	} `json:"response"`
+}
+
+func TestUploadCSV(t *testing.T) {
+	var payloads []uploadPayload
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload uploadPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		_, _ = fmt.Fprintf(w, "%d datapoints successfully added to %s data.", payload.Request.Inputs[0].Shape[0], payload.ModelName)
+	})
+
+	var file strings.Builder
+	file.WriteString("age,city,approved\n")
+	for i := 0; i <= uploadBatchRows; i++ {
+		fmt.Fprintf(&file, "%d,Boston,%t\n", 20+i%50, i%2 == 0)
+	}
+
+	rows, message, err := UploadCSV(context.Background(), client, strings.NewReader(file.String()), models.DataUpload{ModelID: "loan", Tag: "TRAINING", Outputs: []string{"approved"}})
+	assert.NoError(t, err)
+	assert.Equal(t, uploadBatchRows+1, rows)
+	assert.Equal(t, "1 datapoints successfully added to loan data.", message)
+	if !assert.Len(t, payloads, 2) {
+		return
+	}
+	first := payloads[0]
+	assert.Equal(t, "loan", first.ModelName)
+	assert.Equal(t, "TRAINING", first.DataTag)
+	if assert.Len(t, first.Request.Inputs, 2) && assert.Len(t, first.Response.Outputs, 1) {
+		assert.Equal(t, "age", first.Request.Inputs[0].Name)
+		assert.Equal(t, datatypeFP64, first.Request.Inputs[0].Datatype)
+		assert.Equal(t, []int{uploadBatchRows}, first.Request.Inputs[0].Shape)
+		assert.Equal(t, datatypeString, first.Request.Inputs[1].Datatype)
+		assert.Equal(t, "approved", first.Response.Outputs[0].Name)
+		assert.Equal(t, datatypeBool, first.Response.Outputs[0].Datatype)
+		assert.Equal(t, true, first.Response.Outputs[0].Data[0])
+	}
+	assert.Equal(t, []int{1}, payloads[1].Request.Inputs[0].Shape)
+}
+
+func TestUploadCSVErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("added"))
+	})
+	upload := models.DataUpload{ModelID: "loan", Tag: "TRAINING", Outputs: []string{"approved"}}
+
+	for name, file := range map[string]string{
+		"empty":          "",
+		"no rows":        "age,approved\n",
+		"unknown output": "age,risk\n30,1\n",
+		"no inputs":      "approved\n1\n",
+		"ragged":         "age,approved\n30,1,2\n",
+	} {
+		_, _, err := UploadCSV(context.Background(), client, strings.NewReader(file), upload)
+		assert.ErrorIs(t, err, ErrInvalidData, name)
+	}
+
+	// Column types are those of the first batch
+	var file strings.Builder
+	file.WriteString("age,approved\n")
+	for i := 0; i < uploadBatchRows; i++ {
+		file.WriteString("30,1\n")
+	}
+	file.WriteString("thirty,1\n")
+	rows, _, err := UploadCSV(context.Background(), client, strings.NewReader(file.String()), upload)
+	assert.ErrorIs(t, err, ErrInvalidData)
+	assert.ErrorContains(t, err, fmt.Sprintf("row %d", uploadBatchRows+1))
+	assert.Equal(t, uploadBatchRows, rows)
+}
+
+func TestUploadJSON(t *testing.T) {
+	var received map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		_, _ = w.Write([]byte("2 datapoints successfully added to loan data.\n"))
+	})
+
+	file := ` {"model_name": "other", "request": {"inputs": [{"name": "age", "shape": [2], "datatype": "FP64", "data": [30, 40]}]},
+ "response": {"outputs": [{"name": "approved", "shape": [2], "datatype": "INT64", "data": [1, 0]}]}}
+`
+	message, err := UploadJSON(context.Background(), client, strings.NewReader(file), models.DataUpload{ModelID: "loan", Tag: "TRAINING"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2 datapoints successfully added to loan data.", message)
+	assert.Equal(t, "loan", received["model_name"])
+	assert.Equal(t, "TRAINING", received["data_tag"])
+	assert.Contains(t, received, "request")
+	assert.Contains(t, received, "response")
+
+	for name, file := range map[string]string{"empty": " ", "array": "[1, 2]", "empty object": "{ }"} {
+		_, err := UploadJSON(context.Background(), client, strings.NewReader(file), models.DataUpload{ModelID: "loan", Tag: "TRAINING"})
+		assert.ErrorIs(t, err, ErrInvalidData, name)
+	}
+
+	// Other syntax errors are reported by the service
+	_, err = UploadJSON(context.Background(), client, strings.NewReader(`{"request": {}`), models.DataUpload{ModelID: "loan", Tag: "TRAINING"})
+	var httpError *integrations.HTTPError
+	if assert.ErrorAs(t, err, &httpError) {
+		assert.Equal(t, http.StatusBadRequest, httpError.StatusCode)
+	}
+}
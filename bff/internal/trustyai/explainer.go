@@ -0,0 +1,120 @@
+package trustyai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// ExplainerTimeout bounds explanations, for which the explainer sends many perturbed
+// inputs to the model
+const ExplainerTimeout = 2 * time.Minute
+
+// maxSamples bounds the perturbed inputs of an explanation
+const maxSamples = 10000
+
+// Explainers are the local explainers that can be requested
+var Explainers = []string{models.ExplainerLIME, models.ExplainerSHAP}
+
+// ErrExplanationTimeout is wrapped by the errors of explanations the explainer did not
+// return in time
+var ErrExplanationTimeout = errors.New("the TrustyAI service did not explain the prediction in time")
+
+// ModelConfig locates the model the explainer sends perturbed inputs to
+type ModelConfig struct {
+	// Target is the host:port of the inference server
+	Target  string `json:"target"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ValidateExplanation checks an explanation request before it is sent to the service
+func ValidateExplanation(request *models.ExplanationRequest) error {
+	switch {
+	case request.Model == "":
+		return fmt.Errorf("model is required")
+	case !slices.Contains(Explainers, request.Explainer):
+		return fmt.Errorf("explainer must be one of %s", strings.Join(Explainers, ", "))
+	case request.PredictionID == "":
+		return fmt.Errorf("predictionId is required")
+	case request.Samples < 0 || request.Samples > maxSamples:
+		return fmt.Errorf("samples must be between 0 and %d", maxSamples)
+	}
+	return nil
+}
+
+// explainerPath is the path of the API of a local explainer, e.g. /explainers/local/lime
+func explainerPath(explainer string) string {
+	return "/explainers/local/" + strings.ToLower(explainer)
+}
+
+func (c *httpClient) Explain(ctx context.Context, explainer, predictionID string, model ModelConfig, samples int) (*models.Explanation, error) {
+	config := map[string]any{}
+	if samples > 0 {
+		config["n_samples"] = samples
+	}
+	payload, err := json.Marshal(map[string]any{
+		"predictionId": predictionID,
+		"config": map[string]any{
+			"model":     model,
+			"explainer": config,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.explainer.POST(ctx, explainerPath(explainer), bytes.NewReader(payload))
+	if isTimeout(err) {
+		return nil, fmt.Errorf("%w after %s: %w", ErrExplanationTimeout, ExplainerTimeout, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Timestamp  json.RawMessage                     `json:"timestamp"`
+		Saliencies map[string][]models.FeatureSaliency `json:"saliencies"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode the %s explanation: %w", explainer, err)
+	}
+	return &models.Explanation{
+		PredictionID: predictionID,
+		Explainer:    explainer,
+		Timestamp:    parseTimestamp(response.Timestamp),
+		Saliencies:   sortSaliencies(response.Saliencies),
+	}, nil
+}
+
+// sortSaliencies orders the outputs by name and their features by decreasing absolute score
+func sortSaliencies(saliencies map[string][]models.FeatureSaliency) []models.OutputSaliency {
+	outputs := make([]models.OutputSaliency, 0, len(saliencies))
+	for output, features := range saliencies {
+		if features == nil {
+			features = []models.FeatureSaliency{}
+		}
+		sort.SliceStable(features, func(i, j int) bool {
+			return math.Abs(features[i].Score) > math.Abs(features[j].Score)
+		})
+		outputs = append(outputs, models.OutputSaliency{Output: output, Features: features})
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].Output < outputs[j].Output })
+	return outputs
+}
+
+// isTimeout reports whether a request failed for taking too long
+func isTimeout(err error) bool {
+	var netError net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netError) && netError.Timeout())
+}
@@ -0,0 +1,76 @@
+package trustyai
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/config"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations/kubernetes"
+)
+
+// ClientFactory returns the client of a TrustyAI service discovered with
+// KubernetesClientInterface.GetServiceDetails. The token of the user authenticates
+// the requests to Prometheus.
+type ClientFactory interface {
+	GetClient(namespace string, service kubernetes.ServiceDetails, token string) (Client, error)
+}
+
+// NewClientFactory returns the factory for the auth method, in-memory services in mock mode
+func NewClientFactory(cfg config.EnvConfig, logger *slog.Logger) ClientFactory {
+	if cfg.AuthMethod == config.AuthMethodMock {
+		return NewMockClientFactory()
+	}
+	return &httpClientFactory{logger: logger, prometheusURL: cfg.PrometheusURL}
+}
+
+type httpClientFactory struct {
+	logger        *slog.Logger
+	prometheusURL string
+}
+
+// GetClient calls the service on its http-api port. The BFF checks that the user can
+// access the service, the service itself is not authenticated within the cluster.
+func (f *httpClientFactory) GetClient(namespace string, service kubernetes.ServiceDetails, token string) (Client, error) {
+	baseURL := fmt.Sprintf("http://%s:%d", service.ClusterIP, service.HTTPPort)
+	http, err := integrations.NewHTTPClientWithTimeout(f.logger, baseURL, RequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TrustyAI client for %s/%s: %w", namespace, service.Name, err)
+	}
+	explainer, err := integrations.NewHTTPClientWithTimeout(f.logger, baseURL, ExplainerTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TrustyAI explainer client for %s/%s: %w", namespace, service.Name, err)
+	}
+	upload, err := integrations.NewHTTPClient(f.logger, baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TrustyAI upload client for %s/%s: %w", namespace, service.Name, err)
+	}
+	var prometheus *PrometheusClient
+	if f.prometheusURL != "" {
+		prometheus = NewPrometheusClient(f.prometheusURL, namespace, token)
+	}
+	return NewClient(http, explainer, upload, prometheus), nil
+}
+
+// MockClientFactory returns an in-memory service per namespace
+type MockClientFactory struct {
+	mu      sync.Mutex
+	clients map[string]*MockClient
+}
+
+func NewMockClientFactory() *MockClientFactory {
+	return &MockClientFactory{clients: map[string]*MockClient{}}
+}
+
+func (f *MockClientFactory) GetClient(namespace string, _ kubernetes.ServiceDetails, _ string) (Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	client, ok := f.clients[namespace]
+	if !ok {
+		client = NewMockClient()
+		f.clients[namespace] = client
+	}
+	return client, nil
+}
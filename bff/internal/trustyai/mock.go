@@ -0,0 +1,261 @@
+package trustyai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/integrations"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// Ids of the metrics every mock service starts with
+const (
+	MockMetricID      = "b9e7a1c4-2d3f-4e5a-9b8c-1d2e3f4a5b6c"
+	MockDriftMetricID = "4c2d8e1f-7a3b-4d5e-8f9a-0b1c2d3e4f5a"
+)
+
+// defaultThresholdDelta is the threshold delta of the service when a request sets none
+const defaultThresholdDelta = 0.1
+
+// MockClient is an in-memory TrustyAI service for mock mode and tests
+type MockClient struct {
+	mu           sync.Mutex
+	metrics      []models.FairnessMetric
+	driftMetrics []models.DriftMetric
+	// tags are the tagged row ranges per model and tag
+	tags map[string]map[string][][2]int
+	// rows are the numbers of stored inferences per model
+	rows map[string]int
+}
+
+func NewMockClient() *MockClient {
+	return &MockClient{
+		metrics:      []models.FairnessMetric{mockFairnessMetric()},
+		driftMetrics: []models.DriftMetric{mockDriftMetric()},
+		tags:         map[string]map[string][][2]int{"gaussian-credit-model": {"TRAINING": {{0, 1000}}}},
+		rows:         map[string]int{"gaussian-credit-model": 1000},
+	}
+}
+
+func mockFairnessMetric() models.FairnessMetric {
+	return models.FairnessMetric{
+		ID: MockMetricID,
+		Request: models.FairnessMetricRequest{
+			ModelID:               "demo-loan-nn-onnx",
+			MetricName:            models.FairnessMetricSPD,
+			RequestName:           "gender-spd",
+			ProtectedAttribute:    "Is Male-Identifying?",
+			PrivilegedAttribute:   1.0,
+			UnprivilegedAttribute: 0.0,
+			OutcomeName:           "Will Default?",
+			FavorableOutcome:      0.0,
+			BatchSize:             5000,
+		},
+	}
+}
+
+func mockDriftMetric() models.DriftMetric {
+	return models.DriftMetric{
+		ID: MockDriftMetricID,
+		Request: models.DriftMetricRequest{
+			ModelID:      "gaussian-credit-model",
+			MetricName:   models.DriftMetricMeanShift,
+			RequestName:  "credit-meanshift",
+			ReferenceTag: "TRAINING",
+			FitColumns:   []string{"credit_inputs-0", "credit_inputs-1"},
+			BatchSize:    100,
+		},
+	}
+}
+
+func (m *MockClient) ListFairnessMetrics(_ context.Context) ([]models.FairnessMetric, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]models.FairnessMetric{}, m.metrics...), nil
+}
+
+func (m *MockClient) ScheduleFairnessMetric(_ context.Context, request models.FairnessMetricRequest) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := uuid.NewString()
+	m.metrics = append(m.metrics, models.FairnessMetric{ID: id, Request: request})
+	return id, nil
+}
+
+func (m *MockClient) DeleteFairnessMetric(_ context.Context, metric, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, scheduled := range m.metrics {
+		if scheduled.ID == id && scheduled.Request.MetricName == metric {
+			m.metrics = append(m.metrics[:i], m.metrics[i+1:]...)
+			return nil
+		}
+	}
+	return mockNotFound(metric, id)
+}
+
+// ComputeFairnessMetric returns a fixed value, slightly unfair for SPD and fair for DIR
+func (m *MockClient) ComputeFairnessMetric(_ context.Context, request models.FairnessMetricRequest) (*models.FairnessMetricValue, error) {
+	delta := defaultThresholdDelta
+	if request.ThresholdDelta != nil {
+		delta = *request.ThresholdDelta
+	}
+	fair, value := 0.0, -0.12
+	if request.MetricName == models.FairnessMetricDIR {
+		fair, value = 1.0, 0.95
+	}
+	now := time.Now().UTC()
+	return &models.FairnessMetricValue{
+		Value:       value,
+		Timestamp:   &now,
+		Description: fmt.Sprintf("The %s of %g for %s", request.MetricName, value, request.ProtectedAttribute),
+		Thresholds: &models.FairnessThresholds{
+			LowerBound:    fair - delta,
+			UpperBound:    fair + delta,
+			OutsideBounds: value < fair-delta || value > fair+delta,
+		},
+	}, nil
+}
+
+func (m *MockClient) ListDriftMetrics(_ context.Context) ([]models.DriftMetric, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]models.DriftMetric{}, m.driftMetrics...), nil
+}
+
+// ScheduleDriftMetric requires the reference tag to exist, as the service does
+func (m *MockClient) ScheduleDriftMetric(_ context.Context, request models.DriftMetricRequest) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.tags[request.ModelID][request.ReferenceTag]; !ok {
+		return "", &integrations.HTTPError{
+			StatusCode:    http.StatusBadRequest,
+			ErrorResponse: integrations.ErrorResponse{Code: strconv.Itoa(http.StatusBadRequest), Message: fmt.Sprintf("no data tagged %s for model %s", request.ReferenceTag, request.ModelID)},
+		}
+	}
+	id := uuid.NewString()
+	m.driftMetrics = append(m.driftMetrics, models.DriftMetric{ID: id, Request: request})
+	return id, nil
+}
+
+func (m *MockClient) DeleteDriftMetric(_ context.Context, metric, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, scheduled := range m.driftMetrics {
+		if scheduled.ID == id && scheduled.Request.MetricName == metric {
+			m.driftMetrics = append(m.driftMetrics[:i], m.driftMetrics[i+1:]...)
+			return nil
+		}
+	}
+	return mockNotFound(metric, id)
+}
+
+func (m *MockClient) TagData(_ context.Context, request models.DataTagRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tags[request.ModelID] == nil {
+		m.tags[request.ModelID] = map[string][][2]int{}
+	}
+	m.tags[request.ModelID][request.Tag] = append(m.tags[request.ModelID][request.Tag], request.Ranges...)
+	return nil
+}
+
+// UploadData stores the number of uploaded inferences, tagging them as the service does
+func (m *MockClient) UploadData(_ context.Context, payload io.Reader) (string, error) {
+	var upload struct {
+		ModelName string `json:"model_name"`
+		DataTag   string `json:"data_tag"`
+		Request   struct {
+			Inputs []struct {
+				Shape []int `json:"shape"`
+			} `json:"inputs"`
+		} `json:"request"`
+	}
+	if err := json.NewDecoder(payload).Decode(&upload); err != nil || upload.ModelName == "" || len(upload.Request.Inputs) == 0 || len(upload.Request.Inputs[0].Shape) == 0 {
+		return "", &integrations.HTTPError{
+			StatusCode:    http.StatusBadRequest,
+			ErrorResponse: integrations.ErrorResponse{Code: strconv.Itoa(http.StatusBadRequest), Message: "Could not parse the uploaded inferences"},
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rows := upload.Request.Inputs[0].Shape[0]
+	start := m.rows[upload.ModelName]
+	m.rows[upload.ModelName] = start + rows
+	if upload.DataTag != "" {
+		if m.tags[upload.ModelName] == nil {
+			m.tags[upload.ModelName] = map[string][][2]int{}
+		}
+		m.tags[upload.ModelName][upload.DataTag] = append(m.tags[upload.ModelName][upload.DataTag], [2]int{start, start + rows})
+	}
+	return fmt.Sprintf("%d datapoints successfully added to %s data.", rows, upload.ModelName), nil
+}
+
+// MetricSeries returns hourly values drifting away from the reference, a series per fit
+// column of drift metrics
+func (m *MockClient) MetricSeries(_ context.Context, _, id string, start, end time.Time) ([]models.MetricSeries, error) {
+	subcategories := []string{""}
+	m.mu.Lock()
+	for _, scheduled := range m.driftMetrics {
+		if scheduled.ID == id && len(scheduled.Request.FitColumns) > 0 {
+			subcategories = scheduled.Request.FitColumns
+		}
+	}
+	m.mu.Unlock()
+
+	series := []models.MetricSeries{}
+	for i, subcategory := range subcategories {
+		points := []models.MetricPoint{}
+		for t, n := start.Truncate(time.Hour), 0; !t.After(end); t, n = t.Add(time.Hour), n+1 {
+			if t.Before(start) {
+				continue
+			}
+			points = append(points, models.MetricPoint{Time: t.UTC(), Value: 1 / (1 + 0.05*float64(n*(i+1)))})
+		}
+		series = append(series, models.MetricSeries{Subcategory: subcategory, Points: points})
+	}
+	return series, nil
+}
+
+// mockFeatures are the input features of the predictions mock services explain
+var mockFeatures = []string{"credit_inputs-0", "credit_inputs-1", "credit_inputs-2", "credit_inputs-3"}
+
+// Explain returns saliencies derived from the prediction id, the same for every request
+func (m *MockClient) Explain(_ context.Context, explainer, predictionID string, _ ModelConfig, _ int) (*models.Explanation, error) {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(explainer + "/" + predictionID))
+	seed := hash.Sum32()
+
+	features := make([]models.FeatureSaliency, 0, len(mockFeatures))
+	for i, name := range mockFeatures {
+		score := float64(int(seed>>(8*i))%201-100) / 200
+		confidence := 0.0
+		if explainer == models.ExplainerSHAP {
+			confidence = 0.05
+		}
+		features = append(features, models.FeatureSaliency{Name: name, Score: score, Confidence: confidence})
+	}
+	now := time.Now().UTC()
+	return &models.Explanation{
+		PredictionID: predictionID,
+		Explainer:    explainer,
+		Timestamp:    &now,
+		Saliencies:   sortSaliencies(map[string][]models.FeatureSaliency{"credit_output-0": features}),
+	}, nil
+}
+
+func mockNotFound(metric, id string) error {
+	return &integrations.HTTPError{
+		StatusCode:    http.StatusNotFound,
+		ErrorResponse: integrations.ErrorResponse{Code: strconv.Itoa(http.StatusNotFound), Message: fmt.Sprintf("no %s request with id %s", metric, id)},
+	}
+}
@@ -0,0 +1,134 @@
+package trustyai
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	helper "github.com/trustyai-explainability/trustyai-dashboard/bff/internal/helpers"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+const (
+	queryRangePath    = "/api/v1/query_range"
+	prometheusTimeout = 30 * time.Second
+
+	// maxPoints bounds the points of a series, the step grows with the time range
+	maxPoints = 250
+	minStep   = time.Minute
+)
+
+// ErrSeriesUnavailable is returned for time series when no Prometheus is configured
+var ErrSeriesUnavailable = errors.New("metric time series are not available, the BFF has no Prometheus URL configured")
+
+// PrometheusClient reads the values the TrustyAI service publishes from the Prometheus
+// scraping it, typically the tenancy port of the OpenShift Thanos querier, which only
+// returns the series of the namespace the token is allowed to read
+type PrometheusClient struct {
+	baseURL    string
+	namespace  string
+	token      string
+	httpClient *http.Client
+}
+
+func NewPrometheusClient(baseURL, namespace, token string) *PrometheusClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if pool := helper.ServiceCertPool(); pool != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &PrometheusClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		namespace:  namespace,
+		token:      token,
+		httpClient: &http.Client{Timeout: prometheusTimeout, Transport: transport},
+	}
+}
+
+// MetricSeries returns the values of a scheduled metric between start and end, one series
+// per subcategory. The service publishes metric as the trustyai_<metric> gauge labelled
+// with the request id.
+func (c *PrometheusClient) MetricSeries(ctx context.Context, metric, id string, start, end time.Time) ([]models.MetricSeries, error) {
+	query := fmt.Sprintf(`trustyai_%s{request=%q}`, strings.ToLower(metric), id)
+	params := url.Values{
+		"query":     {query},
+		"namespace": {c.namespace},
+		"start":     {strconv.FormatInt(start.Unix(), 10)},
+		"end":       {strconv.FormatInt(end.Unix(), 10)},
+		"step":      {strconv.Itoa(int(step(start, end).Seconds()))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+queryRangePath+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("prometheus request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Metric map[string]string    `json:"metric"`
+				Values [][2]json.RawMessage `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prometheus response: %w", err)
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("prometheus query failed with HTTP %d", resp.StatusCode)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed with HTTP %d: %s", resp.StatusCode, response.Error)
+	}
+
+	series := []models.MetricSeries{}
+	for _, result := range response.Data.Result {
+		points := make([]models.MetricPoint, 0, len(result.Values))
+		for _, value := range result.Values {
+			var seconds float64
+			var text string
+			if json.Unmarshal(value[0], &seconds) != nil || json.Unmarshal(value[1], &text) != nil {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				continue
+			}
+			points = append(points, models.MetricPoint{
+				Time:  time.UnixMilli(int64(seconds * 1000)).UTC(),
+				Value: parsed,
+			})
+		}
+		series = append(series, models.MetricSeries{Subcategory: result.Metric["subcategory"], Points: points})
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Subcategory < series[j].Subcategory })
+	return series, nil
+}
+
+// step spreads at most maxPoints over the time range
+func step(start, end time.Time) time.Duration {
+	step := (end.Sub(start) / maxPoints).Truncate(time.Second)
+	if step < minStep {
+		return minStep
+	}
+	return step
+}
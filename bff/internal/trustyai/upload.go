@@ -0,0 +1,291 @@
+package trustyai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/models"
+)
+
+// uploadBatchRows is the number of CSV rows sent to the service per request
+const uploadBatchRows = 5000
+
+// reservedTagPrefix starts the tags the service uses internally
+const reservedTagPrefix = "_trustyai"
+
+// ErrInvalidData is wrapped by the errors of uploaded files the service cannot take
+var ErrInvalidData = errors.New("invalid data")
+
+// KServe v2 datatypes of the CSV columns
+const (
+	datatypeBool   = "BOOL"
+	datatypeFP64   = "FP64"
+	datatypeString = "BYTES"
+)
+
+// ValidateDataUpload checks the description of an upload before its file is read
+func ValidateDataUpload(upload *models.DataUpload, format string) error {
+	switch {
+	case upload.ModelID == "":
+		return fmt.Errorf("modelId is required")
+	case upload.Tag == "":
+		return fmt.Errorf("tag is required")
+	case strings.HasPrefix(upload.Tag, reservedTagPrefix):
+		return fmt.Errorf("tags starting with %s are reserved by the TrustyAI service", reservedTagPrefix)
+	case format == models.DataUploadFormatCSV && len(upload.Outputs) == 0:
+		return fmt.Errorf("outputs are required for CSV files")
+	case format == models.DataUploadFormatJSON && len(upload.Outputs) > 0:
+		return fmt.Errorf("outputs only apply to CSV files")
+	}
+	return nil
+}
+
+// tensor is a KServe v2 tensor holding one column
+type tensor struct {
+	Name     string `json:"name"`
+	Shape    []int  `json:"shape"`
+	Datatype string `json:"datatype"`
+	Data     []any  `json:"data"`
+}
+
+// UploadCSV uploads the rows of a CSV file with a header, uploadBatchRows at a time, each
+// column as a tensor of the inputs or outputs of the model. It returns the rows uploaded,
+// which the service keeps when a later batch fails.
+func UploadCSV(ctx context.Context, client Client, file io.Reader, upload models.DataUpload) (int, string, error) {
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return 0, "", fmt.Errorf("%w: the CSV file is empty", ErrInvalidData)
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	header = slices.Clone(header)
+	for _, output := range upload.Outputs {
+		if !slices.Contains(header, output) {
+			return 0, "", fmt.Errorf("%w: output %q is not a column of the CSV file", ErrInvalidData, output)
+		}
+	}
+	if len(upload.Outputs) == len(header) {
+		return 0, "", fmt.Errorf("%w: the CSV file has no input column", ErrInvalidData)
+	}
+
+	var datatypes []string
+	uploaded, message := 0, ""
+	columns := make([][]string, len(header))
+	for {
+		record, err := reader.Read()
+		if err != nil && err != io.EOF {
+			return uploaded, message, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		if err == nil {
+			for i, value := range record {
+				columns[i] = append(columns[i], value)
+			}
+		}
+		rows := len(columns[0])
+		if rows == uploadBatchRows || (err == io.EOF && rows > 0) {
+			if datatypes == nil {
+				datatypes = make([]string, len(columns))
+				for i, column := range columns {
+					datatypes[i] = columnDatatype(column)
+				}
+			}
+			payload, payloadErr := csvPayload(header, columns, datatypes, uploaded, upload)
+			if payloadErr != nil {
+				return uploaded, message, payloadErr
+			}
+			if message, payloadErr = client.UploadData(ctx, bytes.NewReader(payload)); payloadErr != nil {
+				return uploaded, message, payloadErr
+			}
+			uploaded += rows
+			for i := range columns {
+				columns[i] = columns[i][:0]
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if uploaded == 0 {
+		return 0, "", fmt.Errorf("%w: the CSV file has no rows", ErrInvalidData)
+	}
+	return uploaded, message, nil
+}
+
+// columnDatatype is the narrowest datatype of the values of a column
+func columnDatatype(values []string) string {
+	datatype := datatypeBool
+	for _, value := range values {
+		if datatype == datatypeBool {
+			if _, err := strconv.ParseBool(value); err == nil && !isNumber(value) {
+				continue
+			}
+			datatype = datatypeFP64
+		}
+		if !isNumber(value) {
+			return datatypeString
+		}
+	}
+	return datatype
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// csvPayload is the /data/upload payload of a batch of CSV columns. first is the number of
+// rows uploaded before, for errors to name rows as in the file.
+func csvPayload(header []string, columns [][]string, datatypes []string, first int, upload models.DataUpload) ([]byte, error) {
+	inputs, outputs := []tensor{}, []tensor{}
+	for i, column := range columns {
+		data := make([]any, len(column))
+		for j, value := range column {
+			switch datatypes[i] {
+			case datatypeBool:
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: value %q of column %q on row %d is not a boolean", ErrInvalidData, value, header[i], first+j+1)
+				}
+				data[j] = parsed
+			case datatypeFP64:
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: value %q of column %q on row %d is not a number", ErrInvalidData, value, header[i], first+j+1)
+				}
+				data[j] = parsed
+			default:
+				data[j] = value
+			}
+		}
+		column := tensor{Name: header[i], Shape: []int{len(data)}, Datatype: datatypes[i], Data: data}
+		if slices.Contains(upload.Outputs, header[i]) {
+			outputs = append(outputs, column)
+		} else {
+			inputs = append(inputs, column)
+		}
+	}
+	return json.Marshal(map[string]any{
+		"model_name": upload.ModelID,
+		"data_tag":   upload.Tag,
+		"request":    map[string]any{"inputs": inputs},
+		"response":   map[string]any{"model_name": upload.ModelID, "outputs": outputs},
+	})
+}
+
+// UploadJSON streams a JSON file holding the KServe v2 "request" and "response" of
+// inferences to the service, adding the model and tag of the upload
+func UploadJSON(ctx context.Context, client Client, file io.Reader, upload models.DataUpload) (string, error) {
+	fields, err := json.Marshal(map[string]string{"model_name": upload.ModelID, "data_tag": upload.Tag})
+	if err != nil {
+		return "", err
+	}
+
+	reader, writer := io.Pipe()
+	spliced := make(chan error, 1)
+	go func() {
+		err := spliceJSONObject(writer, file, fields[1:len(fields)-1])
+		spliced <- err
+		writer.CloseWithError(err)
+	}()
+	message, err := client.UploadData(ctx, reader)
+	// Unblocks the splice when the service answered before reading the whole file
+	reader.Close()
+	if spliceErr := <-spliced; spliceErr != nil && !errors.Is(spliceErr, io.ErrClosedPipe) {
+		return "", spliceErr
+	}
+	return message, err
+}
+
+// spliceJSONObject copies the JSON object read from src to dst, adding fields, members
+// written as in an object, after its own. Later members win in the service, so fields
+// replace members of the same name.
+func spliceJSONObject(dst io.Writer, src io.Reader, fields []byte) error {
+	in, out := bufio.NewReader(src), bufio.NewWriter(dst)
+	notObject := fmt.Errorf("%w: the JSON file must hold an object", ErrInvalidData)
+
+	opening, err := readNonSpace(in, out)
+	if err == io.EOF || (err == nil && opening != '{') {
+		return notObject
+	}
+	if err != nil {
+		return err
+	}
+	if err := out.WriteByte(opening); err != nil {
+		return err
+	}
+
+	// tail is the last non-space byte read and the spaces after it, written once more
+	// bytes follow, as only the closing brace of the object is left out
+	var tail []byte
+	members := false
+	for {
+		b, err := in.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case isSpace(b) && tail == nil:
+			err = out.WriteByte(b)
+		case isSpace(b):
+			tail = append(tail, b)
+		default:
+			if tail != nil {
+				members = true
+				_, err = out.Write(tail)
+			}
+			tail = append(tail[:0], b)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(tail) == 0 || tail[0] != '}' {
+		return notObject
+	}
+	if !members {
+		return fmt.Errorf("%w: the JSON file holds an empty object", ErrInvalidData)
+	}
+
+	if err := out.WriteByte(','); err != nil {
+		return err
+	}
+	if _, err := out.Write(fields); err != nil {
+		return err
+	}
+	if err := out.WriteByte('}'); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// readNonSpace returns the first byte that is not a JSON space, writing the spaces before it
+func readNonSpace(in *bufio.Reader, out *bufio.Writer) (byte, error) {
+	for {
+		b, err := in.ReadByte()
+		if err != nil || !isSpace(b) {
+			return b, err
+		}
+		if err := out.WriteByte(b); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,10 @@
+// Package bff embeds the OpenAPI description of the BFF API, so the server, the
+// contract test and the client generator all read the same document.
+package bff
+
+import _ "embed"
+
+// OpenAPISpec is the content of openapi.yaml
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
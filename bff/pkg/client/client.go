@@ -0,0 +1,142 @@
+// Package client is a Go client of the TrustyAI dashboard BFF API. The operations
+// and types in client.gen.go are generated from openapi.yaml, run go generate
+// after changing the document.
+package client
+
+//go:generate go run ../../internal/openapi/clientgen -spec ../../openapi.yaml -out client.gen.go -package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client sends requests to a BFF, e.g. New("http://localhost:8080", WithBearerToken(token))
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	userID     string
+}
+
+type Option func(*Client)
+
+// WithBearerToken authenticates requests with a Kubernetes token
+func WithBearerToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithUserID sets the kubeflow-userid header, for BFFs using the internal or mock auth methods
+func WithUserID(userID string) Option {
+	return func(c *Client) { c.userID = userID }
+}
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is returned for responses with a non 2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// Ptr returns a pointer to v, for the optional fields of the generated types
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// RawBody is a request body sent as is, such as the multipart form of a file upload,
+// e.g. built with mime/multipart and streamed through an io.Pipe
+type RawBody struct {
+	ContentType string
+	Reader      io.Reader
+}
+
+// do sends a request and decodes a JSON response into out, or copies the raw
+// response when out is a *[]byte
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	contentType := ""
+	switch body := body.(type) {
+	case nil:
+	case *RawBody:
+		if body != nil {
+			reader, contentType = body.Reader, body.ContentType
+		}
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader, contentType = bytes.NewReader(data), "application/json"
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.userID != "" {
+		req.Header.Set("kubeflow-userid", c.userID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var envelope ErrorEnvelope
+		if json.Unmarshal(data, &envelope) == nil && envelope.Error.Message != "" {
+			apiErr.Message = envelope.Error.Message
+		}
+		return apiErr
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out = data
+		return nil
+	default:
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+}
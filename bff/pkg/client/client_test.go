@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff"
+	"github.com/trustyai-explainability/trustyai-dashboard/bff/internal/openapi"
+)
+
+func TestGeneratedClientIsUpToDate(t *testing.T) {
+	doc, err := openapi.Load(bff.OpenAPISpec)
+	if !assert.NoError(t, err) {
+		return
+	}
+	expected, err := openapi.GenerateClient(doc, "client")
+	if !assert.NoError(t, err) {
+		return
+	}
+	actual, err := os.ReadFile("client.gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "client.gen.go is out of date, run go generate ./pkg/client")
+}
+
+func TestClientSendsCredentialsAndDecodesErrors(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/evaluations/llama-eval/logs" {
+			_, _ = w.Write([]byte(`{"data":{"evaluation":"llama-eval","namespace":"project-1","pod":"llama-eval","logs":"done"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"404","message":"the requested resource could not be found"}}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", WithBearerToken("s3cr3t"), WithUserID("user@example.com"))
+
+	logs, err := c.GetEvaluationLogs(context.Background(), "llama-eval", &GetEvaluationLogsParams{Namespace: "project-1", TailLines: Ptr(10)})
+	assert.NoError(t, err)
+	assert.Equal(t, "done", logs.Data.Logs)
+	assert.Equal(t, "Bearer s3cr3t", received.Header.Get("Authorization"))
+	assert.Equal(t, "user@example.com", received.Header.Get("kubeflow-userid"))
+	assert.Equal(t, "namespace=project-1&tailLines=10", received.URL.RawQuery)
+
+	err = c.DeleteEvaluation(context.Background(), "missing", &DeleteEvaluationParams{Namespace: "project-1"})
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "the requested resource could not be found (HTTP 404)", err.Error())
+	assert.Equal(t, http.MethodDelete, received.Method)
+}
+
+func TestClientStreamsRawBodies(t *testing.T) {
+	var contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"modelId":"credit","tag":"TRAINING","format":"csv","rows":1}}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	result, err := c.UploadData(context.Background(), &UploadDataParams{Namespace: "project-1"}, &RawBody{
+		ContentType: "multipart/form-data; boundary=x",
+		Reader:      strings.NewReader("--x--"),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, *result.Data.Rows)
+	}
+	assert.Equal(t, "multipart/form-data; boundary=x", contentType)
+	assert.Equal(t, "--x--", body)
+}